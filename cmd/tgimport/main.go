@@ -0,0 +1,117 @@
+// tgimport создаёт группу из выгрузки чата Telegram Desktop (JSON).
+//
+//	go run ./cmd/tgimport -user alice -export ./ChatExport_2025-01-31
+//
+// Вместо папки можно передать ZIP-архив с ней. Настройки БД и MinIO
+// берутся из тех же переменных окружения, что и у сервера.
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"flag"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/usecase"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	_ "github.com/lib/pq"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/nats-io/nats.go"
+)
+
+func main() {
+	username := flag.String("user", "", "username владельца импортированной группы")
+	exportPath := flag.String("export", "", "папка выгрузки Telegram с result.json или ZIP-архив с ней")
+	maxFileSize := flag.Int64("max-file-size", config.Import.MaxFileSize, "предельный размер вложения в байтах")
+	maxResultSize := flag.Int64("max-result-size", config.Import.MaxResultSize, "предельный размер result.json в байтах")
+	maxTotalSize := flag.Int64("max-total-size", config.Import.MaxTotalSize, "предельный суммарный размер вложений в байтах")
+	maxFiles := flag.Int("max-files", config.Import.MaxFiles, "предельное число вложений")
+	flag.Parse()
+
+	if *username == "" || *exportPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config.Init()
+	logger := utils.InitLogger()
+	ctx := utils.WithLogger(context.Background(), logger)
+
+	dbConn, err := sql.Open("postgres", config.GetPostgresDSN())
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	defer dbConn.Close()
+	if err := dbConn.Ping(); err != nil {
+		log.Fatal("Failed to ping database:", err)
+	}
+
+	minioClient, err := minio.New(config.GetMinioEndpoint(), &minio.Options{
+		Creds:  credentials.NewStaticV4(config.Minio.AccessKey, config.Minio.SecretKey, ""),
+		Secure: config.Minio.UseSSL,
+	})
+	if err != nil {
+		log.Fatalf("Failed to connect to minio: %v", err)
+	}
+	filesRepo := repository.NewFilesRepo(minioClient, dbConn, config.Minio.Bucket)
+	if filesRepo == nil {
+		log.Fatalf("Failed to open bucket %q", config.Minio.Bucket)
+	}
+
+	// Без NATS импорт тоже работает, клиенты увидят группу после перезагрузки списка чатов
+	nc, err := nats.Connect(config.NATSURL, nats.UserInfo(config.NATSUser, config.NATSPass), nats.Timeout(5*time.Second))
+	if err != nil {
+		log.Printf("NATS unavailable, chat event will not be sent: %v", err)
+		nc = nil
+	} else {
+		defer nc.Close()
+	}
+
+	user, err := repository.NewUserRepo(dbConn).GetUserByUsername(ctx, *username)
+	if err != nil {
+		log.Fatalf("Failed to find user %q: %v", *username, err)
+	}
+
+	export, closeExport, err := openExport(*exportPath)
+	if err != nil {
+		log.Fatalf("Failed to open export: %v", err)
+	}
+	defer closeExport()
+
+	importUsecase := usecase.NewImportUsecase(repository.NewImportRepo(dbConn), filesRepo, repository.NewChatRepo(dbConn), nc, usecase.ImportLimits{
+		MaxFileSize:   *maxFileSize,
+		MaxResultSize: *maxResultSize,
+		MaxTotalSize:  *maxTotalSize,
+		MaxFiles:      *maxFiles,
+	})
+	result, err := importUsecase.ImportTelegram(ctx, user.ID, export)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	log.Printf("Imported %q into chat %s: %d messages, %d files, %d skipped",
+		result.Title, result.ChatID, result.Messages, result.Files, result.Skipped)
+	for _, missing := range result.MissingFiles {
+		log.Printf("Missing file: %s", missing)
+	}
+}
+
+// openExport открывает папку выгрузки или ZIP-архив как fs.FS
+func openExport(path string) (fs.FS, func(), error) {
+	if !strings.HasSuffix(strings.ToLower(path), ".zip") {
+		return os.DirFS(path), func() {}, nil
+	}
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return zr, func() { zr.Close() }, nil
+}
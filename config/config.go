@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -29,7 +30,7 @@ var Cors = struct {
 }{
 	AllowedOrigin:  "http://localhost:8088",
 	AllowedMethods: "GET, POST, PUT, DELETE",
-	AllowedHeaders: "Content-Type, Authorization, X-CSRF-Token, Access-Control-Allow-Credentials, enctype",
+	AllowedHeaders: "Content-Type, Authorization, X-CSRF-Token, Access-Control-Allow-Credentials, enctype, Idempotency-Key",
 }
 
 var (
@@ -56,6 +57,40 @@ var Minio = struct {
 	Bucket    string
 }{}
 
+var LinkPreview = struct {
+	Timeout     time.Duration
+	MaxBodySize int64
+	CacheTTL    time.Duration
+	Workers     int
+	Allow       []string
+	Deny        []string
+}{
+	Timeout:     5 * time.Second,
+	MaxBodySize: 512 << 10, // 512 KB
+	CacheTTL:    time.Hour,
+	Workers:     16,
+}
+
+// Import — ограничения импорта истории из Telegram. Вложения импорта
+// крупнее обычных загрузок: в выгрузке попадаются видео и документы.
+// MaxResultSize, MaxTotalSize и MaxFiles ограничивают данные после распаковки:
+// архив сжат, и MaxArchiveSize их не сдерживает.
+var Import = struct {
+	MaxArchiveSize int64
+	MaxFileSize    int64
+	MaxResultSize  int64
+	MaxTotalSize   int64
+	MaxFiles       int
+	Timeout        time.Duration
+}{
+	MaxArchiveSize: 512 << 20, // 512 MB
+	MaxFileSize:    20 << 20,  // 20 MB
+	MaxResultSize:  256 << 20, // 256 MB
+	MaxTotalSize:   2 << 30,   // 2 GB на все вложения
+	MaxFiles:       10000,
+	Timeout:        10 * time.Minute,
+}
+
 var Redis = struct {
 	Host     string
 	Port     string
@@ -80,6 +115,20 @@ func Init() {
 	Minio.SecretKey = os.Getenv("MINIO_SECRET_KEY")
 	Minio.UseSSL = os.Getenv("MINIO_USE_SSL") == "true"
 	Minio.Bucket = os.Getenv("MINIO_BUCKET")
+
+	LinkPreview.Allow = splitList(os.Getenv("LINK_PREVIEW_ALLOW"))
+	LinkPreview.Deny = splitList(os.Getenv("LINK_PREVIEW_DENY"))
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 func GetPostgresDSN() string {
@@ -6,9 +6,9 @@ CREATE EXTENSION IF NOT EXISTS pg_trgm;
 CREATE EXTENSION IF NOT EXISTS unaccent;
 
 CREATE TYPE chat_type AS ENUM ('dialog', 'group', 'channel');
-CREATE TYPE message_type AS ENUM ('default', 'with_payload', 'sticker');
+CREATE TYPE message_type AS ENUM ('default', 'with_payload', 'sticker', 'poll');
 CREATE TYPE user_type AS ENUM ('owner', 'member');
-CREATE TYPE reaction_type AS ENUM ('like', 'dislike');
+CREATE TYPE export_status AS ENUM ('pending', 'running', 'done', 'failed');
 
 CREATE TABLE IF NOT EXISTS public.user (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -42,8 +42,12 @@ CREATE TABLE IF NOT EXISTS public.chat (
     avatar_path TEXT CHECK (avatar_path IS NULL OR (LENGTH(avatar_path) > 0 AND LENGTH(avatar_path) <= 255)),
     type chat_type NOT NULL,
     title TEXT NOT NULL CHECK (LENGTH(title) > 0 AND LENGTH(title) <= 100),
+    message_ttl INTEGER CHECK (message_ttl IS NULL OR message_ttl > 0),
+    -- Владелец «Избранного»: у каждого пользователя ровно один такой диалог с самим собой
+    saved_by UUID UNIQUE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (saved_by) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
 CREATE TABLE IF NOT EXISTS public.user_chat (
@@ -58,6 +62,15 @@ CREATE TABLE IF NOT EXISTS public.user_chat (
 );
 
 
+CREATE TABLE IF NOT EXISTS public.link_preview (
+    url TEXT PRIMARY KEY CHECK (LENGTH(url) > 0 AND LENGTH(url) <= 2048),
+    title TEXT,
+    description TEXT,
+    image_url TEXT,
+    site_name TEXT,
+    fetched_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE IF NOT EXISTS public.message (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     parent_message_id UUID,
@@ -68,7 +81,20 @@ CREATE TABLE IF NOT EXISTS public.message (
     body TEXT NOT NULL CHECK (LENGTH(body) <= 2000),
     sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP CHECK (sent_at <= CURRENT_TIMESTAMP),
     is_redacted BOOLEAN DEFAULT FALSE,
+    edit_count INTEGER NOT NULL DEFAULT 0,
+    edited_at TIMESTAMP,
+    forwarded_from_user_id UUID,
+    forwarded_from_chat_id UUID,
+    forwarded_sent_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    deleted_at TIMESTAMP,
+    link_preview_url TEXT,
+    -- Имя автора из импортированной истории; сообщение принадлежит импортировавшему
+    imported_author TEXT CHECK (imported_author IS NULL OR (LENGTH(imported_author) > 0 AND LENGTH(imported_author) <= 255)),
     FOREIGN KEY (parent_message_id) REFERENCES public.message(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    FOREIGN KEY (forwarded_from_user_id) REFERENCES public.user(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    FOREIGN KEY (forwarded_from_chat_id) REFERENCES public.chat(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    FOREIGN KEY (link_preview_url) REFERENCES public.link_preview(url) ON DELETE SET NULL ON UPDATE CASCADE,
     FOREIGN KEY (chat_id) REFERENCES public.chat(id) ON DELETE CASCADE ON UPDATE CASCADE,
     FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
 );
@@ -77,13 +103,109 @@ CREATE TABLE IF NOT EXISTS public.message_reaction (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     message_id UUID NOT NULL,
     user_id UUID NOT NULL,
-    reaction reaction_type NOT NULL,
+    reaction TEXT NOT NULL CHECK (LENGTH(reaction) > 0 AND LENGTH(reaction) <= 32),
     reacted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP CHECK (reacted_at <= CURRENT_TIMESTAMP),
     UNIQUE (message_id, user_id),
     FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE,
     FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS public.message_version (
+    message_id UUID NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    body TEXT NOT NULL CHECK (LENGTH(body) <= 2000),
+    created_at TIMESTAMP NOT NULL,
+    replaced_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, version),
+    FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.message_hidden (
+    message_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    hidden_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, message_id),
+    FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.message_client_id (
+    user_id UUID NOT NULL,
+    chat_id UUID NOT NULL,
+    client_message_id TEXT NOT NULL CHECK (LENGTH(client_message_id) > 0 AND LENGTH(client_message_id) <= 64),
+    message_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, chat_id, client_message_id),
+    FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (chat_id) REFERENCES public.chat(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.message_entity (
+    message_id UUID NOT NULL,
+    type TEXT NOT NULL CHECK (LENGTH(type) > 0 AND LENGTH(type) <= 32),
+    entity_offset INTEGER NOT NULL CHECK (entity_offset >= 0),
+    entity_length INTEGER NOT NULL CHECK (entity_length > 0),
+    user_id UUID,
+    url TEXT CHECK (url IS NULL OR LENGTH(url) <= 2048),
+    PRIMARY KEY (message_id, type, entity_offset),
+    FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.message_draft (
+    user_id UUID NOT NULL,
+    chat_id UUID NOT NULL,
+    body TEXT NOT NULL DEFAULT '' CHECK (LENGTH(body) <= 1000),
+    entities JSONB,
+    parent_message_id UUID,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, chat_id),
+    FOREIGN KEY (user_id, chat_id) REFERENCES public.user_chat(user_id, chat_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (parent_message_id) REFERENCES public.message(id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.bookmark (
+    message_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    source_chat_id UUID,
+    source_message_id UUID,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, source_message_id),
+    FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (source_chat_id) REFERENCES public.chat(id) ON DELETE SET NULL ON UPDATE CASCADE,
+    FOREIGN KEY (source_message_id) REFERENCES public.message(id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.poll (
+    message_id UUID PRIMARY KEY,
+    question TEXT NOT NULL CHECK (LENGTH(question) > 0 AND LENGTH(question) <= 300),
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at TIMESTAMPTZ,
+    FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.poll_option (
+    message_id UUID NOT NULL,
+    position SMALLINT NOT NULL CHECK (position >= 0 AND position < 10),
+    text TEXT NOT NULL CHECK (LENGTH(text) > 0 AND LENGTH(text) <= 100),
+    PRIMARY KEY (message_id, position),
+    FOREIGN KEY (message_id) REFERENCES public.poll(message_id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.poll_vote (
+    message_id UUID NOT NULL,
+    position SMALLINT NOT NULL,
+    user_id UUID NOT NULL,
+    voted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, position, user_id),
+    FOREIGN KEY (message_id, position) REFERENCES public.poll_option(message_id, position) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS public.message_view (
     message_id UUID NOT NULL,
     user_id UUID NOT NULL,
@@ -93,6 +215,34 @@ CREATE TABLE IF NOT EXISTS public.message_view (
     FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS public.scheduled_message (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    body TEXT NOT NULL CHECK (LENGTH(body) <= 2000),
+    sticker_path TEXT,
+    parent_message_id UUID,
+    payloads JSONB NOT NULL DEFAULT '[]',
+    entities JSONB NOT NULL DEFAULT '[]',
+    ttl INTEGER CHECK (ttl IS NULL OR ttl > 0),
+    send_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (chat_id) REFERENCES public.chat(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (parent_message_id) REFERENCES public.message(id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.pinned_message (
+    chat_id UUID NOT NULL,
+    message_id UUID NOT NULL,
+    pinned_by UUID,
+    pinned_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chat_id, message_id),
+    FOREIGN KEY (chat_id) REFERENCES public.chat(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (pinned_by) REFERENCES public.user(id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS public.message_payload (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     message_id UUID NOT NULL,
@@ -100,9 +250,30 @@ CREATE TABLE IF NOT EXISTS public.message_payload (
     file_name TEXT NOT NULL CHECK (LENGTH(file_name) > 0 AND LENGTH(file_name) <= 255),
     content_type TEXT NOT NULL,
     file_size INT CHECK (file_size >= 0),
+    -- Только у голосовых: длительность и огибающая громкости для плеера
+    duration_ms INT CHECK (duration_ms >= 0),
+    waveform BYTEA CHECK (LENGTH(waveform) <= 255),
     FOREIGN KEY (message_id) REFERENCES public.message(id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS public.chat_export (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    status export_status NOT NULL DEFAULT 'pending',
+    processed_messages INT NOT NULL DEFAULT 0,
+    total_messages INT NOT NULL DEFAULT 0,
+    processed_files INT NOT NULL DEFAULT 0,
+    total_files INT NOT NULL DEFAULT 0,
+    file_path TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMPTZ,
+    FOREIGN KEY (chat_id) REFERENCES public.chat(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES public.user(id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
 CREATE TABLE IF NOT EXISTS public.sticker (
 	id uuid NOT NULL,
 	sticker_path text NOT NULL,
@@ -134,5 +305,18 @@ CREATE INDEX idx_user_name_trgm ON public.user USING gin (name gin_trgm_ops);
 CREATE INDEX idx_chat_title_trgm ON chat USING gin (title gin_trgm_ops);
 CREATE INDEX idx_message_body_trgm ON message USING gin (body gin_trgm_ops);
 
-CREATE INDEX idx_message_chat_sent_at ON message(chat_id, sent_at DESC);
+CREATE INDEX idx_message_chat_sent_at ON message(chat_id, sent_at DESC, id DESC);
 CREATE INDEX idx_message_user_id ON message(user_id);
+CREATE INDEX idx_message_parent_sent_at ON message(parent_message_id, sent_at) WHERE parent_message_id IS NOT NULL;
+CREATE INDEX idx_message_expires_at ON message(expires_at) WHERE expires_at IS NOT NULL;
+CREATE INDEX idx_message_payload_file_path ON message_payload(file_path);
+CREATE INDEX idx_scheduled_message_send_at ON scheduled_message(send_at);
+CREATE INDEX idx_scheduled_message_chat_user ON scheduled_message(chat_id, user_id);
+CREATE INDEX idx_message_client_id_created_at ON message_client_id(created_at);
+CREATE INDEX idx_message_view_user_id ON message_view(user_id, message_id);
+CREATE INDEX idx_bookmark_user ON bookmark(user_id, created_at DESC);
+CREATE INDEX idx_bookmark_tags ON bookmark USING GIN (tags);
+CREATE INDEX idx_poll_vote_user ON poll_vote(message_id, user_id);
+CREATE INDEX idx_message_entity_mention ON message_entity(user_id, message_id) WHERE type = 'mention';
+CREATE UNIQUE INDEX idx_chat_export_active ON chat_export(chat_id, user_id) WHERE status IN ('pending', 'running');
+CREATE INDEX idx_chat_export_queue ON chat_export(created_at) WHERE status IN ('pending', 'running');
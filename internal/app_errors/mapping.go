@@ -44,21 +44,39 @@ var errToCode = map[error]int{
 	usecase.ErrMessageAccessDenied:     http.StatusForbidden,           // 403
 	usecase.ErrMessageUpdateFailed:     http.StatusInternalServerError, // 500
 	usecase.ErrMessageDeleteFailed:     http.StatusInternalServerError, // 500
+	usecase.ErrInvalidParentMessage:    http.StatusBadRequest,          // 400
+	usecase.ErrMessageEditWindowClosed: http.StatusForbidden,           // 403
+	usecase.ErrNotAPoll:                http.StatusBadRequest,          // 400
+	usecase.ErrPollNotEditable:         http.StatusBadRequest,          // 400
+	usecase.ErrInvalidPollVote:         http.StatusBadRequest,          // 400
+	usecase.ErrScheduledPoll:           http.StatusBadRequest,          // 400
 	usecase.ErrMessagePublishFailed:    http.StatusInternalServerError, // 500
+	usecase.ErrChatPublishFailed:       http.StatusInternalServerError, // 500
 
 	// Repository level
-	repository.ErrSessionNotFound:      http.StatusNotFound,            // 404
-	repository.ErrSelfContact:          http.StatusBadRequest,          // 400
-	repository.ErrContactAlreadyExists: http.StatusConflict,            // 409
-	repository.ErrUserNotFound:         http.StatusNotFound,            // 404
-	repository.ErrChatNotFound:         http.StatusNotFound,            // 404
-	repository.ErrRecordAlreadyExists:  http.StatusConflict,            // 409
-	repository.ErrUpdateFailed:         http.StatusInternalServerError, // 500
-	repository.ErrInvalidUUID:          http.StatusBadRequest,          // 400
-	repository.ErrEmptyField:           http.StatusBadRequest,          // 400
-	repository.ErrDatabaseOperation:    http.StatusInternalServerError, // 500
-	repository.ErrDatabaseScan:         http.StatusInternalServerError, // 500
-	repository.ErrSetNotifications:     http.StatusInternalServerError, // 500
+	repository.ErrSessionNotFound:          http.StatusNotFound,            // 404
+	repository.ErrSelfContact:              http.StatusBadRequest,          // 400
+	repository.ErrContactAlreadyExists:     http.StatusConflict,            // 409
+	repository.ErrUserNotFound:             http.StatusNotFound,            // 404
+	repository.ErrChatNotFound:             http.StatusNotFound,            // 404
+	repository.ErrMessagesNotFound:         http.StatusNotFound,            // 404
+	repository.ErrScheduledMessageNotFound: http.StatusNotFound,            // 404
+	repository.ErrDuplicateClientMessageID: http.StatusConflict,            // 409
+	repository.ErrPollClosed:               http.StatusForbidden,           // 403
+	repository.ErrDraftNotFound:            http.StatusNotFound,            // 404
+	repository.ErrBookmarkExists:           http.StatusConflict,            // 409
+	repository.ErrBookmarkNotFound:         http.StatusNotFound,            // 404
+	repository.ErrNotMessageAuthor:         http.StatusForbidden,           // 403
+	repository.ErrExportInProgress:         http.StatusConflict,            // 409
+	repository.ErrExportNotFound:           http.StatusNotFound,            // 404
+	repository.ErrFileForbidden:            http.StatusForbidden,           // 403
+	repository.ErrRecordAlreadyExists:      http.StatusConflict,            // 409
+	repository.ErrUpdateFailed:             http.StatusInternalServerError, // 500
+	repository.ErrInvalidUUID:              http.StatusBadRequest,          // 400
+	repository.ErrEmptyField:               http.StatusBadRequest,          // 400
+	repository.ErrDatabaseOperation:        http.StatusInternalServerError, // 500
+	repository.ErrDatabaseScan:             http.StatusInternalServerError, // 500
+	repository.ErrSetNotifications:         http.StatusInternalServerError, // 500
 
 	// Utils level
 	utils.ErrNotImage:      http.StatusBadRequest,          // 400
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/app_errors"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	usecase "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/usecase"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/middleware"
+	utils "github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	authpb "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/auth_service/proto"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+	"go.uber.org/zap"
+)
+
+type bookmarkController struct {
+	bookmarkUsecase usecase.IBookmarkUsecase
+	sessionClient   authpb.SessionServiceClient
+}
+
+func NewBookmarkController(r *mux.Router, bookmarkUsecase usecase.IBookmarkUsecase, sessionClient authpb.SessionServiceClient) {
+	controller := &bookmarkController{
+		bookmarkUsecase: bookmarkUsecase,
+		sessionClient:   sessionClient,
+	}
+
+	r.Handle("/saved", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetSavedChat))).Methods(http.MethodGet)
+	r.Handle("/bookmarks", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.SearchBookmarks))).Methods(http.MethodGet)
+	r.Handle("/bookmarks/{message_id}/tags", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.UpdateBookmarkTags))).Methods(http.MethodPut)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/bookmark", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.BookmarkMessage))).Methods(http.MethodPost)
+}
+
+// @Summary Получить «Избранное»
+// @Description Возвращает личный чат пользователя для сохранённых сообщений, создавая его при необходимости
+// @Tags Bookmark
+// @Produce json
+// @Success 200 {object} model.Chat
+// @Failure 401 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /saved [get]
+func (c *bookmarkController) GetSavedChat(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	chat, err := c.bookmarkUsecase.GetSavedChat(r.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to get saved chat", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	resp, err := easyjson.Marshal(chat)
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
+
+// @Summary Сохранить сообщение в «Избранное»
+// @Description Копирует сообщение в «Избранное» со ссылкой на источник; сообщение самого «Избранного» помечается на месте
+// @Tags Bookmark
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения"
+// @Param bookmark body model.BookmarkInput false "Теги"
+// @Success 201 {object} model.Bookmark
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 409 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/bookmark [post]
+func (c *bookmarkController) BookmarkMessage(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.BookmarkInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+	if len(body) > 0 {
+		if err := easyjson.Unmarshal(body, &input); err != nil {
+			logger.Error("Failed to decode bookmark input", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+			return
+		}
+	}
+
+	bookmark, err := c.bookmarkUsecase.BookmarkMessage(r.Context(), userID, chatID, messageID, &input)
+	if err != nil {
+		logger.Error("Failed to bookmark message", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	resp, err := easyjson.Marshal(bookmark)
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusCreated, resp, true)
+}
+
+// @Summary Изменить теги закладки
+// @Description Заменяет теги сохранённого сообщения
+// @Tags Bookmark
+// @Accept json
+// @Produce json
+// @Param message_id path string true "ID сообщения в «Избранном»"
+// @Param bookmark body model.BookmarkInput true "Теги"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /bookmarks/{message_id}/tags [put]
+func (c *bookmarkController) UpdateBookmarkTags(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	messageID, err := uuid.Parse(mux.Vars(r)["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.BookmarkInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode bookmark input", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := c.bookmarkUsecase.UpdateBookmarkTags(r.Context(), userID, messageID, &input); err != nil {
+		logger.Error("Failed to update bookmark tags", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "Tags updated successfully", true)
+}
+
+// @Summary Поиск по «Избранному»
+// @Description Ищет сохранённые сообщения по подстроке текста и тегам; закладка должна иметь все указанные теги
+// @Tags Bookmark
+// @Produce json
+// @Param q query string false "Подстрока текста"
+// @Param tag query []string false "Тег (можно несколько)" collectionFormat(multi)
+// @Param limit query int false "Сколько вернуть (по умолчанию 25, не больше 100)"
+// @Success 200 {object} model.BookmarkList
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /bookmarks [get]
+func (c *bookmarkController) SearchBookmarks(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	query := r.URL.Query()
+	search := model.BookmarkSearch{
+		Query: query.Get("q"),
+		Tags:  query["tag"],
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid limit", false)
+			return
+		}
+		search.Limit = n
+	}
+
+	bookmarks, err := c.bookmarkUsecase.SearchBookmarks(r.Context(), userID, &search)
+	if err != nil {
+		logger.Error("Failed to search bookmarks", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	resp, err := easyjson.Marshal(model.BookmarkList(bookmarks))
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -39,6 +40,9 @@ func NewChatController(r *mux.Router, chatUsecase usecase.IChatUsecase, sessionC
 	r.Handle("/chat/{chat_id}/users", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.RemoveUsersFromChat))).Methods(http.MethodDelete)
 	r.Handle("/chat/{chat_id}/leave", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.LeaveChat))).Methods(http.MethodPost)
 	r.Handle("/chat/{chat_id}/notifications/{send}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.SendNotifications))).Methods(http.MethodPost)
+	r.Handle("/chat/{chat_id}/pins/{message_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.PinMessage))).Methods(http.MethodPost)
+	r.Handle("/chat/{chat_id}/pins/{message_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.UnpinMessage))).Methods(http.MethodDelete)
+	r.Handle("/chat/{chat_id}/ttl", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.SetMessageTTL))).Methods(http.MethodPut)
 }
 
 // GetChats возвращает список чатов пользователя
@@ -462,3 +466,148 @@ func (c *chatController) LeaveChat(w http.ResponseWriter, r *http.Request) {
 
 	utils.SendJSONResponse(w, r, http.StatusOK, "left chat successfully", true)
 }
+
+// PinMessage закрепляет сообщение в чате
+// @Summary Закрепить сообщение
+// @Description Закрепляет сообщение в чате. В группах и каналах доступно только владельцу
+// @Tags Chat
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/pins/{message_id} [post]
+func (c *chatController) PinMessage(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+	logger.Info("PinMessage", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := c.chatUsecase.PinMessage(r.Context(), userID, chatID, messageID); err != nil {
+		logger.Error("Failed to pin message", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "message pinned successfully", true)
+}
+
+// UnpinMessage открепляет сообщение в чате
+// @Summary Открепить сообщение
+// @Description Открепляет сообщение в чате. В группах и каналах доступно только владельцу
+// @Tags Chat
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/pins/{message_id} [delete]
+func (c *chatController) UnpinMessage(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+	logger.Info("UnpinMessage", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := c.chatUsecase.UnpinMessage(r.Context(), userID, chatID, messageID); err != nil {
+		logger.Error("Failed to unpin message", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "message unpinned successfully", true)
+}
+
+// SetMessageTTL задаёт время жизни сообщений чата
+// @Summary Установить время жизни сообщений
+// @Description Новые сообщения чата будут автоматически удалены через ttl секунд; null отключает удаление. В группах и каналах доступно только владельцу
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param ttl body model.ChatTTLInput true "Время жизни в секундах"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/ttl [put]
+func (c *chatController) SetMessageTTL(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	var input model.ChatTTLInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode ttl input", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+	logger.Info("SetMessageTTL", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()))
+
+	if err := c.chatUsecase.SetMessageTTL(r.Context(), userID, chatID, input.TTL); err != nil {
+		logger.Error("Failed to set message ttl", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "message ttl updated successfully", true)
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"io"
+	"net/http"
+
+	apperrors "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/app_errors"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	usecase "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/usecase"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/middleware"
+	utils "github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	authpb "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/auth_service/proto"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+	"go.uber.org/zap"
+)
+
+type draftController struct {
+	draftUsecase  usecase.IDraftUsecase
+	sessionClient authpb.SessionServiceClient
+}
+
+func NewDraftController(r *mux.Router, draftUsecase usecase.IDraftUsecase, sessionClient authpb.SessionServiceClient) {
+	controller := &draftController{
+		draftUsecase:  draftUsecase,
+		sessionClient: sessionClient,
+	}
+
+	r.Handle("/chat/{chat_id}/draft", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetDraft))).Methods(http.MethodGet)
+	r.Handle("/chat/{chat_id}/draft", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.SaveDraft))).Methods(http.MethodPut)
+	r.Handle("/chat/{chat_id}/draft", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.DeleteDraft))).Methods(http.MethodDelete)
+}
+
+// @Summary Получить черновик
+// @Description Возвращает неотправленное сообщение текущего пользователя в чате
+// @Tags Draft
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Success 200 {object} model.Draft
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/draft [get]
+func (c *draftController) GetDraft(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	draft, err := c.draftUsecase.GetDraft(r.Context(), userID, chatID)
+	if err != nil {
+		logger.Error("Failed to get draft", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	sendDraft(w, r, draft)
+}
+
+// @Summary Сохранить черновик
+// @Description Сохраняет черновик и рассылает его на другие устройства событием draftUpdated. Пустой body без parent_message_id удаляет черновик
+// @Tags Draft
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param draft body model.DraftInput true "Текст, разметка и сообщение, на которое отвечают"
+// @Success 200 {object} model.Draft
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/draft [put]
+func (c *draftController) SaveDraft(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.DraftInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode draft input", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	draft, err := c.draftUsecase.SaveDraft(r.Context(), userID, chatID, &input)
+	if err != nil {
+		logger.Error("Failed to save draft", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	sendDraft(w, r, draft)
+}
+
+// @Summary Удалить черновик
+// @Description Удаляет черновик текущего пользователя в чате
+// @Tags Draft
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/draft [delete]
+func (c *draftController) DeleteDraft(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	if err := c.draftUsecase.DeleteDraft(r.Context(), userID, chatID); err != nil {
+		logger.Error("Failed to delete draft", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "Draft deleted successfully", true)
+}
+
+func sendDraft(w http.ResponseWriter, r *http.Request, draft *model.Draft) {
+	resp, err := easyjson.Marshal(draft)
+	if err != nil {
+		utils.GetLoggerFromCtx(r.Context()).Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
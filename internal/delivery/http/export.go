@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+
+	apperrors "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/app_errors"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	usecase "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/usecase"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/middleware"
+	utils "github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	authpb "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/auth_service/proto"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+	"go.uber.org/zap"
+)
+
+type exportController struct {
+	exportUsecase usecase.IExportUsecase
+	sessionClient authpb.SessionServiceClient
+}
+
+func NewExportController(r *mux.Router, exportUsecase usecase.IExportUsecase, sessionClient authpb.SessionServiceClient) {
+	controller := &exportController{
+		exportUsecase: exportUsecase,
+		sessionClient: sessionClient,
+	}
+
+	r.Handle("/chat/{chat_id}/exports", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.RequestExport))).Methods(http.MethodPost)
+	r.Handle("/exports/{export_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetExport))).Methods(http.MethodGet)
+}
+
+// @Summary Выгрузить историю чата
+// @Description Ставит в очередь сборку ZIP-архива с transcript.json, index.html, вложениями и стикерами. Прогресс приходит событием exportUpdated, готовый архив доступен только запросившему по file_url
+// @Tags Export
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Success 202 {object} model.ChatExport
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 409 {object} utils.JSONResponse "Выгрузка этого чата уже идёт"
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/exports [post]
+func (c *exportController) RequestExport(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	export, err := c.exportUsecase.RequestExport(r.Context(), userID, chatID)
+	if err != nil {
+		logger.Error("Failed to request export", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	sendExport(w, r, http.StatusAccepted, export)
+}
+
+// @Summary Статус выгрузки
+// @Description Возвращает прогресс выгрузки и ссылку на архив, когда он готов
+// @Tags Export
+// @Produce json
+// @Param export_id path string true "ID выгрузки"
+// @Success 200 {object} model.ChatExport
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /exports/{export_id} [get]
+func (c *exportController) GetExport(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	exportID, err := uuid.Parse(mux.Vars(r)["export_id"])
+	if err != nil {
+		logger.Error("Invalid export ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid export ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	export, err := c.exportUsecase.GetExport(r.Context(), userID, exportID)
+	if err != nil {
+		logger.Error("Failed to get export", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	sendExport(w, r, http.StatusOK, export)
+}
+
+func sendExport(w http.ResponseWriter, r *http.Request, code int, export *model.ChatExport) {
+	resp, err := easyjson.Marshal(export)
+	if err != nil {
+		utils.GetLoggerFromCtx(r.Context()).Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, code, resp, true)
+}
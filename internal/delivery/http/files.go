@@ -3,12 +3,13 @@ package http
 import (
 	"context"
 	"fmt"
-	"io"
 	"log"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
 	"os"
+	"strings"
 
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/usecase"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/middleware"
@@ -116,13 +117,16 @@ func getMultipartFile(filePath string) (multipart.File, *multipart.FileHeader, e
 
 // GetFile godoc
 // @Summary Получить файл
-// @Description Получить файл по его ID
+// @Description Получить файл по его ID. Поддерживает Range-запросы: аудио можно перематывать, не скачивая целиком
 // @Tags files
 // @Accept json
 // @Produce octet-stream
 // @Param file_id path string true "File ID"
+// @Param Range header string false "Диапазон байт, например bytes=0-1023"
 // @Success 200 {file} file "Файл успешно получен"
+// @Success 206 {file} file "Запрошенная часть файла"
 // @Failure 404 {object} utils.ErrorResponse "Файл не найден"
+// @Failure 416 {string} string "Диапазон вне файла"
 // @Failure 500 {object} utils.ErrorResponse "Ошибка сервера"
 // @Router /files/{file_id} [get]
 func (c *filesController) GetFile(w http.ResponseWriter, r *http.Request) {
@@ -143,16 +147,24 @@ func (c *filesController) GetFile(w http.ResponseWriter, r *http.Request) {
 		utils.SendJSONResponse(w, r, http.StatusNotFound, "File not found", false)
 		return
 	}
+	defer file.Close()
 
-	w.Header().Set("Content-Type", fileData.ContentType)
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileData.Filename))
-
-	if _, err := io.Copy(w, file); err != nil {
-		logger.Error("Failed to send file",
-			zap.String("fileID", fileID.String()),
-			zap.Error(err))
-		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Failed to send file", false)
+	// Аудио открывается во встроенном плеере, остальное скачивается
+	disposition := "attachment"
+	if strings.HasPrefix(fileData.ContentType, "audio/") {
+		disposition = "inline"
 	}
+	if fileData.ContentType != "" {
+		w.Header().Set("Content-Type", fileData.ContentType)
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": fileData.Filename}))
+	if fileData.ETag != "" {
+		w.Header().Set("ETag", `"`+fileData.ETag+`"`)
+	}
+
+	// ServeContent отвечает на Range и If-Range частью файла (206),
+	// а Seek у объекта хранилища читает только нужный диапазон
+	http.ServeContent(w, r, fileData.Filename, fileData.ModTime, file)
 }
 
 // GetStickerPack godoc
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"archive/zip"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config"
+	apperrors "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/app_errors"
+	usecase "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/usecase"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/middleware"
+	utils "github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	authpb "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/auth_service/proto"
+	"github.com/gorilla/mux"
+	"github.com/mailru/easyjson"
+	"go.uber.org/zap"
+)
+
+type importController struct {
+	importUsecase usecase.IImportUsecase
+	sessionClient authpb.SessionServiceClient
+}
+
+func NewImportController(r *mux.Router, importUsecase usecase.IImportUsecase, sessionClient authpb.SessionServiceClient) {
+	controller := &importController{
+		importUsecase: importUsecase,
+		sessionClient: sessionClient,
+	}
+
+	r.Handle("/chat/import/telegram", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.ImportTelegram))).Methods(http.MethodPost)
+}
+
+// @Summary Импорт истории из Telegram
+// @Description Создаёт группу из выгрузки Telegram Desktop в формате JSON. Принимает ZIP-архив папки выгрузки (result.json и медиа). Авторы сохраняются по имени, время отправки — исходное, ответы связываются с исходными сообщениями
+// @Tags Import
+// @Accept multipart/form-data
+// @Produce json
+// @Param archive formData file true "ZIP-архив выгрузки"
+// @Success 201 {object} model.ImportResult
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 413 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/import/telegram [post]
+func (c *importController) ImportTelegram(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	// Загрузка и разбор большой выгрузки не укладываются в общие таймауты сервера
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(config.Import.Timeout)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		logger.Warn("Failed to extend read deadline", zap.Error(err))
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		logger.Warn("Failed to extend write deadline", zap.Error(err))
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, config.Import.MaxArchiveSize)
+	if err := r.ParseMultipartForm(config.MAX_FILE_SIZE); err != nil {
+		logger.Error("Failed to parse multipart form", zap.Error(err))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.SendJSONResponse(w, r, http.StatusRequestEntityTooLarge, "Archive is too large", false)
+			return
+		}
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Malformed multipart form", false)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	archive, header, err := r.FormFile("archive")
+	if err != nil {
+		logger.Error("Missing archive file", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Archive file is required", false)
+		return
+	}
+	defer archive.Close()
+
+	zr, err := zip.NewReader(archive, header.Size)
+	if err != nil {
+		logger.Error("Invalid archive", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Archive must be a ZIP file", false)
+		return
+	}
+
+	result, err := c.importUsecase.ImportTelegram(r.Context(), userID, zr)
+	if err != nil {
+		logger.Error("Failed to import chat", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	resp, err := easyjson.Marshal(result)
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusCreated, resp, true)
+}
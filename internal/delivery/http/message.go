@@ -3,6 +3,8 @@ package http
 import (
 	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config"
 	apperrors "github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/app_errors"
@@ -29,18 +31,36 @@ func NewMessageController(r *mux.Router, messageUsecase usecase.IMessageUsecase,
 	}
 
 	r.Handle("/chat/{chat_id}/messages", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetMessageHistory))).Methods(http.MethodGet)
-	r.Handle("/chat/{chat_id}/messages/{direction}/{last_message_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetPaginatedMessageHistory))).Methods(http.MethodGet)
+	r.Handle("/chat/{chat_id}/messages/around", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetMessageWindow))).Methods(http.MethodGet)
 	r.Handle("/chat/{chat_id}/messages", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.SendMessage))).Methods(http.MethodPost)
+	r.Handle("/chat/{chat_id}/scheduled", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetScheduledMessages))).Methods(http.MethodGet)
+	r.Handle("/chat/{chat_id}/scheduled/{scheduled_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.UpdateScheduledMessage))).Methods(http.MethodPut)
+	r.Handle("/chat/{chat_id}/scheduled/{scheduled_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.CancelScheduledMessage))).Methods(http.MethodDelete)
+	r.Handle("/chat/{chat_id}/messages", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.ClearHistory))).Methods(http.MethodDelete)
+	r.Handle("/chat/{chat_id}/messages/forward", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.ForwardMessages))).Methods(http.MethodPost)
+	r.Handle("/chat/{chat_id}/messages/delete", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.DeleteMessages))).Methods(http.MethodPost)
 	r.Handle("/chat/{chat_id}/messages/{message_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.UpdateMessage))).Methods(http.MethodPut)
 	r.Handle("/chat/{chat_id}/messages/{message_id}", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.DeleteMessage))).Methods(http.MethodDelete)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/reactions", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.SetReaction))).Methods(http.MethodPut)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/reactions", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.RemoveReaction))).Methods(http.MethodDelete)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/poll", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetPoll))).Methods(http.MethodGet)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/poll/votes", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.VotePoll))).Methods(http.MethodPut)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/poll/votes", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.RetractPollVote))).Methods(http.MethodDelete)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/replies", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetReplies))).Methods(http.MethodGet)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/revisions", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetMessageRevisions))).Methods(http.MethodGet)
+	r.Handle("/chat/{chat_id}/messages/{message_id}/read", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.MarkRead))).Methods(http.MethodPost)
+	r.Handle("/chat/{chat_id}/mentions/next", middleware.AuthMiddleware(sessionClient)(http.HandlerFunc(controller.GetNextMention))).Methods(http.MethodGet)
 }
 
 // @Summary Получить историю сообщений в чате
-// @Description Возвращает все сообщения в чате по chat_id
+// @Description Возвращает страницу сообщений чата от новых к старым. Без курсоров — последние сообщения; before листает к старым, after — к новым
 // @Tags Message
 // @Produce json
 // @Param chat_id path string true "ID чата"
-// @Success 200 {object} utils.JSONResponse
+// @Param before query string false "Курсор older_cursor предыдущей страницы"
+// @Param after query string false "Курсор newer_cursor предыдущей страницы"
+// @Param limit query int false "Размер страницы (по умолчанию 25, не больше 100)"
+// @Success 200 {object} model.MessagePage
 // @Failure 400 {object} utils.JSONResponse
 // @Failure 403 {object} utils.JSONResponse
 // @Failure 500 {object} utils.JSONResponse
@@ -57,19 +77,39 @@ func (c *messageController) GetMessageHistory(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	var query model.HistoryQuery
+	params := r.URL.Query()
+	if before := params.Get("before"); before != "" {
+		if query.Before, err = model.ParseMessageCursor(before); err != nil {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid before cursor", false)
+			return
+		}
+	}
+	if after := params.Get("after"); after != "" {
+		if query.After, err = model.ParseMessageCursor(after); err != nil {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid after cursor", false)
+			return
+		}
+	}
+	if limit := params.Get("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid limit", false)
+			return
+		}
+	}
+
 	userID := utils.GetUserIDFromCtx(r.Context())
 	logger.Info("GetMessageHistory", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()))
 
 	// Получаем историю
-	messages, err := c.messageUsecase.GetChatMessages(r.Context(), userID, chatID)
+	page, err := c.messageUsecase.GetMessageHistory(r.Context(), userID, chatID, &query)
 	if err != nil {
 		logger.Error("Failed to get message history", zap.Error(err))
 		code, msg := apperrors.GetErrAndCodeToSend(err)
 		utils.SendJSONResponse(w, r, code, msg, false)
 		return
 	}
-	messageList := model.MessageList(messages)
-	resp, err := easyjson.Marshal(messageList)
+	resp, err := easyjson.Marshal(page)
 	if err != nil {
 		logger.Error("Marshaling error", zap.Error(err))
 		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
@@ -78,66 +118,65 @@ func (c *messageController) GetMessageHistory(w http.ResponseWriter, r *http.Req
 	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
 }
 
-// @Summary Получить страницу сообщений в чате
-// @Description Возвращает порцию сообщений чата начиная с last_message_id (исключительно), используется для пагинации
+// @Summary Получить окно истории вокруг сообщения или даты
+// @Description Возвращает до limit сообщений до опорной точки и до limit после неё, от новых к старым. anchor_id — сообщение, к которому нужно прокрутить; курсоры продолжают листание через GET /chat/{chat_id}/messages
 // @Tags Message
 // @Produce json
 // @Param chat_id path string true "ID чата"
-// @Param last_message_id path string true "ID последнего сообщения на предыдущей странице"
-// @Success 200 {object} utils.JSONResponse
+// @Param message_id query string false "ID сообщения, вокруг которого строится окно"
+// @Param date query string false "Момент времени (RFC3339), вокруг которого строится окно"
+// @Param limit query int false "Сколько сообщений с каждой стороны (по умолчанию 20, не больше 50)"
+// @Success 200 {object} model.MessagePage
 // @Failure 400 {object} utils.JSONResponse
 // @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
 // @Failure 500 {object} utils.JSONResponse
-// @Router /chat/{chat_id}/messages/{direction}/{last_message_id} [get]
-func (c *messageController) GetPaginatedMessageHistory(w http.ResponseWriter, r *http.Request) {
+// @Router /chat/{chat_id}/messages/around [get]
+func (c *messageController) GetMessageWindow(w http.ResponseWriter, r *http.Request) {
 	logger := utils.GetLoggerFromCtx(r.Context())
 
-	vars := mux.Vars(r)
-
-	chatID, err := uuid.Parse(vars["chat_id"])
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
 	if err != nil {
 		logger.Error("Invalid chat ID format", zap.Error(err))
 		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
 		return
 	}
 
-	lastMessageID, err := uuid.Parse(vars["last_message_id"])
-	if err != nil {
-		logger.Error("Invalid last message ID format", zap.Error(err))
-		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid last message ID", false)
-		return
+	var query model.WindowQuery
+	params := r.URL.Query()
+	if messageID := params.Get("message_id"); messageID != "" {
+		id, err := uuid.Parse(messageID)
+		if err != nil {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+			return
+		}
+		query.MessageID = &id
 	}
-
-	direction := vars["direction"]
-	if direction != "up" && direction != "down" {
-		logger.Error("Invalid direction", zap.String("direction", direction))
-		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid direction: must be 'up' or 'down'", false)
-		return
+	if date := params.Get("date"); date != "" {
+		t, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid date: must be RFC3339", false)
+			return
+		}
+		query.Date = &t
+	}
+	if limit := params.Get("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid limit", false)
+			return
+		}
 	}
 
 	userID := utils.GetUserIDFromCtx(r.Context())
-	logger.Info("GetPaginatedMessageHistory",
-		zap.String("direction", direction),
-		zap.String("chatID", chatID.String()),
-		zap.String("lastMessageID", lastMessageID.String()),
-		zap.String("userID", userID.String()),
-	)
-
-	var messages []model.Message
-	if direction == "up" {
-		messages, err = c.messageUsecase.GetMessagesBefore(r.Context(), userID, chatID, lastMessageID)
-	} else {
-		messages, err = c.messageUsecase.GetMessagesAfter(r.Context(), userID, chatID, lastMessageID)
-	}
 
+	page, err := c.messageUsecase.GetMessageWindow(r.Context(), userID, chatID, &query)
 	if err != nil {
-		logger.Error("Failed to get paginated message history", zap.Error(err))
+		logger.Error("Failed to get message window", zap.Error(err))
 		code, msg := apperrors.GetErrAndCodeToSend(err)
 		utils.SendJSONResponse(w, r, code, msg, false)
 		return
 	}
-	messageList := model.MessageList(messages)
-	resp, err := easyjson.Marshal(messageList)
+	resp, err := easyjson.Marshal(page)
 	if err != nil {
 		logger.Error("Marshaling error", zap.Error(err))
 		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
@@ -152,11 +191,18 @@ func (c *messageController) GetPaginatedMessageHistory(w http.ResponseWriter, r
 // @Accept multipart/form-data
 // @Produce json
 // @Param chat_id path string true "ID чата"
-// @Param text formData string false "Текст сообщения"
+// @Param text formData string false "JSON model.MessageInput: текст и разметка entities (bold, italic, code, link, spoiler)"
 // @Param sticker formData string false "Стикер (URL или ID)"
+// @Param poll formData string false "JSON model.PollInput: вопрос, варианты, multiple_choice, anonymous, closes_at"
 // @Param files formData file false "Файлы (можно несколько)"
 // @Param photos formData file false "Фотографии (можно несколько)"
-// @Success 201 {object} utils.JSONResponse
+// @Param voice formData file false "Голосовое сообщение: Ogg/Opus, WebM, M4A, MP3 или WAV; сочетается только с текстом"
+// @Param parent_message_id formData string false "ID сообщения, на которое отвечают"
+// @Param send_at formData string false "Время отложенной отправки (RFC3339)"
+// @Param ttl formData int false "Время жизни сообщения в секундах"
+// @Param client_message_id formData string false "Клиентский ID для безопасного повтора (можно передать в заголовке Idempotency-Key)"
+// @Param Idempotency-Key header string false "Клиентский ID для безопасного повтора"
+// @Success 201 {object} model.Message "Опубликованное сообщение либо model.ScheduledMessage, если задан send_at"
 // @Failure 400 {object} utils.JSONResponse
 // @Failure 401 {object} utils.JSONResponse
 // @Failure 500 {object} utils.JSONResponse
@@ -198,10 +244,61 @@ func (c *messageController) SendMessage(w http.ResponseWriter, r *http.Request)
 
 	var msg model.Message
 	msg.Body = input.Message
+	msg.Entities = input.Entities
 	msg.ChatID = chatID
 	msg.UserID = userID
 	msg.Sticker = sticker
 
+	if data := r.FormValue("poll"); data != "" {
+		var poll model.PollInput
+		if err := easyjson.Unmarshal([]byte(data), &poll); err != nil {
+			logger.Error("Invalid poll format", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid poll format", false)
+			return
+		}
+		msg.PollInput = &poll
+	}
+
+	if parent := r.FormValue("parent_message_id"); parent != "" {
+		parentID, err := uuid.Parse(parent)
+		if err != nil {
+			logger.Error("Invalid parent message ID format", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid parent message ID", false)
+			return
+		}
+		msg.ParentMessageID = &parentID
+	}
+
+	if sendAt := r.FormValue("send_at"); sendAt != "" {
+		t, err := time.Parse(time.RFC3339, sendAt)
+		if err != nil {
+			logger.Error("Invalid send_at format", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid send_at, RFC3339 expected", false)
+			return
+		}
+		msg.SendAt = &t
+	}
+
+	// Ключ идемпотентности: поле формы или заголовок, при обоих они должны совпадать
+	msg.ClientMessageID = r.FormValue("client_message_id")
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		if msg.ClientMessageID != "" && msg.ClientMessageID != key {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "client_message_id does not match Idempotency-Key", false)
+			return
+		}
+		msg.ClientMessageID = key
+	}
+
+	if ttl := r.FormValue("ttl"); ttl != "" {
+		seconds, err := strconv.Atoi(ttl)
+		if err != nil {
+			logger.Error("Invalid ttl format", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid ttl, seconds expected", false)
+			return
+		}
+		msg.TTL = &seconds
+	}
+
 	files := r.MultipartForm.File["files"]
 	for _, header := range files {
 		file, err := header.Open()
@@ -230,19 +327,49 @@ func (c *messageController) SendMessage(w http.ResponseWriter, r *http.Request)
 		msg.PhotosHeaders = append(msg.PhotosHeaders, header)
 	}
 
+	if voices := r.MultipartForm.File["voice"]; len(voices) > 0 {
+		if len(voices) > 1 {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Only one voice per message", false)
+			return
+		}
+		voice, err := voices[0].Open()
+		if err != nil {
+			logger.Error("Failed to open voice", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Failed to open voice", false)
+			return
+		}
+		defer voice.Close()
+
+		msg.Voice = voice
+		msg.VoiceHeader = voices[0]
+	}
+
 	// Вызываем usecase
-	if err := c.messageUsecase.SendMessage(r.Context(), &msg, userID, chatID); err != nil {
+	saved, scheduled, err := c.messageUsecase.SendMessage(r.Context(), &msg, userID, chatID)
+	if err != nil {
 		logger.Error("Failed to send message", zap.Error(err))
 		code, msg := apperrors.GetErrAndCodeToSend(err)
 		utils.SendJSONResponse(w, r, code, msg, false)
 		return
 	}
 
-	utils.SendJSONResponse(w, r, http.StatusCreated, "Message sent successfully", true)
+	// Для отложенного сообщения отдаём запись очереди: по её ID его можно изменить или отменить
+	var resp []byte
+	if scheduled != nil {
+		resp, err = easyjson.Marshal(scheduled)
+	} else {
+		resp, err = easyjson.Marshal(saved)
+	}
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusCreated, resp, true)
 }
 
 // @Summary Обновить сообщение в чате
-// @Description Обновляет сообщение пользователя в чате
+// @Description Обновляет сообщение пользователя в чате. Прежний текст сохраняется в истории правок; править можно в течение 48 часов после отправки
 // @Tags Message
 // @Accept json
 // @Produce json
@@ -300,14 +427,17 @@ func (c *messageController) UpdateMessage(w http.ResponseWriter, r *http.Request
 }
 
 // @Summary Удалить сообщение в чате
-// @Description Удаляет сообщение пользователя из чата
+// @Description В режиме me скрывает любое сообщение только для текущего пользователя.
+// @Description В режиме everyone (по умолчанию) оставляет надгробие у всех; доступно автору и владельцу группы или канала
 // @Tags Message
 // @Produce json
 // @Param chat_id path string true "ID чата"
 // @Param message_id path string true "ID сообщения"
+// @Param mode query string false "Режим удаления: me или everyone"
 // @Success 200 {object} utils.JSONResponse
 // @Failure 400 {object} utils.JSONResponse
 // @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
 // @Failure 500 {object} utils.JSONResponse
 // @Router /chat/{chat_id}/messages/{message_id} [delete]
 func (c *messageController) DeleteMessage(w http.ResponseWriter, r *http.Request) {
@@ -328,11 +458,20 @@ func (c *messageController) DeleteMessage(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	mode := model.DeleteForEveryone
+	if m := r.URL.Query().Get("mode"); m != "" {
+		mode = model.DeleteMode(m)
+		if !mode.IsValid() {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid delete mode", false)
+			return
+		}
+	}
+
 	userID := utils.GetUserIDFromCtx(r.Context())
 
-	logger.Info("DeleteMessage", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+	logger.Info("DeleteMessage", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()), zap.String("mode", string(mode)))
 
-	if err := c.messageUsecase.DeleteMessage(r.Context(), messageID, userID, chatID); err != nil {
+	if err := c.messageUsecase.DeleteMessage(r.Context(), messageID, userID, chatID, mode); err != nil {
 		logger.Error("Failed to delete message", zap.Error(err))
 		code, msg := apperrors.GetErrAndCodeToSend(err)
 		utils.SendJSONResponse(w, r, code, msg, false)
@@ -341,3 +480,772 @@ func (c *messageController) DeleteMessage(w http.ResponseWriter, r *http.Request
 
 	utils.SendJSONResponse(w, r, http.StatusOK, "Message deleted successfully", true)
 }
+
+// @Summary Удалить несколько сообщений
+// @Description Удаляет до 100 сообщений одной операцией и рассылает одно событие deleteMessages.
+// @Description В режиме me сообщения скрываются только у текущего пользователя; в режиме everyone (по умолчанию) удалять можно свои сообщения, а владелец группы или канала — любые. Удаляется всё или ничего
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param delete body model.BulkDeleteInput true "ID сообщений и режим удаления"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/delete [post]
+func (c *messageController) DeleteMessages(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.BulkDeleteInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode bulk delete input", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid delete data format", false)
+		return
+	}
+
+	logger.Info("DeleteMessages", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.Int("count", len(input.MessageIDs)))
+
+	if err := c.messageUsecase.DeleteMessages(r.Context(), &input, userID, chatID); err != nil {
+		logger.Error("Failed to delete messages", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "Messages deleted successfully", true)
+}
+
+// @Summary Очистить историю чата
+// @Description В режиме me скрывает всю историю только для текущего пользователя; доступно любому участнику.
+// @Description В режиме everyone удаляет все сообщения чата вместе с файлами; доступно владельцу группы или канала. Рассылается одно событие clearHistory
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param mode query string false "Режим: me (по умолчанию) или everyone"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages [delete]
+func (c *messageController) ClearHistory(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	// Очистка для всех необратима, поэтому по умолчанию история скрывается только у себя
+	mode := model.DeleteForMe
+	if m := r.URL.Query().Get("mode"); m != "" {
+		mode = model.DeleteMode(m)
+		if !mode.IsValid() {
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid delete mode", false)
+			return
+		}
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+	logger.Info("ClearHistory", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("mode", string(mode)))
+
+	if err := c.messageUsecase.ClearHistory(r.Context(), mode, userID, chatID); err != nil {
+		logger.Error("Failed to clear history", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "History cleared successfully", true)
+}
+
+// @Summary Поставить реакцию на сообщение
+// @Description Ставит или меняет реакцию текущего пользователя на сообщение
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения"
+// @Param reaction body model.ReactionInput true "Реакция"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/reactions [put]
+func (c *messageController) SetReaction(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.ReactionInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode reaction input", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	logger.Info("SetReaction", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := c.messageUsecase.SetReaction(r.Context(), messageID, &input, userID, chatID); err != nil {
+		logger.Error("Failed to set reaction", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "Reaction set successfully", true)
+}
+
+// @Summary Убрать реакцию с сообщения
+// @Description Удаляет реакцию текущего пользователя с сообщения
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/reactions [delete]
+func (c *messageController) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	logger.Info("RemoveReaction", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := c.messageUsecase.RemoveReaction(r.Context(), messageID, userID, chatID); err != nil {
+		logger.Error("Failed to remove reaction", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "Reaction removed successfully", true)
+}
+
+// @Summary Получить опрос
+// @Description Возвращает опрос с итогами и голосами текущего пользователя (my_votes)
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения с опросом"
+// @Success 200 {object} model.Poll
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/poll [get]
+func (c *messageController) GetPoll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	poll, err := c.messageUsecase.GetPoll(r.Context(), userID, chatID, messageID)
+	if err != nil {
+		logger.Error("Failed to get poll", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	sendPoll(w, r, poll)
+}
+
+// @Summary Проголосовать в опросе
+// @Description Заменяет голос текущего пользователя выбранными вариантами; итоги рассылаются событием updatePoll
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения с опросом"
+// @Param vote body model.PollVoteInput true "Позиции выбранных вариантов"
+// @Success 200 {object} model.Poll
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/poll/votes [put]
+func (c *messageController) VotePoll(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.PollVoteInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode poll vote", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	logger.Info("VotePoll", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	poll, err := c.messageUsecase.VotePoll(r.Context(), userID, chatID, messageID, &input)
+	if err != nil {
+		logger.Error("Failed to vote in poll", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	sendPoll(w, r, poll)
+}
+
+// @Summary Отозвать голос в опросе
+// @Description Удаляет голос текущего пользователя, пока опрос открыт
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения с опросом"
+// @Success 200 {object} model.Poll
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/poll/votes [delete]
+func (c *messageController) RetractPollVote(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	logger.Info("RetractPollVote", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	poll, err := c.messageUsecase.RetractPollVote(r.Context(), userID, chatID, messageID)
+	if err != nil {
+		logger.Error("Failed to retract poll vote", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	sendPoll(w, r, poll)
+}
+
+func sendPoll(w http.ResponseWriter, r *http.Request, poll *model.Poll) {
+	resp, err := easyjson.Marshal(poll)
+	if err != nil {
+		utils.GetLoggerFromCtx(r.Context()).Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
+
+// @Summary Отметить сообщения прочитанными
+// @Description Отмечает прочитанными все сообщения чата вплоть до message_id включительно
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID последнего прочитанного сообщения"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/read [post]
+func (c *messageController) MarkRead(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	logger.Info("MarkRead", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := c.messageUsecase.MarkRead(r.Context(), messageID, userID, chatID); err != nil {
+		logger.Error("Failed to mark messages as read", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "Messages marked as read", true)
+}
+
+// @Summary Получить ответы на сообщение
+// @Description Возвращает ответы на сообщение в хронологическом порядке, страницами
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID родительского сообщения"
+// @Param after query string false "ID последнего полученного ответа"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/replies [get]
+func (c *messageController) GetReplies(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	var afterReplyID *uuid.UUID
+	if after := r.URL.Query().Get("after"); after != "" {
+		id, err := uuid.Parse(after)
+		if err != nil {
+			logger.Error("Invalid after ID format", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid after ID", false)
+			return
+		}
+		afterReplyID = &id
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	logger.Info("GetReplies", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	replies, err := c.messageUsecase.GetReplies(r.Context(), userID, chatID, messageID, afterReplyID)
+	if err != nil {
+		logger.Error("Failed to get replies", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+	resp, err := easyjson.Marshal(model.MessageList(replies))
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
+
+// @Summary Получить историю правок сообщения
+// @Description Возвращает все версии текста сообщения, от исходной до текущей
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param message_id path string true "ID сообщения"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/{message_id}/revisions [get]
+func (c *messageController) GetMessageRevisions(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	messageID, err := uuid.Parse(vars["message_id"])
+	if err != nil {
+		logger.Error("Invalid message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	logger.Info("GetMessageRevisions", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	revisions, err := c.messageUsecase.GetMessageRevisions(r.Context(), userID, chatID, messageID)
+	if err != nil {
+		logger.Error("Failed to get message revisions", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+	resp, err := easyjson.Marshal(model.MessageRevisionList(revisions))
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
+
+// @Summary Перейти к следующему упоминанию
+// @Description Возвращает самое раннее непрочитанное сообщение чата, где упомянут пользователь. С after — следующее после указанного сообщения
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param after query string false "ID сообщения, после которого искать"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/mentions/next [get]
+func (c *messageController) GetNextMention(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	var afterMessageID *uuid.UUID
+	if after := r.URL.Query().Get("after"); after != "" {
+		id, err := uuid.Parse(after)
+		if err != nil {
+			logger.Error("Invalid after ID format", zap.Error(err))
+			utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid after ID", false)
+			return
+		}
+		afterMessageID = &id
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	logger.Info("GetNextMention", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()))
+
+	message, err := c.messageUsecase.GetNextMention(r.Context(), userID, chatID, afterMessageID)
+	if err != nil {
+		logger.Error("Failed to get next mention", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+	resp, err := easyjson.Marshal(message)
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
+
+// @Summary Переслать сообщения
+// @Description Копирует сообщения из другого чата в chat_id с указанием первоисточника
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID целевого чата"
+// @Param forward body model.ForwardInput true "Исходный чат и ID сообщений"
+// @Success 201 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/messages/forward [post]
+func (c *messageController) ForwardMessages(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.ForwardInput
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode forward input", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid forward data format", false)
+		return
+	}
+
+	logger.Info("ForwardMessages", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.Int("count", len(input.MessageIDs)))
+
+	if err := c.messageUsecase.ForwardMessages(r.Context(), &input, userID, chatID); err != nil {
+		logger.Error("Failed to forward messages", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusCreated, "Messages forwarded successfully", true)
+}
+
+// @Summary Получить отложенные сообщения
+// @Description Возвращает отложенные сообщения текущего пользователя в чате
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/scheduled [get]
+func (c *messageController) GetScheduledMessages(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	chatID, err := uuid.Parse(mux.Vars(r)["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+	logger.Info("GetScheduledMessages", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()))
+
+	messages, err := c.messageUsecase.GetScheduledMessages(r.Context(), userID, chatID)
+	if err != nil {
+		logger.Error("Failed to get scheduled messages", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+	resp, err := easyjson.Marshal(model.ScheduledMessageList(messages))
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
+
+// @Summary Изменить отложенное сообщение
+// @Description Меняет текст и/или время отправки отложенного сообщения
+// @Tags Message
+// @Accept json
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param scheduled_id path string true "ID отложенного сообщения"
+// @Param update body model.ScheduledMessageUpdate true "Новый текст и/или время отправки"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/scheduled/{scheduled_id} [put]
+func (c *messageController) UpdateScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	scheduledID, err := uuid.Parse(vars["scheduled_id"])
+	if err != nil {
+		logger.Error("Invalid scheduled message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid scheduled message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+
+	var input model.ScheduledMessageUpdate
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("Failed to read request body", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid request body", false)
+		return
+	}
+
+	if err := easyjson.Unmarshal(body, &input); err != nil {
+		logger.Error("Failed to decode scheduled message update", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid update data format", false)
+		return
+	}
+
+	logger.Info("UpdateScheduledMessage", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("scheduledID", scheduledID.String()))
+
+	updated, err := c.messageUsecase.UpdateScheduledMessage(r.Context(), userID, chatID, scheduledID, &input)
+	if err != nil {
+		logger.Error("Failed to update scheduled message", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+	resp, err := easyjson.Marshal(updated)
+	if err != nil {
+		logger.Error("Marshaling error", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusInternalServerError, "Internal error", false)
+		return
+	}
+	utils.SendJSONResponse(w, r, http.StatusOK, resp, true)
+}
+
+// @Summary Отменить отложенное сообщение
+// @Description Удаляет отложенное сообщение вместе с загруженными вложениями
+// @Tags Message
+// @Produce json
+// @Param chat_id path string true "ID чата"
+// @Param scheduled_id path string true "ID отложенного сообщения"
+// @Success 200 {object} utils.JSONResponse
+// @Failure 400 {object} utils.JSONResponse
+// @Failure 403 {object} utils.JSONResponse
+// @Failure 404 {object} utils.JSONResponse
+// @Failure 500 {object} utils.JSONResponse
+// @Router /chat/{chat_id}/scheduled/{scheduled_id} [delete]
+func (c *messageController) CancelScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	logger := utils.GetLoggerFromCtx(r.Context())
+
+	vars := mux.Vars(r)
+	chatID, err := uuid.Parse(vars["chat_id"])
+	if err != nil {
+		logger.Error("Invalid chat ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid chat ID", false)
+		return
+	}
+
+	scheduledID, err := uuid.Parse(vars["scheduled_id"])
+	if err != nil {
+		logger.Error("Invalid scheduled message ID format", zap.Error(err))
+		utils.SendJSONResponse(w, r, http.StatusBadRequest, "Invalid scheduled message ID", false)
+		return
+	}
+
+	userID := utils.GetUserIDFromCtx(r.Context())
+	logger.Info("CancelScheduledMessage", zap.String("chatID", chatID.String()), zap.String("userID", userID.String()), zap.String("scheduledID", scheduledID.String()))
+
+	if err := c.messageUsecase.CancelScheduledMessage(r.Context(), userID, chatID, scheduledID); err != nil {
+		logger.Error("Failed to cancel scheduled message", zap.Error(err))
+		code, msg := apperrors.GetErrAndCodeToSend(err)
+		utils.SendJSONResponse(w, r, code, msg, false)
+		return
+	}
+
+	utils.SendJSONResponse(w, r, http.StatusOK, "Scheduled message cancelled", true)
+}
@@ -0,0 +1,103 @@
+//go:generate easyjson -all bookmark.go
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxBookmarkTags      = 10
+	MaxBookmarkTagLength = 32
+)
+
+var bookmarkTagPattern = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+// Bookmark — сообщение, сохранённое в «Избранное», со ссылкой на оригинал.
+// Источник обнуляется, если исходное сообщение или чат удалены.
+//
+//easyjson:json
+type Bookmark struct {
+	Message         Message    `json:"message"`
+	SourceChatID    *uuid.UUID `json:"source_chat_id,omitempty"`
+	SourceMessageID *uuid.UUID `json:"source_message_id,omitempty"`
+	Tags            []string   `json:"tags"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+//easyjson:json
+type BookmarkList []Bookmark
+
+//easyjson:json
+type BookmarkInput struct {
+	Tags []string `json:"tags"`
+}
+
+func (b *BookmarkInput) Validate() error {
+	tags, err := NormalizeTags(b.Tags)
+	if err != nil {
+		return err
+	}
+	b.Tags = tags
+	return nil
+}
+
+// BookmarkSearch — фильтр поиска по «Избранному»: подстрока текста и теги,
+// которые должны быть у закладки все одновременно
+type BookmarkSearch struct {
+	Query string
+	Tags  []string
+	Limit int
+}
+
+const (
+	DefaultBookmarkSearchLimit = 25
+	MaxBookmarkSearchLimit     = 100
+)
+
+func (s *BookmarkSearch) Validate() error {
+	s.Query = strings.TrimSpace(s.Query)
+	if len([]rune(s.Query)) > 100 {
+		return errors.Join(ErrValidation, errors.New("search query is too long"))
+	}
+	tags, err := NormalizeTags(s.Tags)
+	if err != nil {
+		return err
+	}
+	s.Tags = tags
+	if s.Limit <= 0 {
+		s.Limit = DefaultBookmarkSearchLimit
+	}
+	if s.Limit > MaxBookmarkSearchLimit {
+		s.Limit = MaxBookmarkSearchLimit
+	}
+	return nil
+}
+
+// NormalizeTags приводит теги к нижнему регистру, убирает «#» и повторы
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" || len([]rune(tag)) > MaxBookmarkTagLength || !bookmarkTagPattern.MatchString(tag) {
+			return nil, errors.Join(ErrValidation, fmt.Errorf("invalid tag %q", tag))
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxBookmarkTags {
+		return nil, errors.Join(ErrValidation, fmt.Errorf("at most %d tags allowed", MaxBookmarkTags))
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
@@ -0,0 +1,444 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(in *jlexer.Lexer, out *BookmarkSearch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Query":
+			out.Query = string(in.String())
+		case "Tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Tags = append(out.Tags, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "Limit":
+			out.Limit = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(out *jwriter.Writer, in BookmarkSearch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Query\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Query))
+	}
+	{
+		const prefix string = ",\"Tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Tags {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BookmarkSearch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BookmarkSearch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BookmarkSearch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BookmarkSearch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(l, v)
+}
+func easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *BookmarkList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(BookmarkList, 0, 0)
+			} else {
+				*out = BookmarkList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 Bookmark
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in BookmarkList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BookmarkList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BookmarkList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BookmarkList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BookmarkList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(l, v)
+}
+func easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(in *jlexer.Lexer, out *BookmarkInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Tags = append(out.Tags, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(out *jwriter.Writer, in BookmarkInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix[1:])
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Tags {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BookmarkInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BookmarkInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BookmarkInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BookmarkInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(l, v)
+}
+func easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(in *jlexer.Lexer, out *Bookmark) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			(out.Message).UnmarshalEasyJSON(in)
+		case "source_chat_id":
+			if in.IsNull() {
+				in.Skip()
+				out.SourceChatID = nil
+			} else {
+				if out.SourceChatID == nil {
+					out.SourceChatID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.SourceChatID).UnmarshalText(data))
+				}
+			}
+		case "source_message_id":
+			if in.IsNull() {
+				in.Skip()
+				out.SourceMessageID = nil
+			} else {
+				if out.SourceMessageID == nil {
+					out.SourceMessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.SourceMessageID).UnmarshalText(data))
+				}
+			}
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Tags = append(out.Tags, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(out *jwriter.Writer, in Bookmark) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix[1:])
+		(in.Message).MarshalEasyJSON(out)
+	}
+	if in.SourceChatID != nil {
+		const prefix string = ",\"source_chat_id\":"
+		out.RawString(prefix)
+		out.RawText((*in.SourceChatID).MarshalText())
+	}
+	if in.SourceMessageID != nil {
+		const prefix string = ",\"source_message_id\":"
+		out.RawString(prefix)
+		out.RawText((*in.SourceMessageID).MarshalText())
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Tags {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Bookmark) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Bookmark) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB71f463cEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Bookmark) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Bookmark) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB71f463cDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
+}
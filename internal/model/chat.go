@@ -4,6 +4,7 @@ package model
 import (
 	"errors"
 	"mime/multipart"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
@@ -40,13 +41,46 @@ const (
 
 //easyjson:json
 type Chat struct {
-	ID                uuid.UUID    `json:"id" valid:"uuid"`
-	AvatarPath        *string      `json:"avatar_path,omitempty"`
-	Type              string       `json:"type" valid:"in(dialog|group|channel),required"`
-	Title             string       `json:"title" valid:"required~Title is required,length(1|100)"`
-	LastMessage       *LastMessage `json:"last_message,omitempty"`
-	CountUsers        int          `json:"count_users" valid:"range(0|5000)"`
-	SendNotifications bool         `json:"send_notifications" valid:"-"`
+	ID                uuid.UUID       `json:"id" valid:"uuid"`
+	AvatarPath        *string         `json:"avatar_path,omitempty"`
+	Type              string          `json:"type" valid:"in(dialog|group|channel),required"`
+	Title             string          `json:"title" valid:"required~Title is required,length(1|100)"`
+	LastMessage       *LastMessage    `json:"last_message,omitempty"`
+	CountUsers        int             `json:"count_users" valid:"range(0|5000)"`
+	SendNotifications bool            `json:"send_notifications" valid:"-"`
+	UnreadCount       int             `json:"unread_count" valid:"-"`
+	UnreadMentions    int             `json:"unread_mentions" valid:"-"`
+	LastReadMessageID *uuid.UUID      `json:"last_read_message_id,omitempty" valid:"-"`
+	Pins              []PinnedMessage `json:"pins,omitempty" valid:"-"`
+	MessageTTL        *int            `json:"message_ttl,omitempty" valid:"-"`
+	Draft             *Draft          `json:"draft,omitempty" valid:"-"`
+	// IsSaved отмечает «Избранное» — личный диалог пользователя с самим собой
+	IsSaved bool `json:"is_saved,omitempty" valid:"-"`
+}
+
+// ChatTTLInput задаёт время жизни новых сообщений чата; null отключает удаление
+//
+//easyjson:json
+type ChatTTLInput struct {
+	TTL *int `json:"ttl"`
+}
+
+func (t *ChatTTLInput) Validate() error {
+	if t.TTL == nil {
+		return nil
+	}
+	return ValidateTTL(*t.TTL)
+}
+
+//easyjson:json
+type PinnedMessage struct {
+	MessageID   uuid.UUID  `json:"message_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Username    string     `json:"user,omitempty"`
+	Body        string     `json:"body,omitempty"`
+	MessageType string     `json:"message_type,omitempty"`
+	PinnedBy    *uuid.UUID `json:"pinned_by,omitempty"`
+	PinnedAt    time.Time  `json:"pinned_at"`
 }
 
 //easyjson:json
@@ -84,9 +118,10 @@ type UpdateChatResp struct {
 
 //easyjson:json
 type ChatInfo struct {
-	Role     string       `json:"role" example:"owner" valid:"in(owner|member)"`
-	Users    []UserInChat `json:"users" valid:"-"`
-	Messages []Message    `json:"messages" valid:"-"`
+	Role     string          `json:"role" example:"owner" valid:"in(owner|member)"`
+	Users    []UserInChat    `json:"users" valid:"-"`
+	Messages []Message       `json:"messages" valid:"-"`
+	Pins     []PinnedMessage `json:"pins" valid:"-"`
 }
 
 //easyjson:json
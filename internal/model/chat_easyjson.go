@@ -4,6 +4,7 @@ package model
 
 import (
 	json "encoding/json"
+	uuid "github.com/google/uuid"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
@@ -399,7 +400,131 @@ func (v *UpdateChat) UnmarshalJSON(data []byte) error {
 func (v *UpdateChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
 }
-func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(in *jlexer.Lexer, out *DeletedUsersFromChat) {
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(in *jlexer.Lexer, out *PinnedMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.MessageID).UnmarshalText(data))
+			}
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "user":
+			out.Username = string(in.String())
+		case "body":
+			out.Body = string(in.String())
+		case "message_type":
+			out.MessageType = string(in.String())
+		case "pinned_by":
+			if in.IsNull() {
+				in.Skip()
+				out.PinnedBy = nil
+			} else {
+				if out.PinnedBy == nil {
+					out.PinnedBy = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.PinnedBy).UnmarshalText(data))
+				}
+			}
+		case "pinned_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.PinnedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(out *jwriter.Writer, in PinnedMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.MessageID).MarshalText())
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.RawText((in.UserID).MarshalText())
+	}
+	if in.Username != "" {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	if in.Body != "" {
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	if in.MessageType != "" {
+		const prefix string = ",\"message_type\":"
+		out.RawString(prefix)
+		out.String(string(in.MessageType))
+	}
+	if in.PinnedBy != nil {
+		const prefix string = ",\"pinned_by\":"
+		out.RawString(prefix)
+		out.RawText((*in.PinnedBy).MarshalText())
+	}
+	{
+		const prefix string = ",\"pinned_at\":"
+		out.RawString(prefix)
+		out.Raw((in.PinnedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PinnedMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PinnedMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PinnedMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PinnedMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(l, v)
+}
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(in *jlexer.Lexer, out *DeletedUsersFromChat) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -451,7 +576,7 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(i
 		in.Consumed()
 	}
 }
-func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(out *jwriter.Writer, in DeletedUsersFromChat) {
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(out *jwriter.Writer, in DeletedUsersFromChat) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -476,27 +601,27 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(o
 // MarshalJSON supports json.Marshaler interface
 func (v DeletedUsersFromChat) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DeletedUsersFromChat) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DeletedUsersFromChat) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&r, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DeletedUsersFromChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(l, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(l, v)
 }
-func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(in *jlexer.Lexer, out *CreateChatRequest) {
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(in *jlexer.Lexer, out *CreateChatRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -552,7 +677,7 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(i
 		in.Consumed()
 	}
 }
-func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(out *jwriter.Writer, in CreateChatRequest) {
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(out *jwriter.Writer, in CreateChatRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -588,27 +713,27 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(o
 // MarshalJSON supports json.Marshaler interface
 func (v CreateChatRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CreateChatRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CreateChatRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&r, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CreateChatRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(l, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(l, v)
 }
-func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(in *jlexer.Lexer, out *CreateChat) {
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(in *jlexer.Lexer, out *CreateChat) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -664,7 +789,7 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(i
 		in.Consumed()
 	}
 }
-func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(out *jwriter.Writer, in CreateChat) {
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(out *jwriter.Writer, in CreateChat) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -705,27 +830,105 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(o
 // MarshalJSON supports json.Marshaler interface
 func (v CreateChat) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CreateChat) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CreateChat) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&r, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CreateChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(l, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(l, v)
+}
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(in *jlexer.Lexer, out *ChatTTLInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ttl":
+			if in.IsNull() {
+				in.Skip()
+				out.TTL = nil
+			} else {
+				if out.TTL == nil {
+					out.TTL = new(int)
+				}
+				*out.TTL = int(in.Int())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(out *jwriter.Writer, in ChatTTLInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ttl\":"
+		out.RawString(prefix[1:])
+		if in.TTL == nil {
+			out.RawString("null")
+		} else {
+			out.Int(int(*in.TTL))
+		}
+	}
+	out.RawByte('}')
 }
-func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(in *jlexer.Lexer, out *ChatList) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatTTLInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatTTLInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatTTLInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatTTLInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(l, v)
+}
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(in *jlexer.Lexer, out *ChatList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -753,7 +956,7 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(i
 		in.Consumed()
 	}
 }
-func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(out *jwriter.Writer, in ChatList) {
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(out *jwriter.Writer, in ChatList) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -771,27 +974,27 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(o
 // MarshalJSON supports json.Marshaler interface
 func (v ChatList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ChatList) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ChatList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&r, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ChatList) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(l, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(l, v)
 }
-func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(in *jlexer.Lexer, out *ChatInfo) {
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(in *jlexer.Lexer, out *ChatInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -858,6 +1061,29 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(i
 				}
 				in.Delim(']')
 			}
+		case "pins":
+			if in.IsNull() {
+				in.Skip()
+				out.Pins = nil
+			} else {
+				in.Delim('[')
+				if out.Pins == nil {
+					if !in.IsDelim(']') {
+						out.Pins = make([]PinnedMessage, 0, 0)
+					} else {
+						out.Pins = []PinnedMessage{}
+					}
+				} else {
+					out.Pins = (out.Pins)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v18 PinnedMessage
+					(v18).UnmarshalEasyJSON(in)
+					out.Pins = append(out.Pins, v18)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -868,7 +1094,7 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(i
 		in.Consumed()
 	}
 }
-func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(out *jwriter.Writer, in ChatInfo) {
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(out *jwriter.Writer, in ChatInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -884,11 +1110,11 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(o
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v18, v19 := range in.Users {
-				if v18 > 0 {
+			for v19, v20 := range in.Users {
+				if v19 > 0 {
 					out.RawByte(',')
 				}
-				(v19).MarshalEasyJSON(out)
+				(v20).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -900,11 +1126,27 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(o
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v20, v21 := range in.Messages {
-				if v20 > 0 {
+			for v21, v22 := range in.Messages {
+				if v21 > 0 {
 					out.RawByte(',')
 				}
-				(v21).MarshalEasyJSON(out)
+				(v22).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"pins\":"
+		out.RawString(prefix)
+		if in.Pins == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Pins {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				(v24).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -915,27 +1157,27 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(o
 // MarshalJSON supports json.Marshaler interface
 func (v ChatInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ChatInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ChatInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&r, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ChatInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(l, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(l, v)
 }
-func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(in *jlexer.Lexer, out *Chat) {
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(in *jlexer.Lexer, out *Chat) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -986,6 +1228,67 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(i
 			out.CountUsers = int(in.Int())
 		case "send_notifications":
 			out.SendNotifications = bool(in.Bool())
+		case "unread_count":
+			out.UnreadCount = int(in.Int())
+		case "unread_mentions":
+			out.UnreadMentions = int(in.Int())
+		case "last_read_message_id":
+			if in.IsNull() {
+				in.Skip()
+				out.LastReadMessageID = nil
+			} else {
+				if out.LastReadMessageID == nil {
+					out.LastReadMessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.LastReadMessageID).UnmarshalText(data))
+				}
+			}
+		case "pins":
+			if in.IsNull() {
+				in.Skip()
+				out.Pins = nil
+			} else {
+				in.Delim('[')
+				if out.Pins == nil {
+					if !in.IsDelim(']') {
+						out.Pins = make([]PinnedMessage, 0, 0)
+					} else {
+						out.Pins = []PinnedMessage{}
+					}
+				} else {
+					out.Pins = (out.Pins)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 PinnedMessage
+					(v25).UnmarshalEasyJSON(in)
+					out.Pins = append(out.Pins, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "message_ttl":
+			if in.IsNull() {
+				in.Skip()
+				out.MessageTTL = nil
+			} else {
+				if out.MessageTTL == nil {
+					out.MessageTTL = new(int)
+				}
+				*out.MessageTTL = int(in.Int())
+			}
+		case "draft":
+			if in.IsNull() {
+				in.Skip()
+				out.Draft = nil
+			} else {
+				if out.Draft == nil {
+					out.Draft = new(Draft)
+				}
+				(*out.Draft).UnmarshalEasyJSON(in)
+			}
+		case "is_saved":
+			out.IsSaved = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -996,7 +1299,7 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(i
 		in.Consumed()
 	}
 }
-func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(out *jwriter.Writer, in Chat) {
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(out *jwriter.Writer, in Chat) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1035,33 +1338,77 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(o
 		out.RawString(prefix)
 		out.Bool(bool(in.SendNotifications))
 	}
+	{
+		const prefix string = ",\"unread_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.UnreadCount))
+	}
+	{
+		const prefix string = ",\"unread_mentions\":"
+		out.RawString(prefix)
+		out.Int(int(in.UnreadMentions))
+	}
+	if in.LastReadMessageID != nil {
+		const prefix string = ",\"last_read_message_id\":"
+		out.RawString(prefix)
+		out.RawText((*in.LastReadMessageID).MarshalText())
+	}
+	if len(in.Pins) != 0 {
+		const prefix string = ",\"pins\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v26, v27 := range in.Pins {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				(v27).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.MessageTTL != nil {
+		const prefix string = ",\"message_ttl\":"
+		out.RawString(prefix)
+		out.Int(int(*in.MessageTTL))
+	}
+	if in.Draft != nil {
+		const prefix string = ",\"draft\":"
+		out.RawString(prefix)
+		(*in.Draft).MarshalEasyJSON(out)
+	}
+	if in.IsSaved {
+		const prefix string = ",\"is_saved\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsSaved))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Chat) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Chat) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Chat) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&r, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Chat) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(l, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(l, v)
 }
-func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(in *jlexer.Lexer, out *AddedUsersIntoChat) {
+func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(in *jlexer.Lexer, out *AddedUsersIntoChat) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1096,9 +1443,9 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(
 					out.AddedUsers = (out.AddedUsers)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v22 string
-					v22 = string(in.String())
-					out.AddedUsers = append(out.AddedUsers, v22)
+					var v28 string
+					v28 = string(in.String())
+					out.AddedUsers = append(out.AddedUsers, v28)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1119,9 +1466,9 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(
 					out.NotAddedUsers = (out.NotAddedUsers)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v23 string
-					v23 = string(in.String())
-					out.NotAddedUsers = append(out.NotAddedUsers, v23)
+					var v29 string
+					v29 = string(in.String())
+					out.NotAddedUsers = append(out.NotAddedUsers, v29)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1136,7 +1483,7 @@ func easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(
 		in.Consumed()
 	}
 }
-func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(out *jwriter.Writer, in AddedUsersIntoChat) {
+func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(out *jwriter.Writer, in AddedUsersIntoChat) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1146,11 +1493,11 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(
 		out.RawString(prefix[1:])
 		{
 			out.RawByte('[')
-			for v24, v25 := range in.AddedUsers {
-				if v24 > 0 {
+			for v30, v31 := range in.AddedUsers {
+				if v30 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v25))
+				out.String(string(v31))
 			}
 			out.RawByte(']')
 		}
@@ -1165,11 +1512,11 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(
 		}
 		{
 			out.RawByte('[')
-			for v26, v27 := range in.NotAddedUsers {
-				if v26 > 0 {
+			for v32, v33 := range in.NotAddedUsers {
+				if v32 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v27))
+				out.String(string(v33))
 			}
 			out.RawByte(']')
 		}
@@ -1180,23 +1527,23 @@ func easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(
 // MarshalJSON supports json.Marshaler interface
 func (v AddedUsersIntoChat) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(&w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddedUsersIntoChat) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(w, v)
+	easyjson9b8f5552EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddedUsersIntoChat) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(&r, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddedUsersIntoChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(l, v)
+	easyjson9b8f5552DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(l, v)
 }
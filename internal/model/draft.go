@@ -0,0 +1,44 @@
+//go:generate easyjson -all draft.go
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDraftLength совпадает с ограничением на текст сообщения
+const MaxDraftLength = 1000
+
+// Draft — неотправленный текст пользователя в чате вместе с целью ответа.
+// Пустой Body без ParentMessageID означает, что черновик удалён.
+//
+//easyjson:json
+type Draft struct {
+	ChatID          uuid.UUID       `json:"chat_id"`
+	Body            string          `json:"body"`
+	Entities        []MessageEntity `json:"entities,omitempty"`
+	ParentMessageID *uuid.UUID      `json:"parent_message_id,omitempty"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+func (d *Draft) IsEmpty() bool {
+	return strings.TrimSpace(d.Body) == "" && d.ParentMessageID == nil
+}
+
+//easyjson:json
+type DraftInput struct {
+	Body            string          `json:"body"`
+	Entities        []MessageEntity `json:"entities,omitempty"`
+	ParentMessageID *uuid.UUID      `json:"parent_message_id,omitempty"`
+}
+
+func (d *DraftInput) Validate() error {
+	if len([]rune(d.Body)) > MaxDraftLength {
+		return errors.Join(ErrValidation, fmt.Errorf("draft must be at most %d characters", MaxDraftLength))
+	}
+	return ValidateEntities(d.Body, d.Entities)
+}
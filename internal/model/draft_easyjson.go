@@ -0,0 +1,278 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson99a4b8cbDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(in *jlexer.Lexer, out *DraftInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "body":
+			out.Body = string(in.String())
+		case "entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 MessageEntity
+					(v1).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "parent_message_id":
+			if in.IsNull() {
+				in.Skip()
+				out.ParentMessageID = nil
+			} else {
+				if out.ParentMessageID == nil {
+					out.ParentMessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.ParentMessageID).UnmarshalText(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson99a4b8cbEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(out *jwriter.Writer, in DraftInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Body))
+	}
+	if len(in.Entities) != 0 {
+		const prefix string = ",\"entities\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Entities {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ParentMessageID != nil {
+		const prefix string = ",\"parent_message_id\":"
+		out.RawString(prefix)
+		out.RawText((*in.ParentMessageID).MarshalText())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DraftInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson99a4b8cbEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DraftInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson99a4b8cbEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DraftInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson99a4b8cbDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DraftInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson99a4b8cbDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(l, v)
+}
+func easyjson99a4b8cbDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *Draft) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chat_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ChatID).UnmarshalText(data))
+			}
+		case "body":
+			out.Body = string(in.String())
+		case "entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 MessageEntity
+					(v4).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "parent_message_id":
+			if in.IsNull() {
+				in.Skip()
+				out.ParentMessageID = nil
+			} else {
+				if out.ParentMessageID == nil {
+					out.ParentMessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.ParentMessageID).UnmarshalText(data))
+				}
+			}
+		case "updated_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.UpdatedAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson99a4b8cbEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in Draft) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chat_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ChatID).MarshalText())
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	if len(in.Entities) != 0 {
+		const prefix string = ",\"entities\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Entities {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ParentMessageID != nil {
+		const prefix string = ",\"parent_message_id\":"
+		out.RawString(prefix)
+		out.RawText((*in.ParentMessageID).MarshalText())
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.Raw((in.UpdatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Draft) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson99a4b8cbEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Draft) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson99a4b8cbEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Draft) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson99a4b8cbDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Draft) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson99a4b8cbDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(l, v)
+}
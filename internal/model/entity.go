@@ -0,0 +1,269 @@
+//go:generate easyjson -all entity.go
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type EntityType string
+
+const (
+	// EntityMention — упоминание участника чата через @username.
+	// Такую разметку расставляет только сервер.
+	EntityMention EntityType = "mention"
+
+	// Форматирование, которое задаёт клиент
+	EntityBold    EntityType = "bold"
+	EntityItalic  EntityType = "italic"
+	EntityCode    EntityType = "code"
+	EntityLink    EntityType = "link"
+	EntitySpoiler EntityType = "spoiler"
+)
+
+const (
+	MaxMessageEntities = 100
+	MaxEntityURLLength = 2048
+)
+
+// IsFormatting сообщает, может ли клиент передать разметку этого типа
+func (t EntityType) IsFormatting() bool {
+	switch t {
+	case EntityBold, EntityItalic, EntityCode, EntityLink, EntitySpoiler:
+		return true
+	}
+	return false
+}
+
+// MessageEntity — размеченный фрагмент текста сообщения. Offset и Length
+// считаются в UTF-16 code units, как в JavaScript-строках на клиенте.
+// Текст сообщения остаётся простым, клиент рисует форматирование сам,
+// поэтому HTML в теле не нужен.
+//
+//easyjson:json
+type MessageEntity struct {
+	Type   EntityType `json:"type"`
+	Offset int        `json:"offset"`
+	Length int        `json:"length"`
+	UserID *uuid.UUID `json:"user_id,omitempty"`
+	URL    string     `json:"url,omitempty"`
+}
+
+func (e MessageEntity) end() int {
+	return e.Offset + e.Length
+}
+
+//easyjson:json
+type MessageEntityList []MessageEntity
+
+// MentionedUsers возвращает упомянутых пользователей без повторов
+func MentionedUsers(entities []MessageEntity) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{})
+	var users []uuid.UUID
+	for _, e := range entities {
+		if e.Type != EntityMention || e.UserID == nil {
+			continue
+		}
+		if _, ok := seen[*e.UserID]; ok {
+			continue
+		}
+		seen[*e.UserID] = struct{}{}
+		users = append(users, *e.UserID)
+	}
+	return users
+}
+
+// usernameRune — символы, допустимые в username (см. RegisterCredentials)
+func usernameRune(r rune) bool {
+	return r < utf8.RuneSelf && (r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+		strings.ContainsRune("!#$%^&*()_-+=", r))
+}
+
+const (
+	minUsernameLen = 3
+	maxUsernameLen = 20
+)
+
+// FindMentions находит в тексте упоминания @username и разрешает их через
+// resolve. Так как username может оканчиваться знаками вроде «!» или «)»,
+// из найденного токена берётся самый длинный префикс, который resolve узнаёт.
+// Упоминание должно стоять в начале текста или после символа, не входящего
+// в username, — так адреса почты не принимаются за упоминания.
+func FindMentions(body string, resolve func(username string) (uuid.UUID, bool)) []MessageEntity {
+	runes := []rune(body)
+	var entities []MessageEntity
+
+	offset := 0 // позиция текущей руны в UTF-16
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '@' || (i > 0 && usernameRune(runes[i-1])) {
+			offset += utf16.RuneLen(r)
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && end-i-1 < maxUsernameLen && usernameRune(runes[end]) {
+			end++
+		}
+
+		matched := 0
+		var userID uuid.UUID
+		for n := end - i - 1; n >= minUsernameLen; n-- {
+			if id, ok := resolve(string(runes[i+1 : i+1+n])); ok {
+				matched, userID = n, id
+				break
+			}
+		}
+
+		if matched == 0 {
+			offset += utf16.RuneLen(r)
+			continue
+		}
+
+		id := userID
+		// username состоит из ASCII, поэтому длина в UTF-16 равна числу рун
+		entities = append(entities, MessageEntity{
+			Type:   EntityMention,
+			Offset: offset,
+			Length: matched + 1,
+			UserID: &id,
+		})
+		offset += matched + 1
+		i += matched
+	}
+
+	return entities
+}
+
+// ValidateEntities проверяет разметку, присланную клиентом: только типы
+// форматирования, границы внутри текста и не посреди суррогатной пары,
+// без пересечений разметки одного типа; у ссылок — адрес http(s).
+func ValidateEntities(body string, entities []MessageEntity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if len(entities) > MaxMessageEntities {
+		return errors.Join(ErrValidation, fmt.Errorf("too many entities, max %d", MaxMessageEntities))
+	}
+
+	// boundaries[i] — можно ли поставить границу разметки в позицию i (UTF-16)
+	boundaries := []bool{true}
+	for _, r := range body {
+		if utf16.RuneLen(r) == 2 {
+			boundaries = append(boundaries, false)
+		}
+		boundaries = append(boundaries, true)
+	}
+	textLen := len(boundaries) - 1
+
+	for _, e := range entities {
+		if !e.Type.IsFormatting() {
+			return errors.Join(ErrValidation, fmt.Errorf("unsupported entity type %q", e.Type))
+		}
+		if e.Offset < 0 || e.Length <= 0 || e.end() > textLen {
+			return errors.Join(ErrValidation, fmt.Errorf("%s entity at %d is out of text bounds", e.Type, e.Offset))
+		}
+		if !boundaries[e.Offset] || !boundaries[e.end()] {
+			return errors.Join(ErrValidation, fmt.Errorf("%s entity at %d splits a character", e.Type, e.Offset))
+		}
+		if e.UserID != nil {
+			return errors.Join(ErrValidation, errors.New("user_id is allowed only in mentions"))
+		}
+		if e.Type == EntityLink {
+			if err := validateEntityURL(e.URL); err != nil {
+				return err
+			}
+		} else if e.URL != "" {
+			return errors.Join(ErrValidation, errors.New("url is allowed only in links"))
+		}
+	}
+
+	sorted := append([]MessageEntity{}, entities...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		return sorted[i].Offset < sorted[j].Offset
+	})
+	for i := 1; i < len(sorted); i++ {
+		prev, cur := sorted[i-1], sorted[i]
+		if prev.Type == cur.Type && prev.end() > cur.Offset {
+			return errors.Join(ErrValidation, fmt.Errorf("%s entities overlap at %d", cur.Type, cur.Offset))
+		}
+	}
+	return nil
+}
+
+func validateEntityURL(raw string) error {
+	if raw == "" || len(raw) > MaxEntityURLLength {
+		return errors.Join(ErrValidation, fmt.Errorf("link url must be 1-%d bytes", MaxEntityURLLength))
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Join(ErrValidation, errors.New("link url must be an absolute http(s) url"))
+	}
+	return nil
+}
+
+// MergeEntities объединяет форматирование клиента с найденными сервером
+// упоминаниями. Упоминание внутри кода — просто текст, такие отбрасываются.
+func MergeEntities(formatting, mentions []MessageEntity) []MessageEntity {
+	merged := append([]MessageEntity{}, formatting...)
+	for _, m := range mentions {
+		inCode := false
+		for _, f := range formatting {
+			if f.Type == EntityCode && f.Offset < m.end() && m.Offset < f.end() {
+				inCode = true
+				break
+			}
+		}
+		if !inCode {
+			merged = append(merged, m)
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return sortedEntities(merged)
+}
+
+// sortedEntities возвращает копию, упорядоченную по позиции, затем по типу
+func sortedEntities(entities []MessageEntity) []MessageEntity {
+	sorted := append([]MessageEntity{}, entities...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Offset != sorted[j].Offset {
+			return sorted[i].Offset < sorted[j].Offset
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+	return sorted
+}
+
+var bareURLPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// PreviewURL выбирает ссылку для превью: первую размеченную ссылку,
+// а если таких нет — первый http(s)-адрес в тексте
+func PreviewURL(body string, entities []MessageEntity) string {
+	for _, e := range sortedEntities(entities) {
+		if e.Type == EntityLink && e.URL != "" {
+			return e.URL
+		}
+	}
+
+	for _, raw := range bareURLPattern.FindAllString(body, -1) {
+		// Знаки препинания в конце обычно относятся к предложению, а не к адресу
+		raw = strings.TrimRight(raw, ".,;:!?)]}'")
+		if validateEntityURL(raw) == nil {
+			return raw
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+//go:generate easyjson -all export.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportStatus string
+
+const (
+	ExportPending ExportStatus = "pending"
+	ExportRunning ExportStatus = "running"
+	ExportDone    ExportStatus = "done"
+	ExportFailed  ExportStatus = "failed"
+)
+
+// ChatExport — фоновая выгрузка истории чата в ZIP. Готовый архив лежит
+// в хранилище по FileURL и доступен только запросившему.
+//
+//easyjson:json
+type ChatExport struct {
+	ID                uuid.UUID    `json:"id"`
+	ChatID            uuid.UUID    `json:"chat_id"`
+	UserID            uuid.UUID    `json:"-"`
+	Status            ExportStatus `json:"status"`
+	ProcessedMessages int          `json:"processed_messages"`
+	TotalMessages     int          `json:"total_messages"`
+	ProcessedFiles    int          `json:"processed_files"`
+	TotalFiles        int          `json:"total_files"`
+	Progress          int          `json:"progress"`
+	FileURL           string       `json:"file_url,omitempty"`
+	Error             string       `json:"error,omitempty"`
+	CreatedAt         time.Time    `json:"created_at"`
+	FinishedAt        *time.Time   `json:"finished_at,omitempty"`
+}
+
+// UpdateProgress пересчитывает процент готовности по обработанным
+// сообщениям и файлам; 100 только у завершённой выгрузки
+func (e *ChatExport) UpdateProgress() {
+	if e.Status == ExportDone {
+		e.Progress = 100
+		return
+	}
+	total := e.TotalMessages + e.TotalFiles
+	if total == 0 {
+		e.Progress = 0
+		return
+	}
+	progress := (e.ProcessedMessages + e.ProcessedFiles) * 100 / total
+	e.Progress = min(progress, 99)
+}
+
+// ExportedMessage — сообщение в transcript.json архива. Пути вложений и
+// стикеров указаны относительно корня архива.
+//
+//easyjson:json
+type ExportedMessage struct {
+	ID          uuid.UUID       `json:"id"`
+	AuthorID    uuid.UUID       `json:"author_id"`
+	Author      string          `json:"author"`
+	SentAt      time.Time       `json:"sent_at"`
+	EditedAt    *time.Time      `json:"edited_at,omitempty"`
+	Deleted     bool            `json:"deleted,omitempty"`
+	Body        string          `json:"body,omitempty"`
+	Entities    []MessageEntity `json:"entities,omitempty"`
+	ReplyTo     *uuid.UUID      `json:"reply_to,omitempty"`
+	Forward     *ForwardInfo    `json:"forward,omitempty"`
+	Sticker     string          `json:"sticker,omitempty"`
+	Attachments []ExportedFile  `json:"attachments,omitempty"`
+	Poll        *Poll           `json:"poll,omitempty"`
+}
+
+//easyjson:json
+type ExportedFile struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size"`
+	DurationMs  int    `json:"duration_ms,omitempty"`
+}
+
+//easyjson:json
+type ExportedAuthor struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+}
+
+// ExportManifest — manifest.json архива: что выгружено и какие файлы
+// не удалось приложить (удалены из хранилища или недоступны запросившему)
+//
+//easyjson:json
+type ExportManifest struct {
+	ChatID       uuid.UUID `json:"chat_id"`
+	ChatTitle    string    `json:"chat_title"`
+	ChatType     string    `json:"chat_type"`
+	ExportedBy   uuid.UUID `json:"exported_by"`
+	ExportedAt   time.Time `json:"exported_at"`
+	Messages     int       `json:"messages"`
+	Files        int       `json:"files"`
+	MissingFiles []string  `json:"missing_files,omitempty"`
+}
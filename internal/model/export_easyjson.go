@@ -0,0 +1,759 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(in *jlexer.Lexer, out *ExportedMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "author_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.AuthorID).UnmarshalText(data))
+			}
+		case "author":
+			out.Author = string(in.String())
+		case "sent_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.SentAt).UnmarshalJSON(data))
+			}
+		case "edited_at":
+			if in.IsNull() {
+				in.Skip()
+				out.EditedAt = nil
+			} else {
+				if out.EditedAt == nil {
+					out.EditedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.EditedAt).UnmarshalJSON(data))
+				}
+			}
+		case "deleted":
+			out.Deleted = bool(in.Bool())
+		case "body":
+			out.Body = string(in.String())
+		case "entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 MessageEntity
+					(v1).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "reply_to":
+			if in.IsNull() {
+				in.Skip()
+				out.ReplyTo = nil
+			} else {
+				if out.ReplyTo == nil {
+					out.ReplyTo = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.ReplyTo).UnmarshalText(data))
+				}
+			}
+		case "forward":
+			if in.IsNull() {
+				in.Skip()
+				out.Forward = nil
+			} else {
+				if out.Forward == nil {
+					out.Forward = new(ForwardInfo)
+				}
+				(*out.Forward).UnmarshalEasyJSON(in)
+			}
+		case "sticker":
+			out.Sticker = string(in.String())
+		case "attachments":
+			if in.IsNull() {
+				in.Skip()
+				out.Attachments = nil
+			} else {
+				in.Delim('[')
+				if out.Attachments == nil {
+					if !in.IsDelim(']') {
+						out.Attachments = make([]ExportedFile, 0, 0)
+					} else {
+						out.Attachments = []ExportedFile{}
+					}
+				} else {
+					out.Attachments = (out.Attachments)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v2 ExportedFile
+					(v2).UnmarshalEasyJSON(in)
+					out.Attachments = append(out.Attachments, v2)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "poll":
+			if in.IsNull() {
+				in.Skip()
+				out.Poll = nil
+			} else {
+				if out.Poll == nil {
+					out.Poll = new(Poll)
+				}
+				(*out.Poll).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(out *jwriter.Writer, in ExportedMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"author_id\":"
+		out.RawString(prefix)
+		out.RawText((in.AuthorID).MarshalText())
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"sent_at\":"
+		out.RawString(prefix)
+		out.Raw((in.SentAt).MarshalJSON())
+	}
+	if in.EditedAt != nil {
+		const prefix string = ",\"edited_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.EditedAt).MarshalJSON())
+	}
+	if in.Deleted {
+		const prefix string = ",\"deleted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Deleted))
+	}
+	if in.Body != "" {
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	if len(in.Entities) != 0 {
+		const prefix string = ",\"entities\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v3, v4 := range in.Entities {
+				if v3 > 0 {
+					out.RawByte(',')
+				}
+				(v4).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.ReplyTo != nil {
+		const prefix string = ",\"reply_to\":"
+		out.RawString(prefix)
+		out.RawText((*in.ReplyTo).MarshalText())
+	}
+	if in.Forward != nil {
+		const prefix string = ",\"forward\":"
+		out.RawString(prefix)
+		(*in.Forward).MarshalEasyJSON(out)
+	}
+	if in.Sticker != "" {
+		const prefix string = ",\"sticker\":"
+		out.RawString(prefix)
+		out.String(string(in.Sticker))
+	}
+	if len(in.Attachments) != 0 {
+		const prefix string = ",\"attachments\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Attachments {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Poll != nil {
+		const prefix string = ",\"poll\":"
+		out.RawString(prefix)
+		(*in.Poll).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportedMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportedMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportedMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportedMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(l, v)
+}
+func easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *ExportedFile) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "kind":
+			out.Kind = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "path":
+			out.Path = string(in.String())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "duration_ms":
+			out.DurationMs = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in ExportedFile) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"path\":"
+		out.RawString(prefix)
+		out.String(string(in.Path))
+	}
+	if in.ContentType != "" {
+		const prefix string = ",\"content_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if in.DurationMs != 0 {
+		const prefix string = ",\"duration_ms\":"
+		out.RawString(prefix)
+		out.Int(int(in.DurationMs))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportedFile) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportedFile) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportedFile) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportedFile) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(l, v)
+}
+func easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(in *jlexer.Lexer, out *ExportedAuthor) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "username":
+			out.Username = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(out *jwriter.Writer, in ExportedAuthor) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"username\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportedAuthor) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportedAuthor) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportedAuthor) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportedAuthor) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(l, v)
+}
+func easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(in *jlexer.Lexer, out *ExportManifest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chat_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ChatID).UnmarshalText(data))
+			}
+		case "chat_title":
+			out.ChatTitle = string(in.String())
+		case "chat_type":
+			out.ChatType = string(in.String())
+		case "exported_by":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ExportedBy).UnmarshalText(data))
+			}
+		case "exported_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ExportedAt).UnmarshalJSON(data))
+			}
+		case "messages":
+			out.Messages = int(in.Int())
+		case "files":
+			out.Files = int(in.Int())
+		case "missing_files":
+			if in.IsNull() {
+				in.Skip()
+				out.MissingFiles = nil
+			} else {
+				in.Delim('[')
+				if out.MissingFiles == nil {
+					if !in.IsDelim(']') {
+						out.MissingFiles = make([]string, 0, 4)
+					} else {
+						out.MissingFiles = []string{}
+					}
+				} else {
+					out.MissingFiles = (out.MissingFiles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.MissingFiles = append(out.MissingFiles, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(out *jwriter.Writer, in ExportManifest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chat_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ChatID).MarshalText())
+	}
+	{
+		const prefix string = ",\"chat_title\":"
+		out.RawString(prefix)
+		out.String(string(in.ChatTitle))
+	}
+	{
+		const prefix string = ",\"chat_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ChatType))
+	}
+	{
+		const prefix string = ",\"exported_by\":"
+		out.RawString(prefix)
+		out.RawText((in.ExportedBy).MarshalText())
+	}
+	{
+		const prefix string = ",\"exported_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ExportedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix)
+		out.Int(int(in.Messages))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		out.Int(int(in.Files))
+	}
+	if len(in.MissingFiles) != 0 {
+		const prefix string = ",\"missing_files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.MissingFiles {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ExportManifest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ExportManifest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ExportManifest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ExportManifest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
+}
+func easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(in *jlexer.Lexer, out *ChatExport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "chat_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ChatID).UnmarshalText(data))
+			}
+		case "status":
+			out.Status = ExportStatus(in.String())
+		case "processed_messages":
+			out.ProcessedMessages = int(in.Int())
+		case "total_messages":
+			out.TotalMessages = int(in.Int())
+		case "processed_files":
+			out.ProcessedFiles = int(in.Int())
+		case "total_files":
+			out.TotalFiles = int(in.Int())
+		case "progress":
+			out.Progress = int(in.Int())
+		case "file_url":
+			out.FileURL = string(in.String())
+		case "error":
+			out.Error = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "finished_at":
+			if in.IsNull() {
+				in.Skip()
+				out.FinishedAt = nil
+			} else {
+				if out.FinishedAt == nil {
+					out.FinishedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.FinishedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(out *jwriter.Writer, in ChatExport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"chat_id\":"
+		out.RawString(prefix)
+		out.RawText((in.ChatID).MarshalText())
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"processed_messages\":"
+		out.RawString(prefix)
+		out.Int(int(in.ProcessedMessages))
+	}
+	{
+		const prefix string = ",\"total_messages\":"
+		out.RawString(prefix)
+		out.Int(int(in.TotalMessages))
+	}
+	{
+		const prefix string = ",\"processed_files\":"
+		out.RawString(prefix)
+		out.Int(int(in.ProcessedFiles))
+	}
+	{
+		const prefix string = ",\"total_files\":"
+		out.RawString(prefix)
+		out.Int(int(in.TotalFiles))
+	}
+	{
+		const prefix string = ",\"progress\":"
+		out.RawString(prefix)
+		out.Int(int(in.Progress))
+	}
+	if in.FileURL != "" {
+		const prefix string = ",\"file_url\":"
+		out.RawString(prefix)
+		out.String(string(in.FileURL))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	if in.FinishedAt != nil {
+		const prefix string = ",\"finished_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.FinishedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ChatExport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ChatExport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4bb85eceEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ChatExport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ChatExport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4bb85eceDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(l, v)
+}
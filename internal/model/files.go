@@ -1,7 +1,14 @@
 //go:generate easyjson -all files.go
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxVoiceDuration — предел длительности голосового сообщения
+const MaxVoiceDuration = 15 * time.Minute
 
 //easyjson:json
 type UploadFileResponse struct {
@@ -12,14 +19,24 @@ type FileMetaData struct {
 	Filename    string
 	ContentType string
 	FileSize    int64
+	// ModTime и ETag нужны для условных и Range-запросов при раздаче файла
+	ModTime time.Time
+	ETag    string
 }
 
+// Payload — вложение сообщения. ContentType хранит вид вложения
+// (file, photo, voice), а не MIME-тип.
+//
 //easyjson:json
 type Payload struct {
 	URL         string
 	Filename    string
 	ContentType string
 	Size        int64
+	// DurationMs и Waveform есть только у голосовых: длительность и огибающая
+	// громкости (100 значений 0..255) для отрисовки плеера без загрузки файла
+	DurationMs int    `json:",omitempty"`
+	Waveform   []byte `json:",omitempty"`
 }
 
 //easyjson:json
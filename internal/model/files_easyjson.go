@@ -290,6 +290,15 @@ func easyjson3f98fba7DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 			out.ContentType = string(in.String())
 		case "Size":
 			out.Size = int64(in.Int64())
+		case "DurationMs":
+			out.DurationMs = int(in.Int())
+		case "Waveform":
+			if in.IsNull() {
+				in.Skip()
+				out.Waveform = nil
+			} else {
+				out.Waveform = in.Bytes()
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -324,6 +333,16 @@ func easyjson3f98fba7EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(o
 		out.RawString(prefix)
 		out.Int64(int64(in.Size))
 	}
+	if in.DurationMs != 0 {
+		const prefix string = ",\"DurationMs\":"
+		out.RawString(prefix)
+		out.Int(int(in.DurationMs))
+	}
+	if len(in.Waveform) != 0 {
+		const prefix string = ",\"Waveform\":"
+		out.RawString(prefix)
+		out.Base64Bytes(in.Waveform)
+	}
 	out.RawByte('}')
 }
 
@@ -387,9 +406,9 @@ func easyjson3f98fba7DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(i
 					out.URLs = (out.URLs)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v4 string
-					v4 = string(in.String())
-					out.URLs = append(out.URLs, v4)
+					var v7 string
+					v7 = string(in.String())
+					out.URLs = append(out.URLs, v7)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -420,11 +439,11 @@ func easyjson3f98fba7EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(o
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v5, v6 := range in.URLs {
-				if v5 > 0 {
+			for v8, v9 := range in.URLs {
+				if v8 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v6))
+				out.String(string(v9))
 			}
 			out.RawByte(']')
 		}
@@ -0,0 +1,120 @@
+//go:generate easyjson -all history.go
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultHistoryLimit = 25
+	MaxHistoryLimit     = 100
+	// DefaultWindowLimit и MaxWindowLimit — сколько сообщений окна брать с каждой стороны от опорной точки
+	DefaultWindowLimit = 20
+	MaxWindowLimit     = 50
+)
+
+// MessageCursor — позиция в истории чата: ключ сортировки (sent_at, id)
+// сообщения, на котором закончилась страница. Клиенту отдаётся в
+// закодированном виде и не зависит от того, удалено ли сообщение позже.
+type MessageCursor struct {
+	SentAt time.Time
+	ID     uuid.UUID
+}
+
+func NewMessageCursor(msg Message) *MessageCursor {
+	return &MessageCursor{SentAt: msg.SentAt, ID: msg.ID}
+}
+
+func (c MessageCursor) Encode() string {
+	raw := strconv.FormatInt(c.SentAt.UnixNano(), 10) + "_" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseMessageCursor(s string) (*MessageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Join(ErrValidation, errors.New("invalid cursor"))
+	}
+	nanos, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return nil, errors.Join(ErrValidation, errors.New("invalid cursor"))
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errors.Join(ErrValidation, errors.New("invalid cursor"))
+	}
+	messageID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.Join(ErrValidation, errors.New("invalid cursor"))
+	}
+	return &MessageCursor{SentAt: time.Unix(0, n).UTC(), ID: messageID}, nil
+}
+
+// HistoryQuery — запрос страницы истории: без курсоров отдаются последние
+// сообщения, Before листает к старым, After — к новым
+type HistoryQuery struct {
+	Before *MessageCursor
+	After  *MessageCursor
+	Limit  int
+}
+
+func (q *HistoryQuery) Validate() error {
+	if q.Before != nil && q.After != nil {
+		return errors.Join(ErrValidation, errors.New("before and after are mutually exclusive"))
+	}
+	if q.Limit < 0 {
+		return errors.Join(ErrValidation, errors.New("limit must be positive"))
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultHistoryLimit
+	}
+	if q.Limit > MaxHistoryLimit {
+		q.Limit = MaxHistoryLimit
+	}
+	return nil
+}
+
+// WindowQuery — запрос окна истории вокруг сообщения или момента времени:
+// Limit сообщений до опорной точки и Limit после неё
+type WindowQuery struct {
+	MessageID *uuid.UUID
+	Date      *time.Time
+	Limit     int
+}
+
+func (q *WindowQuery) Validate() error {
+	if (q.MessageID == nil) == (q.Date == nil) {
+		return errors.Join(ErrValidation, errors.New("exactly one of message_id and date is required"))
+	}
+	if q.Limit < 0 {
+		return errors.Join(ErrValidation, errors.New("limit must be positive"))
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultWindowLimit
+	}
+	if q.Limit > MaxWindowLimit {
+		q.Limit = MaxWindowLimit
+	}
+	return nil
+}
+
+// MessagePage — страница истории, сообщения от новых к старым. OlderCursor
+// передаётся в before, NewerCursor — в after; HasOlder и HasNewer
+// говорят, есть ли что загружать в каждую сторону. AnchorID в окне истории
+// указывает сообщение, к которому клиенту нужно прокрутить.
+//
+//easyjson:json
+type MessagePage struct {
+	Messages    []Message  `json:"messages"`
+	OlderCursor string     `json:"older_cursor,omitempty"`
+	NewerCursor string     `json:"newer_cursor,omitempty"`
+	HasOlder    bool       `json:"has_older"`
+	HasNewer    bool       `json:"has_newer"`
+	AnchorID    *uuid.UUID `json:"anchor_id,omitempty"`
+}
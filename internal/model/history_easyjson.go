@@ -0,0 +1,453 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(in *jlexer.Lexer, out *WindowQuery) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "MessageID":
+			if in.IsNull() {
+				in.Skip()
+				out.MessageID = nil
+			} else {
+				if out.MessageID == nil {
+					out.MessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.MessageID).UnmarshalText(data))
+				}
+			}
+		case "Date":
+			if in.IsNull() {
+				in.Skip()
+				out.Date = nil
+			} else {
+				if out.Date == nil {
+					out.Date = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Date).UnmarshalJSON(data))
+				}
+			}
+		case "Limit":
+			out.Limit = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(out *jwriter.Writer, in WindowQuery) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"MessageID\":"
+		out.RawString(prefix[1:])
+		if in.MessageID == nil {
+			out.RawString("null")
+		} else {
+			out.RawText((*in.MessageID).MarshalText())
+		}
+	}
+	{
+		const prefix string = ",\"Date\":"
+		out.RawString(prefix)
+		if in.Date == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.Date).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v WindowQuery) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v WindowQuery) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *WindowQuery) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *WindowQuery) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(l, v)
+}
+func easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *MessagePage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]Message, 0, 0)
+					} else {
+						out.Messages = []Message{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 Message
+					(v1).UnmarshalEasyJSON(in)
+					out.Messages = append(out.Messages, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "older_cursor":
+			out.OlderCursor = string(in.String())
+		case "newer_cursor":
+			out.NewerCursor = string(in.String())
+		case "has_older":
+			out.HasOlder = bool(in.Bool())
+		case "has_newer":
+			out.HasNewer = bool(in.Bool())
+		case "anchor_id":
+			if in.IsNull() {
+				in.Skip()
+				out.AnchorID = nil
+			} else {
+				if out.AnchorID == nil {
+					out.AnchorID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.AnchorID).UnmarshalText(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in MessagePage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix[1:])
+		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Messages {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.OlderCursor != "" {
+		const prefix string = ",\"older_cursor\":"
+		out.RawString(prefix)
+		out.String(string(in.OlderCursor))
+	}
+	if in.NewerCursor != "" {
+		const prefix string = ",\"newer_cursor\":"
+		out.RawString(prefix)
+		out.String(string(in.NewerCursor))
+	}
+	{
+		const prefix string = ",\"has_older\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HasOlder))
+	}
+	{
+		const prefix string = ",\"has_newer\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.HasNewer))
+	}
+	if in.AnchorID != nil {
+		const prefix string = ",\"anchor_id\":"
+		out.RawString(prefix)
+		out.RawText((*in.AnchorID).MarshalText())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessagePage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessagePage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessagePage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessagePage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(l, v)
+}
+func easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(in *jlexer.Lexer, out *MessageCursor) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "SentAt":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.SentAt).UnmarshalJSON(data))
+			}
+		case "ID":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(out *jwriter.Writer, in MessageCursor) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"SentAt\":"
+		out.RawString(prefix[1:])
+		out.Raw((in.SentAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"ID\":"
+		out.RawString(prefix)
+		out.RawText((in.ID).MarshalText())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageCursor) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageCursor) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageCursor) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageCursor) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(l, v)
+}
+func easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(in *jlexer.Lexer, out *HistoryQuery) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Before":
+			if in.IsNull() {
+				in.Skip()
+				out.Before = nil
+			} else {
+				if out.Before == nil {
+					out.Before = new(MessageCursor)
+				}
+				(*out.Before).UnmarshalEasyJSON(in)
+			}
+		case "After":
+			if in.IsNull() {
+				in.Skip()
+				out.After = nil
+			} else {
+				if out.After == nil {
+					out.After = new(MessageCursor)
+				}
+				(*out.After).UnmarshalEasyJSON(in)
+			}
+		case "Limit":
+			out.Limit = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(out *jwriter.Writer, in HistoryQuery) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Before\":"
+		out.RawString(prefix[1:])
+		if in.Before == nil {
+			out.RawString("null")
+		} else {
+			(*in.Before).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"After\":"
+		out.RawString(prefix)
+		if in.After == nil {
+			out.RawString("null")
+		} else {
+			(*in.After).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"Limit\":"
+		out.RawString(prefix)
+		out.Int(int(in.Limit))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HistoryQuery) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HistoryQuery) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson40eb0d12EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HistoryQuery) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HistoryQuery) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson40eb0d12DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
+}
@@ -0,0 +1,319 @@
+//go:generate easyjson -all import.go
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+)
+
+const (
+	// MaxImportedBodyLength — предел длины тела в символах (ограничение в БД);
+	// более длинные сообщения Telegram делятся на несколько
+	MaxImportedBodyLength = 2000
+	// MaxImportedAuthorLength — предел длины имени автора в символах
+	MaxImportedAuthorLength = 255
+	// DeletedTelegramAuthor подставляется вместо автора удалённого аккаунта
+	DeletedTelegramAuthor = "Deleted Account"
+)
+
+// TelegramExport — result.json из выгрузки одного чата в Telegram Desktop
+// (формат «Machine-readable JSON»). Разбираются только нужные импорту поля.
+//
+//easyjson:json
+type TelegramExport struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	ID       int64             `json:"id"`
+	Messages []TelegramMessage `json:"messages"`
+}
+
+//easyjson:json
+type TelegramMessage struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+	// Date — локальное время выгружавшего без часового пояса,
+	// DateUnixtime есть в выгрузках новых версий и точнее
+	Date             string `json:"date"`
+	DateUnixtime     string `json:"date_unixtime"`
+	Edited           string `json:"edited"`
+	EditedUnixtime   string `json:"edited_unixtime"`
+	From             string `json:"from"`
+	ReplyToMessageID int64  `json:"reply_to_message_id"`
+	// Text — строка или массив из строк и размеченных фрагментов;
+	// в новых выгрузках то же самое лежит в TextEntities
+	Text         easyjson.RawMessage  `json:"text"`
+	TextEntities []TelegramTextEntity `json:"text_entities"`
+	Photo        string               `json:"photo"`
+	File         string               `json:"file"`
+	FileName     string               `json:"file_name"`
+	MediaType    string               `json:"media_type"`
+	MimeType     string               `json:"mime_type"`
+	StickerEmoji string               `json:"sticker_emoji"`
+	Poll         *TelegramPoll        `json:"poll"`
+}
+
+//easyjson:json
+type TelegramTextEntity struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+	Href string `json:"href"`
+}
+
+//easyjson:json
+type TelegramPoll struct {
+	Question string               `json:"question"`
+	Answers  []TelegramPollAnswer `json:"answers"`
+}
+
+//easyjson:json
+type TelegramPollAnswer struct {
+	Text string `json:"text"`
+}
+
+// Validate проверяет, что файл похож на выгрузку одного чата, а не всего аккаунта
+func (e *TelegramExport) Validate() error {
+	if strings.TrimSpace(e.Name) == "" {
+		return errors.Join(ErrValidation, errors.New("export has no chat name, expected a single chat export"))
+	}
+	if len(e.Messages) == 0 {
+		return errors.Join(ErrValidation, errors.New("export has no messages"))
+	}
+	return nil
+}
+
+// SentAt возвращает время отправки в UTC
+func (m *TelegramMessage) SentAt() (time.Time, error) {
+	return parseTelegramTime(m.DateUnixtime, m.Date)
+}
+
+// EditedAt возвращает время последней правки или nil
+func (m *TelegramMessage) EditedAt() *time.Time {
+	if m.EditedUnixtime == "" && m.Edited == "" {
+		return nil
+	}
+	t, err := parseTelegramTime(m.EditedUnixtime, m.Edited)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func parseTelegramTime(unix, local string) (time.Time, error) {
+	if unix != "" {
+		sec, err := strconv.ParseInt(unix, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.Join(ErrValidation, err)
+		}
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02T15:04:05", local)
+	if err != nil {
+		return time.Time{}, errors.Join(ErrValidation, err)
+	}
+	return t, nil
+}
+
+// Author возвращает имя отправителя для показа
+func (m *TelegramMessage) Author() string {
+	name := strings.TrimSpace(m.From)
+	if name == "" {
+		return DeletedTelegramAuthor
+	}
+	if runes := []rune(name); len(runes) > MaxImportedAuthorLength {
+		name = string(runes[:MaxImportedAuthorLength])
+	}
+	return name
+}
+
+// Content собирает текст сообщения и переводит разметку Telegram в нашу.
+// Разметка без аналога (подчёркивание, хештеги, упоминания) остаётся текстом.
+func (m *TelegramMessage) Content() (string, []MessageEntity) {
+	var body strings.Builder
+	var entities []MessageEntity
+	offset := 0
+	for _, seg := range m.segments() {
+		length := len(utf16.Encode([]rune(seg.Text)))
+		if e, ok := seg.entity(); ok && length > 0 {
+			e.Offset, e.Length = offset, length
+			entities = append(entities, e)
+		}
+		body.WriteString(seg.Text)
+		offset += length
+	}
+
+	if m.Poll != nil {
+		if body.Len() > 0 {
+			body.WriteString("\n")
+		}
+		body.WriteString("📊 " + m.Poll.Question)
+		for _, a := range m.Poll.Answers {
+			body.WriteString("\n• " + a.Text)
+		}
+	}
+	if body.Len() == 0 && m.StickerEmoji != "" {
+		body.WriteString(m.StickerEmoji)
+	}
+
+	// Разметка из чужого клиента не должна мешать импорту текста,
+	// поэтому неподходящие фрагменты отбрасываются по одному
+	text := body.String()
+	valid := entities[:0]
+	for _, e := range entities {
+		if len(valid) < MaxMessageEntities && ValidateEntities(text, []MessageEntity{e}) == nil {
+			valid = append(valid, e)
+		}
+	}
+	if len(valid) == 0 {
+		return text, nil
+	}
+	return text, valid
+}
+
+func (m *TelegramMessage) segments() []TelegramTextEntity {
+	if len(m.TextEntities) > 0 {
+		return m.TextEntities
+	}
+	if len(m.Text) == 0 {
+		return nil
+	}
+
+	var plain string
+	if json.Unmarshal(m.Text, &plain) == nil {
+		return []TelegramTextEntity{{Type: "plain", Text: plain}}
+	}
+	var parts []json.RawMessage
+	if json.Unmarshal(m.Text, &parts) != nil {
+		return nil
+	}
+	segments := make([]TelegramTextEntity, 0, len(parts))
+	for _, part := range parts {
+		var seg TelegramTextEntity
+		if json.Unmarshal(part, &plain) == nil {
+			seg = TelegramTextEntity{Type: "plain", Text: plain}
+		} else if json.Unmarshal(part, &seg) != nil {
+			continue
+		}
+		segments = append(segments, seg)
+	}
+	return segments
+}
+
+func (s TelegramTextEntity) entity() (MessageEntity, bool) {
+	switch s.Type {
+	case "bold":
+		return MessageEntity{Type: EntityBold}, true
+	case "italic":
+		return MessageEntity{Type: EntityItalic}, true
+	case "code", "pre":
+		return MessageEntity{Type: EntityCode}, true
+	case "spoiler":
+		return MessageEntity{Type: EntitySpoiler}, true
+	case "text_link":
+		return MessageEntity{Type: EntityLink, URL: s.Href}, true
+	case "link":
+		return MessageEntity{Type: EntityLink, URL: s.Text}, true
+	}
+	return MessageEntity{}, false
+}
+
+// TelegramAttachment — файл сообщения внутри папки выгрузки
+type TelegramAttachment struct {
+	Path     string
+	Name     string
+	MimeType string
+	IsPhoto  bool
+	IsVoice  bool
+}
+
+// Attachments возвращает файлы сообщения. Файлы, которые Telegram не включил
+// в выгрузку, вместо пути содержат пояснение в скобках и пропускаются.
+func (m *TelegramMessage) Attachments() (files []TelegramAttachment, missing []string) {
+	add := func(p, name, mimeType string, photo, voice bool) {
+		switch {
+		case p == "":
+		case strings.HasPrefix(p, "("):
+			missing = append(missing, p)
+		default:
+			if name == "" {
+				name = p[strings.LastIndex(p, "/")+1:]
+			}
+			files = append(files, TelegramAttachment{Path: p, Name: name, MimeType: mimeType, IsPhoto: photo, IsVoice: voice})
+		}
+	}
+	add(m.Photo, "", "image/jpeg", true, false)
+	add(m.File, m.FileName, m.MimeType, m.MediaType == "sticker" && strings.HasPrefix(m.MimeType, "image/"), m.MediaType == "voice_message")
+	return files, missing
+}
+
+// ImportedMessage — сообщение, готовое к вставке в импортированный чат
+type ImportedMessage struct {
+	ID              uuid.UUID
+	ParentMessageID *uuid.UUID
+	Author          string
+	Body            string
+	Entities        []MessageEntity
+	SentAt          time.Time
+	EditedAt        *time.Time
+	Payloads        []Payload
+}
+
+// ImportedChat — группа, создаваемая импортом, вместе со всей историей
+type ImportedChat struct {
+	ID       uuid.UUID
+	Title    string
+	OwnerID  uuid.UUID
+	Messages []ImportedMessage
+}
+
+// ImportResult — итог импорта
+//
+//easyjson:json
+type ImportResult struct {
+	ChatID       uuid.UUID `json:"chat_id"`
+	Title        string    `json:"title"`
+	Messages     int       `json:"messages"`
+	Files        int       `json:"files"`
+	Skipped      int       `json:"skipped"`
+	MissingFiles []string  `json:"missing_files,omitempty"`
+}
+
+// ImportedText — часть текста длинного сообщения со своей разметкой
+type ImportedText struct {
+	Body     string
+	Entities []MessageEntity
+}
+
+// SplitImportedText режет текст на части не длиннее limit символов.
+// Разметка на стыке частей обрезается по границе и сохраняется в обеих.
+func SplitImportedText(body string, entities []MessageEntity, limit int) []ImportedText {
+	runes := []rune(body)
+	if len(runes) <= limit {
+		return []ImportedText{{Body: body, Entities: entities}}
+	}
+
+	var parts []ImportedText
+	u16Start := 0
+	for start := 0; start < len(runes); start += limit {
+		chunk := runes[start:min(start+limit, len(runes))]
+		u16End := u16Start + len(utf16.Encode(chunk))
+
+		part := ImportedText{Body: string(chunk)}
+		for _, e := range entities {
+			from, to := max(e.Offset, u16Start), min(e.end(), u16End)
+			if to > from {
+				e.Offset, e.Length = from-u16Start, to-from
+				part.Entities = append(part.Entities, e)
+			}
+		}
+		parts = append(parts, part)
+		u16Start = u16End
+	}
+	return parts
+}
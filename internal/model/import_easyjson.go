@@ -0,0 +1,1272 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(in *jlexer.Lexer, out *TelegramTextEntity) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "text":
+			out.Text = string(in.String())
+		case "href":
+			out.Href = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(out *jwriter.Writer, in TelegramTextEntity) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		out.String(string(in.Text))
+	}
+	{
+		const prefix string = ",\"href\":"
+		out.RawString(prefix)
+		out.String(string(in.Href))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TelegramTextEntity) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TelegramTextEntity) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TelegramTextEntity) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TelegramTextEntity) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *TelegramPollAnswer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in TelegramPollAnswer) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TelegramPollAnswer) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TelegramPollAnswer) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TelegramPollAnswer) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TelegramPollAnswer) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(in *jlexer.Lexer, out *TelegramPoll) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "question":
+			out.Question = string(in.String())
+		case "answers":
+			if in.IsNull() {
+				in.Skip()
+				out.Answers = nil
+			} else {
+				in.Delim('[')
+				if out.Answers == nil {
+					if !in.IsDelim(']') {
+						out.Answers = make([]TelegramPollAnswer, 0, 4)
+					} else {
+						out.Answers = []TelegramPollAnswer{}
+					}
+				} else {
+					out.Answers = (out.Answers)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 TelegramPollAnswer
+					(v1).UnmarshalEasyJSON(in)
+					out.Answers = append(out.Answers, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(out *jwriter.Writer, in TelegramPoll) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Question))
+	}
+	{
+		const prefix string = ",\"answers\":"
+		out.RawString(prefix)
+		if in.Answers == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Answers {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TelegramPoll) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TelegramPoll) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TelegramPoll) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TelegramPoll) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(in *jlexer.Lexer, out *TelegramMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int64(in.Int64())
+		case "type":
+			out.Type = string(in.String())
+		case "date":
+			out.Date = string(in.String())
+		case "date_unixtime":
+			out.DateUnixtime = string(in.String())
+		case "edited":
+			out.Edited = string(in.String())
+		case "edited_unixtime":
+			out.EditedUnixtime = string(in.String())
+		case "from":
+			out.From = string(in.String())
+		case "reply_to_message_id":
+			out.ReplyToMessageID = int64(in.Int64())
+		case "text":
+			(out.Text).UnmarshalEasyJSON(in)
+		case "text_entities":
+			if in.IsNull() {
+				in.Skip()
+				out.TextEntities = nil
+			} else {
+				in.Delim('[')
+				if out.TextEntities == nil {
+					if !in.IsDelim(']') {
+						out.TextEntities = make([]TelegramTextEntity, 0, 1)
+					} else {
+						out.TextEntities = []TelegramTextEntity{}
+					}
+				} else {
+					out.TextEntities = (out.TextEntities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 TelegramTextEntity
+					(v4).UnmarshalEasyJSON(in)
+					out.TextEntities = append(out.TextEntities, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "photo":
+			out.Photo = string(in.String())
+		case "file":
+			out.File = string(in.String())
+		case "file_name":
+			out.FileName = string(in.String())
+		case "media_type":
+			out.MediaType = string(in.String())
+		case "mime_type":
+			out.MimeType = string(in.String())
+		case "sticker_emoji":
+			out.StickerEmoji = string(in.String())
+		case "poll":
+			if in.IsNull() {
+				in.Skip()
+				out.Poll = nil
+			} else {
+				if out.Poll == nil {
+					out.Poll = new(TelegramPoll)
+				}
+				(*out.Poll).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(out *jwriter.Writer, in TelegramMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"date\":"
+		out.RawString(prefix)
+		out.String(string(in.Date))
+	}
+	{
+		const prefix string = ",\"date_unixtime\":"
+		out.RawString(prefix)
+		out.String(string(in.DateUnixtime))
+	}
+	{
+		const prefix string = ",\"edited\":"
+		out.RawString(prefix)
+		out.String(string(in.Edited))
+	}
+	{
+		const prefix string = ",\"edited_unixtime\":"
+		out.RawString(prefix)
+		out.String(string(in.EditedUnixtime))
+	}
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(in.From))
+	}
+	{
+		const prefix string = ",\"reply_to_message_id\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ReplyToMessageID))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		(in.Text).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"text_entities\":"
+		out.RawString(prefix)
+		if in.TextEntities == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.TextEntities {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				(v6).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"photo\":"
+		out.RawString(prefix)
+		out.String(string(in.Photo))
+	}
+	{
+		const prefix string = ",\"file\":"
+		out.RawString(prefix)
+		out.String(string(in.File))
+	}
+	{
+		const prefix string = ",\"file_name\":"
+		out.RawString(prefix)
+		out.String(string(in.FileName))
+	}
+	{
+		const prefix string = ",\"media_type\":"
+		out.RawString(prefix)
+		out.String(string(in.MediaType))
+	}
+	{
+		const prefix string = ",\"mime_type\":"
+		out.RawString(prefix)
+		out.String(string(in.MimeType))
+	}
+	{
+		const prefix string = ",\"sticker_emoji\":"
+		out.RawString(prefix)
+		out.String(string(in.StickerEmoji))
+	}
+	{
+		const prefix string = ",\"poll\":"
+		out.RawString(prefix)
+		if in.Poll == nil {
+			out.RawString("null")
+		} else {
+			(*in.Poll).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TelegramMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TelegramMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TelegramMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TelegramMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(in *jlexer.Lexer, out *TelegramExport) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "id":
+			out.ID = int64(in.Int64())
+		case "messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]TelegramMessage, 0, 0)
+					} else {
+						out.Messages = []TelegramMessage{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 TelegramMessage
+					(v7).UnmarshalEasyJSON(in)
+					out.Messages = append(out.Messages, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(out *jwriter.Writer, in TelegramExport) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix)
+		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Messages {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TelegramExport) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TelegramExport) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TelegramExport) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TelegramExport) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(in *jlexer.Lexer, out *TelegramAttachment) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Path":
+			out.Path = string(in.String())
+		case "Name":
+			out.Name = string(in.String())
+		case "MimeType":
+			out.MimeType = string(in.String())
+		case "IsPhoto":
+			out.IsPhoto = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(out *jwriter.Writer, in TelegramAttachment) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Path\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Path))
+	}
+	{
+		const prefix string = ",\"Name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	{
+		const prefix string = ",\"MimeType\":"
+		out.RawString(prefix)
+		out.String(string(in.MimeType))
+	}
+	{
+		const prefix string = ",\"IsPhoto\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsPhoto))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TelegramAttachment) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TelegramAttachment) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TelegramAttachment) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TelegramAttachment) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(in *jlexer.Lexer, out *ImportedText) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "Body":
+			out.Body = string(in.String())
+		case "Entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 MessageEntity
+					(v10).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(out *jwriter.Writer, in ImportedText) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"Body\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Body))
+	}
+	{
+		const prefix string = ",\"Entities\":"
+		out.RawString(prefix)
+		if in.Entities == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Entities {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportedText) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportedText) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportedText) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportedText) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(in *jlexer.Lexer, out *ImportedMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ID":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "ParentMessageID":
+			if in.IsNull() {
+				in.Skip()
+				out.ParentMessageID = nil
+			} else {
+				if out.ParentMessageID == nil {
+					out.ParentMessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.ParentMessageID).UnmarshalText(data))
+				}
+			}
+		case "Author":
+			out.Author = string(in.String())
+		case "Body":
+			out.Body = string(in.String())
+		case "Entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 MessageEntity
+					(v13).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "SentAt":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.SentAt).UnmarshalJSON(data))
+			}
+		case "EditedAt":
+			if in.IsNull() {
+				in.Skip()
+				out.EditedAt = nil
+			} else {
+				if out.EditedAt == nil {
+					out.EditedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.EditedAt).UnmarshalJSON(data))
+				}
+			}
+		case "Payloads":
+			if in.IsNull() {
+				in.Skip()
+				out.Payloads = nil
+			} else {
+				in.Delim('[')
+				if out.Payloads == nil {
+					if !in.IsDelim(']') {
+						out.Payloads = make([]Payload, 0, 1)
+					} else {
+						out.Payloads = []Payload{}
+					}
+				} else {
+					out.Payloads = (out.Payloads)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v14 Payload
+					(v14).UnmarshalEasyJSON(in)
+					out.Payloads = append(out.Payloads, v14)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(out *jwriter.Writer, in ImportedMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ID\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"ParentMessageID\":"
+		out.RawString(prefix)
+		if in.ParentMessageID == nil {
+			out.RawString("null")
+		} else {
+			out.RawText((*in.ParentMessageID).MarshalText())
+		}
+	}
+	{
+		const prefix string = ",\"Author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"Body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	{
+		const prefix string = ",\"Entities\":"
+		out.RawString(prefix)
+		if in.Entities == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v15, v16 := range in.Entities {
+				if v15 > 0 {
+					out.RawByte(',')
+				}
+				(v16).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"SentAt\":"
+		out.RawString(prefix)
+		out.Raw((in.SentAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"EditedAt\":"
+		out.RawString(prefix)
+		if in.EditedAt == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.EditedAt).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"Payloads\":"
+		out.RawString(prefix)
+		if in.Payloads == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v17, v18 := range in.Payloads {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportedMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportedMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportedMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportedMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(in *jlexer.Lexer, out *ImportedChat) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ID":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "Title":
+			out.Title = string(in.String())
+		case "OwnerID":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.OwnerID).UnmarshalText(data))
+			}
+		case "Messages":
+			if in.IsNull() {
+				in.Skip()
+				out.Messages = nil
+			} else {
+				in.Delim('[')
+				if out.Messages == nil {
+					if !in.IsDelim(']') {
+						out.Messages = make([]ImportedMessage, 0, 0)
+					} else {
+						out.Messages = []ImportedMessage{}
+					}
+				} else {
+					out.Messages = (out.Messages)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 ImportedMessage
+					(v19).UnmarshalEasyJSON(in)
+					out.Messages = append(out.Messages, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(out *jwriter.Writer, in ImportedChat) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ID\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"Title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"OwnerID\":"
+		out.RawString(prefix)
+		out.RawText((in.OwnerID).MarshalText())
+	}
+	{
+		const prefix string = ",\"Messages\":"
+		out.RawString(prefix)
+		if in.Messages == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Messages {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				(v21).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportedChat) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportedChat) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportedChat) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportedChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(l, v)
+}
+func easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(in *jlexer.Lexer, out *ImportResult) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chat_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ChatID).UnmarshalText(data))
+			}
+		case "title":
+			out.Title = string(in.String())
+		case "messages":
+			out.Messages = int(in.Int())
+		case "files":
+			out.Files = int(in.Int())
+		case "skipped":
+			out.Skipped = int(in.Int())
+		case "missing_files":
+			if in.IsNull() {
+				in.Skip()
+				out.MissingFiles = nil
+			} else {
+				in.Delim('[')
+				if out.MissingFiles == nil {
+					if !in.IsDelim(']') {
+						out.MissingFiles = make([]string, 0, 4)
+					} else {
+						out.MissingFiles = []string{}
+					}
+				} else {
+					out.MissingFiles = (out.MissingFiles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 string
+					v22 = string(in.String())
+					out.MissingFiles = append(out.MissingFiles, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(out *jwriter.Writer, in ImportResult) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chat_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ChatID).MarshalText())
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"messages\":"
+		out.RawString(prefix)
+		out.Int(int(in.Messages))
+	}
+	{
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		out.Int(int(in.Files))
+	}
+	{
+		const prefix string = ",\"skipped\":"
+		out.RawString(prefix)
+		out.Int(int(in.Skipped))
+	}
+	if len(in.MissingFiles) != 0 {
+		const prefix string = ",\"missing_files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v23, v24 := range in.MissingFiles {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ImportResult) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ImportResult) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson63a4a5efEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ImportResult) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ImportResult) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson63a4a5efDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(l, v)
+}
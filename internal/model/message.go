@@ -28,12 +28,16 @@ type Message struct {
 	ChatID          uuid.UUID  `json:"chat_id,omitempty"`
 	UserID          uuid.UUID  `json:"user_id,omitempty"`
 
-	Body        string    `json:"body,omitempty"`
-	SentAt      time.Time `json:"sent_at,omitempty"`
-	IsRedacted  bool      `json:"is_redacted,omitempty"`
-	AvatarPath  *string   `json:"avatar_path,omitempty"`
-	Username    string    `json:"user,omitempty"`
-	MessageType string    `json:"message_type" valid:"optional,in(text|sticker|file|photo)"`
+	Body string `json:"body,omitempty"`
+	// Entities — разметка текста: форматирование от клиента и упоминания
+	Entities    []MessageEntity `json:"entities,omitempty" valid:"-"`
+	SentAt      time.Time       `json:"sent_at,omitempty"`
+	IsRedacted  bool            `json:"is_redacted,omitempty"`
+	EditCount   int             `json:"edit_count,omitempty"`
+	EditedAt    *time.Time      `json:"edited_at,omitempty"`
+	AvatarPath  *string         `json:"avatar_path,omitempty"`
+	Username    string          `json:"user,omitempty"`
+	MessageType string          `json:"message_type" valid:"optional,in(text|sticker|file|photo|voice)"`
 
 	Files        []multipart.File        `json:"-" valid:"-"`
 	FilesHeaders []*multipart.FileHeader `json:"-" valid:"-"`
@@ -43,12 +47,210 @@ type Message struct {
 	PhotosHeaders []*multipart.FileHeader `json:"-" valid:"-"`
 	PhotosDTO     []Payload               `json:"photos,omitempty" valid:"-"`
 
+	// Voice — голосовое или аудиосообщение; к нему можно добавить только подпись
+	Voice       multipart.File        `json:"-" valid:"-"`
+	VoiceHeader *multipart.FileHeader `json:"-" valid:"-"`
+	VoiceDTO    *Payload              `json:"voice,omitempty" valid:"-"`
+
 	Sticker string `json:"sticker" valid:"optional,length(0|255)"`
+
+	Reactions []ReactionCount `json:"reactions,omitempty" valid:"-"`
+
+	Parent     *ParentPreview `json:"parent,omitempty" valid:"-"`
+	ReplyCount int            `json:"reply_count,omitempty" valid:"-"`
+
+	Forward *ForwardInfo `json:"forward,omitempty" valid:"-"`
+
+	// SendAt задаёт отложенную отправку, в ответах не используется
+	SendAt *time.Time `json:"-" valid:"-"`
+	// TTL — время жизни сообщения в секундах, перекрывает настройку чата
+	TTL       *int       `json:"-" valid:"-"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" valid:"-"`
+	// DeletedAt задан у «надгробия» — сообщения, удалённого для всех
+	DeletedAt *time.Time `json:"deleted_at,omitempty" valid:"-"`
+	// ClientMessageID — ключ идемпотентности от клиента, возвращается в событии
+	// newMessage, чтобы отправитель сопоставил его с оптимистичным сообщением
+	ClientMessageID string `json:"client_message_id,omitempty" valid:"-"`
+	// LinkPreview — карточка первой ссылки; появляется асинхронно,
+	// клиенту приходит отдельным событием updateMessage
+	LinkPreview *LinkPreview `json:"link_preview,omitempty" valid:"-"`
+
+	// Poll — опрос с текущими итогами, PollInput — опрос при отправке
+	Poll      *Poll      `json:"poll,omitempty" valid:"-"`
+	PollInput *PollInput `json:"-" valid:"-"`
+}
+
+//easyjson:json
+type LinkPreview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+}
+
+// IdempotencyWindow — сколько времени повтор с тем же client_message_id
+// возвращает исходное сообщение вместо создания нового
+const IdempotencyWindow = 24 * time.Hour
+
+// ValidateClientMessageID проверяет клиентский ключ: до 64 печатных ASCII-символов
+func ValidateClientMessageID(id string) error {
+	if id == "" || len(id) > 64 {
+		return errors.Join(ErrValidation, errors.New("client_message_id must be 1 to 64 characters long"))
+	}
+	if !govalidator.IsPrintableASCII(id) {
+		return errors.Join(ErrValidation, errors.New("client_message_id must contain printable ASCII characters only"))
+	}
+	return nil
+}
+
+// DeleteMode — режим удаления сообщения
+type DeleteMode string
+
+const (
+	// DeleteForMe скрывает сообщение только для удалившего
+	DeleteForMe DeleteMode = "me"
+	// DeleteForEveryone оставляет вместо сообщения «надгробие» для всех участников
+	DeleteForEveryone DeleteMode = "everyone"
+)
+
+func (m DeleteMode) IsValid() bool {
+	return m == DeleteForMe || m == DeleteForEveryone
+}
+
+// ForwardInfo — сведения об исходном сообщении для пересланной копии
+//
+//easyjson:json
+type ForwardInfo struct {
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	Username string     `json:"user,omitempty"`
+	ChatID   *uuid.UUID `json:"chat_id,omitempty"`
+	SentAt   time.Time  `json:"sent_at"`
+}
+
+// MaxForwardMessages — сколько сообщений можно переслать за один запрос
+const MaxForwardMessages = 100
+
+// MaxBulkDeleteMessages — сколько сообщений можно удалить за один запрос
+const MaxBulkDeleteMessages = 100
+
+// BulkDeleteInput — пакетное удаление сообщений; режим как у одиночного удаления
+//
+//easyjson:json
+type BulkDeleteInput struct {
+	MessageIDs []uuid.UUID `json:"message_ids"`
+	Mode       DeleteMode  `json:"mode,omitempty"`
+}
+
+func (b *BulkDeleteInput) Validate() error {
+	if b.Mode == "" {
+		b.Mode = DeleteForEveryone
+	}
+	if !b.Mode.IsValid() {
+		return errors.Join(ErrValidation, fmt.Errorf("unknown delete mode %q", b.Mode))
+	}
+	if len(b.MessageIDs) == 0 || len(b.MessageIDs) > MaxBulkDeleteMessages {
+		return errors.Join(ErrValidation, fmt.Errorf("from 1 to %d messages can be deleted at once", MaxBulkDeleteMessages))
+	}
+	seen := make(map[uuid.UUID]struct{}, len(b.MessageIDs))
+	unique := b.MessageIDs[:0]
+	for _, id := range b.MessageIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	b.MessageIDs = unique
+	return nil
+}
+
+//easyjson:json
+type ForwardInput struct {
+	FromChatID string   `json:"from_chat_id" valid:"required,uuid"`
+	MessageIDs []string `json:"message_ids" valid:"required"`
+}
+
+func (f *ForwardInput) Validate() error {
+	if _, err := govalidator.ValidateStruct(f); err != nil {
+		return errors.Join(ErrValidation, fmt.Errorf("invalid forward input: %w", err))
+	}
+	if len(f.MessageIDs) == 0 || len(f.MessageIDs) > MaxForwardMessages {
+		return errors.Join(ErrValidation, fmt.Errorf("from 1 to %d messages can be forwarded at once", MaxForwardMessages))
+	}
+	for _, id := range f.MessageIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return errors.Join(ErrValidation, fmt.Errorf("invalid message id %q", id))
+		}
+	}
+	return nil
+}
+
+// ParentPreview — краткое содержимое сообщения, на которое отвечают
+//
+//easyjson:json
+type ParentPreview struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"user,omitempty"`
+	Body        string    `json:"body,omitempty"`
+	MessageType string    `json:"message_type,omitempty"`
 }
 
 //easyjson:json
 type MessageList []Message
 
+// MessageEditWindow — сколько времени после отправки автор может править сообщение
+const MessageEditWindow = 48 * time.Hour
+
+// MessageRevision — одна из версий текста сообщения. Version 1 — исходный
+// текст; ReplacedAt пуст у текущей версии.
+//
+//easyjson:json
+type MessageRevision struct {
+	Version    int        `json:"version"`
+	Body       string     `json:"body"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReplacedAt *time.Time `json:"replaced_at,omitempty"`
+}
+
+//easyjson:json
+type MessageRevisionList []MessageRevision
+
+// AllowedReactions — набор эмодзи, которыми можно реагировать на сообщения.
+// Чтобы добавить реакцию, достаточно дописать её сюда.
+var AllowedReactions = map[string]struct{}{
+	"👍":  {},
+	"👎":  {},
+	"❤️": {},
+	"🔥":  {},
+	"😂":  {},
+	"😮":  {},
+	"😢":  {},
+	"🎉":  {},
+}
+
+//easyjson:json
+type ReactionCount struct {
+	Reaction string `json:"reaction"`
+	Count    int    `json:"count"`
+}
+
+//easyjson:json
+type ReactionInput struct {
+	Reaction string `json:"reaction" valid:"required"`
+}
+
+func (ri *ReactionInput) Validate() error {
+	if _, err := govalidator.ValidateStruct(ri); err != nil {
+		return errors.Join(ErrValidation, fmt.Errorf("invalid reaction input: %w", err))
+	}
+	if _, ok := AllowedReactions[ri.Reaction]; !ok {
+		return errors.Join(ErrValidation, fmt.Errorf("unsupported reaction: %s", ri.Reaction))
+	}
+	return nil
+}
+
 func (m *Message) Validate() error {
 	// Проверка, что хотя бы одно содержимое предоставлено:
 	// либо Body, либо Sticker, либо хотя бы один файл или фото
@@ -56,9 +258,26 @@ func (m *Message) Validate() error {
 	hasSticker := strings.TrimSpace(m.Sticker) != ""
 	hasFiles := len(m.Files) > 0 || len(m.FilesDTO) > 0
 	hasPhotos := len(m.Photos) > 0 || len(m.PhotosDTO) > 0
+	hasVoice := m.Voice != nil || m.VoiceDTO != nil
+
+	if hasVoice && (hasSticker || hasFiles || hasPhotos || m.PollInput != nil) {
+		return errors.Join(ErrValidation, errors.New("voice cannot be combined with sticker, files, photos or poll"))
+	}
+
+	if m.PollInput != nil {
+		if hasSticker || hasFiles || hasPhotos {
+			return errors.Join(ErrValidation, errors.New("poll cannot be combined with sticker, files or photos"))
+		}
+		if err := m.PollInput.Validate(); err != nil {
+			return err
+		}
+		// Вопрос опроса становится текстом сообщения: его видно в списке чатов и в ответах
+		m.Body = m.PollInput.Question
+		hasText = true
+	}
 
-	if !hasText && !hasSticker && !hasFiles && !hasPhotos {
-		return errors.Join(ErrValidation, errors.New("at least one of body, sticker, file, or photo must be provided"))
+	if !hasText && !hasSticker && !hasFiles && !hasPhotos && !hasVoice {
+		return errors.Join(ErrValidation, errors.New("at least one of body, sticker, file, photo or voice must be provided"))
 	}
 
 	// Основная валидация через govalidator
@@ -66,9 +285,103 @@ func (m *Message) Validate() error {
 		return errors.Join(ErrValidation, fmt.Errorf("invalid message input: %w", err))
 	}
 
+	return ValidateEntities(m.Body, m.Entities)
+}
+
+// Payloads возвращает все загруженные вложения сообщения
+func (m *Message) Payloads() []Payload {
+	return joinPayloads(m.FilesDTO, m.PhotosDTO, m.VoiceDTO)
+}
+
+func joinPayloads(files, photos []Payload, voice *Payload) []Payload {
+	payloads := make([]Payload, 0, len(files)+len(photos)+1)
+	payloads = append(append(payloads, files...), photos...)
+	if voice != nil {
+		payloads = append(payloads, *voice)
+	}
+	return payloads
+}
+
+// MaxScheduleAhead — насколько далеко вперёд можно запланировать сообщение
+const MaxScheduleAhead = 365 * 24 * time.Hour
+
+//easyjson:json
+type ScheduledMessage struct {
+	ID              uuid.UUID       `json:"id"`
+	ChatID          uuid.UUID       `json:"chat_id"`
+	UserID          uuid.UUID       `json:"user_id"`
+	Body            string          `json:"body,omitempty"`
+	Entities        []MessageEntity `json:"entities,omitempty"`
+	Sticker         string          `json:"sticker,omitempty"`
+	ParentMessageID *uuid.UUID      `json:"parent_message_id,omitempty"`
+	FilesDTO        []Payload       `json:"files,omitempty"`
+	PhotosDTO       []Payload       `json:"photos,omitempty"`
+	VoiceDTO        *Payload        `json:"voice,omitempty"`
+	SendAt          time.Time       `json:"send_at"`
+	TTL             *int            `json:"ttl,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+// Payloads возвращает все вложения отложенного сообщения
+func (m *ScheduledMessage) Payloads() []Payload {
+	return joinPayloads(m.FilesDTO, m.PhotosDTO, m.VoiceDTO)
+}
+
+//easyjson:json
+type ScheduledMessageList []ScheduledMessage
+
+//easyjson:json
+type ScheduledMessageUpdate struct {
+	Message *string `json:"message" valid:"-"`
+	// Entities — новая разметка текста, учитывается только вместе с Message
+	Entities []MessageEntity `json:"entities,omitempty" valid:"-"`
+	SendAt   *time.Time      `json:"send_at" valid:"-"`
+}
+
+func (u *ScheduledMessageUpdate) Validate() error {
+	if u.Message == nil && u.SendAt == nil {
+		return errors.Join(ErrValidation, errors.New("nothing to update"))
+	}
+	if u.Message != nil && len([]rune(*u.Message)) > 1000 {
+		return errors.Join(ErrValidation, errors.New("message is too long"))
+	}
+	if u.SendAt != nil {
+		return ValidateSendAt(*u.SendAt)
+	}
+	return nil
+}
+
+// ValidateSendAt проверяет, что время отправки в будущем и не дальше MaxScheduleAhead
+func ValidateSendAt(sendAt time.Time) error {
+	now := time.Now()
+	if !sendAt.After(now) {
+		return errors.Join(ErrValidation, errors.New("send_at must be in the future"))
+	}
+	if sendAt.After(now.Add(MaxScheduleAhead)) {
+		return errors.Join(ErrValidation, errors.New("send_at is too far in the future"))
+	}
+	return nil
+}
+
+// MaxMessageTTL — максимальное время жизни сообщения в секундах (год)
+const MaxMessageTTL = 365 * 24 * 60 * 60
+
+// ValidateTTL проверяет время жизни сообщения в секундах
+func ValidateTTL(ttl int) error {
+	if ttl <= 0 || ttl > MaxMessageTTL {
+		return errors.Join(ErrValidation, fmt.Errorf("ttl must be between 1 and %d seconds", MaxMessageTTL))
+	}
 	return nil
 }
 
+// ReadState описывает, до какого сообщения пользователь прочитал чат
+type ReadState struct {
+	ChatID            uuid.UUID `json:"chat_id"`
+	UserID            uuid.UUID `json:"user_id"`
+	LastReadMessageID uuid.UUID `json:"last_read_message_id"`
+	ReadAt            time.Time `json:"read_at"`
+}
+
 type LastMessage struct {
 	ID       uuid.UUID `json:"id,omitempty"`
 	UserID   uuid.UUID `json:"user_id,omitempty"`
@@ -79,14 +392,15 @@ type LastMessage struct {
 
 //easyjson:json
 type MessageInput struct {
-	Message string `json:"message" valid:"required,length(1|1000)"`
+	Message  string          `json:"message" valid:"required,length(1|1000)"`
+	Entities []MessageEntity `json:"entities,omitempty" valid:"-"`
 }
 
 func (m *MessageInput) Validate() error {
 	if _, err := govalidator.ValidateStruct(m); err != nil {
 		return errors.Join(ErrValidation, fmt.Errorf("invalid message input: %w", err))
 	}
-	return nil
+	return ValidateEntities(m.Message, m.Entities)
 }
 
 type SendMessage struct {
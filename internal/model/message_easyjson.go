@@ -8,6 +8,7 @@ import (
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
 )
 
 // suppress unused package warning
@@ -103,139 +104,209 @@ func (v *SendMessage) UnmarshalJSON(data []byte) error {
 func (v *SendMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(l, v)
 }
-func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *MessageList) {
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *ScheduledMessageUpdate) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
 		in.Skip()
-		*out = nil
-	} else {
-		in.Delim('[')
-		if *out == nil {
-			if !in.IsDelim(']') {
-				*out = make(MessageList, 0, 0)
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			if in.IsNull() {
+				in.Skip()
+				out.Message = nil
 			} else {
-				*out = MessageList{}
+				if out.Message == nil {
+					out.Message = new(string)
+				}
+				*out.Message = string(in.String())
 			}
-		} else {
-			*out = (*out)[:0]
-		}
-		for !in.IsDelim(']') {
-			var v1 Message
-			(v1).UnmarshalEasyJSON(in)
-			*out = append(*out, v1)
-			in.WantComma()
+		case "entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 MessageEntity
+					(v1).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "send_at":
+			if in.IsNull() {
+				in.Skip()
+				out.SendAt = nil
+			} else {
+				if out.SendAt == nil {
+					out.SendAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.SendAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
 		}
-		in.Delim(']')
+		in.WantComma()
 	}
+	in.Delim('}')
 	if isTopLevel {
 		in.Consumed()
 	}
 }
-func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in MessageList) {
-	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-		out.RawString("null")
-	} else {
-		out.RawByte('[')
-		for v2, v3 := range in {
-			if v2 > 0 {
-				out.RawByte(',')
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in ScheduledMessageUpdate) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix[1:])
+		if in.Message == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.Message))
+		}
+	}
+	if len(in.Entities) != 0 {
+		const prefix string = ",\"entities\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v2, v3 := range in.Entities {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
 			}
-			(v3).MarshalEasyJSON(out)
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"send_at\":"
+		out.RawString(prefix)
+		if in.SendAt == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.SendAt).MarshalJSON())
 		}
-		out.RawByte(']')
 	}
+	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageList) MarshalJSON() ([]byte, error) {
+func (v ScheduledMessageUpdate) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageList) MarshalEasyJSON(w *jwriter.Writer) {
+func (v ScheduledMessageUpdate) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageList) UnmarshalJSON(data []byte) error {
+func (v *ScheduledMessageUpdate) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *ScheduledMessageUpdate) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(l, v)
 }
-func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(in *jlexer.Lexer, out *MessageInput) {
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(in *jlexer.Lexer, out *ScheduledMessageList) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
 		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(ScheduledMessageList, 0, 0)
+			} else {
+				*out = ScheduledMessageList{}
+			}
+		} else {
+			*out = (*out)[:0]
 		}
-		switch key {
-		case "message":
-			out.Message = string(in.String())
-		default:
-			in.SkipRecursive()
+		for !in.IsDelim(']') {
+			var v4 ScheduledMessage
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
 		}
-		in.WantComma()
+		in.Delim(']')
 	}
-	in.Delim('}')
 	if isTopLevel {
 		in.Consumed()
 	}
 }
-func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(out *jwriter.Writer, in MessageInput) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"message\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Message))
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(out *jwriter.Writer, in ScheduledMessageList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
 	}
-	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageInput) MarshalJSON() ([]byte, error) {
+func (v ScheduledMessageList) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageInput) MarshalEasyJSON(w *jwriter.Writer) {
+func (v ScheduledMessageList) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageInput) UnmarshalJSON(data []byte) error {
+func (v *ScheduledMessageList) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *ScheduledMessageList) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(l, v)
 }
-func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(in *jlexer.Lexer, out *Message) {
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(in *jlexer.Lexer, out *ScheduledMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -258,18 +329,6 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 			if data := in.UnsafeBytes(); in.Ok() {
 				in.AddError((out.ID).UnmarshalText(data))
 			}
-		case "parent_message_id":
-			if in.IsNull() {
-				in.Skip()
-				out.ParentMessageID = nil
-			} else {
-				if out.ParentMessageID == nil {
-					out.ParentMessageID = new(uuid.UUID)
-				}
-				if data := in.UnsafeBytes(); in.Ok() {
-					in.AddError((*out.ParentMessageID).UnmarshalText(data))
-				}
-			}
 		case "chat_id":
 			if data := in.UnsafeBytes(); in.Ok() {
 				in.AddError((out.ChatID).UnmarshalText(data))
@@ -280,26 +339,43 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 			}
 		case "body":
 			out.Body = string(in.String())
-		case "sent_at":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.SentAt).UnmarshalJSON(data))
+		case "entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 MessageEntity
+					(v7).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
-		case "is_redacted":
-			out.IsRedacted = bool(in.Bool())
-		case "avatar_path":
+		case "sticker":
+			out.Sticker = string(in.String())
+		case "parent_message_id":
 			if in.IsNull() {
 				in.Skip()
-				out.AvatarPath = nil
+				out.ParentMessageID = nil
 			} else {
-				if out.AvatarPath == nil {
-					out.AvatarPath = new(string)
+				if out.ParentMessageID == nil {
+					out.ParentMessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.ParentMessageID).UnmarshalText(data))
 				}
-				*out.AvatarPath = string(in.String())
 			}
-		case "user":
-			out.Username = string(in.String())
-		case "message_type":
-			out.MessageType = string(in.String())
 		case "files":
 			if in.IsNull() {
 				in.Skip()
@@ -308,7 +384,7 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 				in.Delim('[')
 				if out.FilesDTO == nil {
 					if !in.IsDelim(']') {
-						out.FilesDTO = make([]Payload, 0, 1)
+						out.FilesDTO = make([]Payload, 0, 0)
 					} else {
 						out.FilesDTO = []Payload{}
 					}
@@ -316,9 +392,9 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 					out.FilesDTO = (out.FilesDTO)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v4 Payload
-					(v4).UnmarshalEasyJSON(in)
-					out.FilesDTO = append(out.FilesDTO, v4)
+					var v8 Payload
+					(v8).UnmarshalEasyJSON(in)
+					out.FilesDTO = append(out.FilesDTO, v8)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -331,7 +407,7 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 				in.Delim('[')
 				if out.PhotosDTO == nil {
 					if !in.IsDelim(']') {
-						out.PhotosDTO = make([]Payload, 0, 1)
+						out.PhotosDTO = make([]Payload, 0, 0)
 					} else {
 						out.PhotosDTO = []Payload{}
 					}
@@ -339,15 +415,41 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 					out.PhotosDTO = (out.PhotosDTO)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v5 Payload
-					(v5).UnmarshalEasyJSON(in)
-					out.PhotosDTO = append(out.PhotosDTO, v5)
+					var v9 Payload
+					(v9).UnmarshalEasyJSON(in)
+					out.PhotosDTO = append(out.PhotosDTO, v9)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "sticker":
-			out.Sticker = string(in.String())
+		case "voice":
+			if in.IsNull() {
+				in.Skip()
+				out.VoiceDTO = nil
+			} else {
+				if out.VoiceDTO == nil {
+					out.VoiceDTO = new(Payload)
+				}
+				(*out.VoiceDTO).UnmarshalEasyJSON(in)
+			}
+		case "send_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.SendAt).UnmarshalJSON(data))
+			}
+		case "ttl":
+			if in.IsNull() {
+				in.Skip()
+				out.TTL = nil
+			} else {
+				if out.TTL == nil {
+					out.TTL = new(int)
+				}
+				*out.TTL = int(in.Int())
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -358,65 +460,1133 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(i
 		in.Consumed()
 	}
 }
-func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(out *jwriter.Writer, in Message) {
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(out *jwriter.Writer, in ScheduledMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if true {
+	{
 		const prefix string = ",\"id\":"
-		first = false
 		out.RawString(prefix[1:])
 		out.RawText((in.ID).MarshalText())
 	}
-	if in.ParentMessageID != nil {
-		const prefix string = ",\"parent_message_id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.RawText((*in.ParentMessageID).MarshalText())
-	}
-	if true {
+	{
 		const prefix string = ",\"chat_id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.RawText((in.ChatID).MarshalText())
 	}
-	if true {
+	{
 		const prefix string = ",\"user_id\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.RawText((in.UserID).MarshalText())
 	}
 	if in.Body != "" {
 		const prefix string = ",\"body\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.String(string(in.Body))
 	}
-	if true {
-		const prefix string = ",\"sent_at\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
+	if len(in.Entities) != 0 {
+		const prefix string = ",\"entities\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v10, v11 := range in.Entities {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				(v11).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
 		}
-		out.Raw((in.SentAt).MarshalJSON())
+	}
+	if in.Sticker != "" {
+		const prefix string = ",\"sticker\":"
+		out.RawString(prefix)
+		out.String(string(in.Sticker))
+	}
+	if in.ParentMessageID != nil {
+		const prefix string = ",\"parent_message_id\":"
+		out.RawString(prefix)
+		out.RawText((*in.ParentMessageID).MarshalText())
+	}
+	if len(in.FilesDTO) != 0 {
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v12, v13 := range in.FilesDTO {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				(v13).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.PhotosDTO) != 0 {
+		const prefix string = ",\"photos\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.PhotosDTO {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				(v15).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.VoiceDTO != nil {
+		const prefix string = ",\"voice\":"
+		out.RawString(prefix)
+		(*in.VoiceDTO).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"send_at\":"
+		out.RawString(prefix)
+		out.Raw((in.SendAt).MarshalJSON())
+	}
+	if in.TTL != nil {
+		const prefix string = ",\"ttl\":"
+		out.RawString(prefix)
+		out.Int(int(*in.TTL))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ScheduledMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ScheduledMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ScheduledMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ScheduledMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(in *jlexer.Lexer, out *ReadState) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "chat_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ChatID).UnmarshalText(data))
+			}
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "last_read_message_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.LastReadMessageID).UnmarshalText(data))
+			}
+		case "read_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.ReadAt).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(out *jwriter.Writer, in ReadState) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"chat_id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ChatID).MarshalText())
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.RawText((in.UserID).MarshalText())
+	}
+	{
+		const prefix string = ",\"last_read_message_id\":"
+		out.RawString(prefix)
+		out.RawText((in.LastReadMessageID).MarshalText())
+	}
+	{
+		const prefix string = ",\"read_at\":"
+		out.RawString(prefix)
+		out.Raw((in.ReadAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReadState) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReadState) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReadState) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReadState) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(in *jlexer.Lexer, out *ReactionInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reaction":
+			out.Reaction = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(out *jwriter.Writer, in ReactionInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reaction\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reaction))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReactionInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReactionInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReactionInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReactionInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel5(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(in *jlexer.Lexer, out *ReactionCount) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reaction":
+			out.Reaction = string(in.String())
+		case "count":
+			out.Count = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(out *jwriter.Writer, in ReactionCount) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reaction\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reaction))
+	}
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix)
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReactionCount) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReactionCount) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReactionCount) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReactionCount) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel6(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(in *jlexer.Lexer, out *ParentPreview) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "user":
+			out.Username = string(in.String())
+		case "body":
+			out.Body = string(in.String())
+		case "message_type":
+			out.MessageType = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(out *jwriter.Writer, in ParentPreview) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	{
+		const prefix string = ",\"user_id\":"
+		out.RawString(prefix)
+		out.RawText((in.UserID).MarshalText())
+	}
+	if in.Username != "" {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.Username))
+	}
+	if in.Body != "" {
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	if in.MessageType != "" {
+		const prefix string = ",\"message_type\":"
+		out.RawString(prefix)
+		out.String(string(in.MessageType))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ParentPreview) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ParentPreview) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ParentPreview) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ParentPreview) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel7(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(in *jlexer.Lexer, out *MessageRevisionList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(MessageRevisionList, 0, 1)
+			} else {
+				*out = MessageRevisionList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v16 MessageRevision
+			(v16).UnmarshalEasyJSON(in)
+			*out = append(*out, v16)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(out *jwriter.Writer, in MessageRevisionList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v17, v18 := range in {
+			if v17 > 0 {
+				out.RawByte(',')
+			}
+			(v18).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageRevisionList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageRevisionList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageRevisionList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageRevisionList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel8(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(in *jlexer.Lexer, out *MessageRevision) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = int(in.Int())
+		case "body":
+			out.Body = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "replaced_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ReplacedAt = nil
+			} else {
+				if out.ReplacedAt == nil {
+					out.ReplacedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ReplacedAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(out *jwriter.Writer, in MessageRevision) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Version))
+	}
+	{
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.String(string(in.Body))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	if in.ReplacedAt != nil {
+		const prefix string = ",\"replaced_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ReplacedAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageRevision) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageRevision) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageRevision) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageRevision) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel9(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(in *jlexer.Lexer, out *MessageList) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(MessageList, 0, 0)
+			} else {
+				*out = MessageList{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v19 Message
+			(v19).UnmarshalEasyJSON(in)
+			*out = append(*out, v19)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(out *jwriter.Writer, in MessageList) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v20, v21 := range in {
+			if v20 > 0 {
+				out.RawByte(',')
+			}
+			(v21).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageList) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageList) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageList) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageList) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel10(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(in *jlexer.Lexer, out *MessageInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			out.Message = string(in.String())
+		case "entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 MessageEntity
+					(v22).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(out *jwriter.Writer, in MessageInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Message))
+	}
+	if len(in.Entities) != 0 {
+		const prefix string = ",\"entities\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v23, v24 := range in.Entities {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				(v24).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MessageInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MessageInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MessageInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MessageInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel11(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(in *jlexer.Lexer, out *Message) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "parent_message_id":
+			if in.IsNull() {
+				in.Skip()
+				out.ParentMessageID = nil
+			} else {
+				if out.ParentMessageID == nil {
+					out.ParentMessageID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.ParentMessageID).UnmarshalText(data))
+				}
+			}
+		case "chat_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ChatID).UnmarshalText(data))
+			}
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "body":
+			out.Body = string(in.String())
+		case "entities":
+			if in.IsNull() {
+				in.Skip()
+				out.Entities = nil
+			} else {
+				in.Delim('[')
+				if out.Entities == nil {
+					if !in.IsDelim(']') {
+						out.Entities = make([]MessageEntity, 0, 1)
+					} else {
+						out.Entities = []MessageEntity{}
+					}
+				} else {
+					out.Entities = (out.Entities)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 MessageEntity
+					(v25).UnmarshalEasyJSON(in)
+					out.Entities = append(out.Entities, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sent_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.SentAt).UnmarshalJSON(data))
+			}
+		case "is_redacted":
+			out.IsRedacted = bool(in.Bool())
+		case "edit_count":
+			out.EditCount = int(in.Int())
+		case "edited_at":
+			if in.IsNull() {
+				in.Skip()
+				out.EditedAt = nil
+			} else {
+				if out.EditedAt == nil {
+					out.EditedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.EditedAt).UnmarshalJSON(data))
+				}
+			}
+		case "avatar_path":
+			if in.IsNull() {
+				in.Skip()
+				out.AvatarPath = nil
+			} else {
+				if out.AvatarPath == nil {
+					out.AvatarPath = new(string)
+				}
+				*out.AvatarPath = string(in.String())
+			}
+		case "user":
+			out.Username = string(in.String())
+		case "message_type":
+			out.MessageType = string(in.String())
+		case "files":
+			if in.IsNull() {
+				in.Skip()
+				out.FilesDTO = nil
+			} else {
+				in.Delim('[')
+				if out.FilesDTO == nil {
+					if !in.IsDelim(']') {
+						out.FilesDTO = make([]Payload, 0, 0)
+					} else {
+						out.FilesDTO = []Payload{}
+					}
+				} else {
+					out.FilesDTO = (out.FilesDTO)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v26 Payload
+					(v26).UnmarshalEasyJSON(in)
+					out.FilesDTO = append(out.FilesDTO, v26)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "photos":
+			if in.IsNull() {
+				in.Skip()
+				out.PhotosDTO = nil
+			} else {
+				in.Delim('[')
+				if out.PhotosDTO == nil {
+					if !in.IsDelim(']') {
+						out.PhotosDTO = make([]Payload, 0, 0)
+					} else {
+						out.PhotosDTO = []Payload{}
+					}
+				} else {
+					out.PhotosDTO = (out.PhotosDTO)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v27 Payload
+					(v27).UnmarshalEasyJSON(in)
+					out.PhotosDTO = append(out.PhotosDTO, v27)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "voice":
+			if in.IsNull() {
+				in.Skip()
+				out.VoiceDTO = nil
+			} else {
+				if out.VoiceDTO == nil {
+					out.VoiceDTO = new(Payload)
+				}
+				(*out.VoiceDTO).UnmarshalEasyJSON(in)
+			}
+		case "sticker":
+			out.Sticker = string(in.String())
+		case "reactions":
+			if in.IsNull() {
+				in.Skip()
+				out.Reactions = nil
+			} else {
+				in.Delim('[')
+				if out.Reactions == nil {
+					if !in.IsDelim(']') {
+						out.Reactions = make([]ReactionCount, 0, 2)
+					} else {
+						out.Reactions = []ReactionCount{}
+					}
+				} else {
+					out.Reactions = (out.Reactions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v28 ReactionCount
+					(v28).UnmarshalEasyJSON(in)
+					out.Reactions = append(out.Reactions, v28)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "parent":
+			if in.IsNull() {
+				in.Skip()
+				out.Parent = nil
+			} else {
+				if out.Parent == nil {
+					out.Parent = new(ParentPreview)
+				}
+				(*out.Parent).UnmarshalEasyJSON(in)
+			}
+		case "reply_count":
+			out.ReplyCount = int(in.Int())
+		case "forward":
+			if in.IsNull() {
+				in.Skip()
+				out.Forward = nil
+			} else {
+				if out.Forward == nil {
+					out.Forward = new(ForwardInfo)
+				}
+				(*out.Forward).UnmarshalEasyJSON(in)
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		case "deleted_at":
+			if in.IsNull() {
+				in.Skip()
+				out.DeletedAt = nil
+			} else {
+				if out.DeletedAt == nil {
+					out.DeletedAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.DeletedAt).UnmarshalJSON(data))
+				}
+			}
+		case "client_message_id":
+			out.ClientMessageID = string(in.String())
+		case "link_preview":
+			if in.IsNull() {
+				in.Skip()
+				out.LinkPreview = nil
+			} else {
+				if out.LinkPreview == nil {
+					out.LinkPreview = new(LinkPreview)
+				}
+				(*out.LinkPreview).UnmarshalEasyJSON(in)
+			}
+		case "poll":
+			if in.IsNull() {
+				in.Skip()
+				out.Poll = nil
+			} else {
+				if out.Poll == nil {
+					out.Poll = new(Poll)
+				}
+				(*out.Poll).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(out *jwriter.Writer, in Message) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if true {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	if in.ParentMessageID != nil {
+		const prefix string = ",\"parent_message_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((*in.ParentMessageID).MarshalText())
+	}
+	if true {
+		const prefix string = ",\"chat_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((in.ChatID).MarshalText())
+	}
+	if true {
+		const prefix string = ",\"user_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((in.UserID).MarshalText())
+	}
+	if in.Body != "" {
+		const prefix string = ",\"body\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Body))
+	}
+	if len(in.Entities) != 0 {
+		const prefix string = ",\"entities\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v29, v30 := range in.Entities {
+				if v29 > 0 {
+					out.RawByte(',')
+				}
+				(v30).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if true {
+		const prefix string = ",\"sent_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.SentAt).MarshalJSON())
 	}
 	if in.IsRedacted {
 		const prefix string = ",\"is_redacted\":"
@@ -426,98 +1596,497 @@ func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(o
 		} else {
 			out.RawString(prefix)
 		}
-		out.Bool(bool(in.IsRedacted))
+		out.Bool(bool(in.IsRedacted))
+	}
+	if in.EditCount != 0 {
+		const prefix string = ",\"edit_count\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int(int(in.EditCount))
+	}
+	if in.EditedAt != nil {
+		const prefix string = ",\"edited_at\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.EditedAt).MarshalJSON())
+	}
+	if in.AvatarPath != nil {
+		const prefix string = ",\"avatar_path\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.AvatarPath))
+	}
+	if in.Username != "" {
+		const prefix string = ",\"user\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Username))
+	}
+	{
+		const prefix string = ",\"message_type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MessageType))
+	}
+	if len(in.FilesDTO) != 0 {
+		const prefix string = ",\"files\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v31, v32 := range in.FilesDTO {
+				if v31 > 0 {
+					out.RawByte(',')
+				}
+				(v32).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.PhotosDTO) != 0 {
+		const prefix string = ",\"photos\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v33, v34 := range in.PhotosDTO {
+				if v33 > 0 {
+					out.RawByte(',')
+				}
+				(v34).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.VoiceDTO != nil {
+		const prefix string = ",\"voice\":"
+		out.RawString(prefix)
+		(*in.VoiceDTO).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"sticker\":"
+		out.RawString(prefix)
+		out.String(string(in.Sticker))
+	}
+	if len(in.Reactions) != 0 {
+		const prefix string = ",\"reactions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v35, v36 := range in.Reactions {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				(v36).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Parent != nil {
+		const prefix string = ",\"parent\":"
+		out.RawString(prefix)
+		(*in.Parent).MarshalEasyJSON(out)
+	}
+	if in.ReplyCount != 0 {
+		const prefix string = ",\"reply_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.ReplyCount))
+	}
+	if in.Forward != nil {
+		const prefix string = ",\"forward\":"
+		out.RawString(prefix)
+		(*in.Forward).MarshalEasyJSON(out)
+	}
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if in.DeletedAt != nil {
+		const prefix string = ",\"deleted_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.DeletedAt).MarshalJSON())
+	}
+	if in.ClientMessageID != "" {
+		const prefix string = ",\"client_message_id\":"
+		out.RawString(prefix)
+		out.String(string(in.ClientMessageID))
+	}
+	if in.LinkPreview != nil {
+		const prefix string = ",\"link_preview\":"
+		out.RawString(prefix)
+		(*in.LinkPreview).MarshalEasyJSON(out)
+	}
+	if in.Poll != nil {
+		const prefix string = ",\"poll\":"
+		out.RawString(prefix)
+		(*in.Poll).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Message) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Message) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Message) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel12(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel13(in *jlexer.Lexer, out *LinkPreview) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.URL = string(in.String())
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "image_url":
+			out.ImageURL = string(in.String())
+		case "site_name":
+			out.SiteName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel13(out *jwriter.Writer, in LinkPreview) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.URL))
+	}
+	if in.Title != "" {
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	if in.Description != "" {
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	if in.ImageURL != "" {
+		const prefix string = ",\"image_url\":"
+		out.RawString(prefix)
+		out.String(string(in.ImageURL))
+	}
+	if in.SiteName != "" {
+		const prefix string = ",\"site_name\":"
+		out.RawString(prefix)
+		out.String(string(in.SiteName))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LinkPreview) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LinkPreview) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LinkPreview) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LinkPreview) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel13(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel14(in *jlexer.Lexer, out *LastMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.ID).UnmarshalText(data))
+			}
+		case "user_id":
+			if data := in.UnsafeBytes(); in.Ok() {
+				in.AddError((out.UserID).UnmarshalText(data))
+			}
+		case "body":
+			out.Body = string(in.String())
+		case "sent_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.SentAt).UnmarshalJSON(data))
+			}
+		case "user":
+			out.Username = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel14(out *jwriter.Writer, in LastMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if true {
+		const prefix string = ",\"id\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.RawText((in.ID).MarshalText())
+	}
+	if true {
+		const prefix string = ",\"user_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((in.UserID).MarshalText())
 	}
-	if in.AvatarPath != nil {
-		const prefix string = ",\"avatar_path\":"
+	if in.Body != "" {
+		const prefix string = ",\"body\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(*in.AvatarPath))
+		out.String(string(in.Body))
 	}
-	if in.Username != "" {
-		const prefix string = ",\"user\":"
+	if true {
+		const prefix string = ",\"sent_at\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Username))
+		out.Raw((in.SentAt).MarshalJSON())
 	}
-	{
-		const prefix string = ",\"message_type\":"
+	if in.Username != "" {
+		const prefix string = ",\"user\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.MessageType))
+		out.String(string(in.Username))
 	}
-	if len(in.FilesDTO) != 0 {
-		const prefix string = ",\"files\":"
-		out.RawString(prefix)
-		{
-			out.RawByte('[')
-			for v6, v7 := range in.FilesDTO {
-				if v6 > 0 {
-					out.RawByte(',')
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LastMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LastMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LastMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LastMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel14(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel15(in *jlexer.Lexer, out *ForwardInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "from_chat_id":
+			out.FromChatID = string(in.String())
+		case "message_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.MessageIDs = nil
+			} else {
+				in.Delim('[')
+				if out.MessageIDs == nil {
+					if !in.IsDelim(']') {
+						out.MessageIDs = make([]string, 0, 4)
+					} else {
+						out.MessageIDs = []string{}
+					}
+				} else {
+					out.MessageIDs = (out.MessageIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v37 string
+					v37 = string(in.String())
+					out.MessageIDs = append(out.MessageIDs, v37)
+					in.WantComma()
 				}
-				(v7).MarshalEasyJSON(out)
+				in.Delim(']')
 			}
-			out.RawByte(']')
+		default:
+			in.SkipRecursive()
 		}
+		in.WantComma()
 	}
-	if len(in.PhotosDTO) != 0 {
-		const prefix string = ",\"photos\":"
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel15(out *jwriter.Writer, in ForwardInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"from_chat_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.FromChatID))
+	}
+	{
+		const prefix string = ",\"message_ids\":"
 		out.RawString(prefix)
-		{
+		if in.MessageIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
 			out.RawByte('[')
-			for v8, v9 := range in.PhotosDTO {
-				if v8 > 0 {
+			for v38, v39 := range in.MessageIDs {
+				if v38 > 0 {
 					out.RawByte(',')
 				}
-				(v9).MarshalEasyJSON(out)
+				out.String(string(v39))
 			}
 			out.RawByte(']')
 		}
 	}
-	{
-		const prefix string = ",\"sticker\":"
-		out.RawString(prefix)
-		out.String(string(in.Sticker))
-	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Message) MarshalJSON() ([]byte, error) {
+func (v ForwardInput) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&w, v)
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Message) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(w, v)
+func (v ForwardInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Message) UnmarshalJSON(data []byte) error {
+func (v *ForwardInput) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&r, v)
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Message) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
+func (v *ForwardInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel15(l, v)
 }
-func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(in *jlexer.Lexer, out *LastMessage) {
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel16(in *jlexer.Lexer, out *ForwardInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -536,22 +2105,36 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(i
 			continue
 		}
 		switch key {
-		case "id":
-			if data := in.UnsafeBytes(); in.Ok() {
-				in.AddError((out.ID).UnmarshalText(data))
-			}
 		case "user_id":
-			if data := in.UnsafeBytes(); in.Ok() {
-				in.AddError((out.UserID).UnmarshalText(data))
+			if in.IsNull() {
+				in.Skip()
+				out.UserID = nil
+			} else {
+				if out.UserID == nil {
+					out.UserID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.UserID).UnmarshalText(data))
+				}
+			}
+		case "user":
+			out.Username = string(in.String())
+		case "chat_id":
+			if in.IsNull() {
+				in.Skip()
+				out.ChatID = nil
+			} else {
+				if out.ChatID == nil {
+					out.ChatID = new(uuid.UUID)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.ChatID).UnmarshalText(data))
+				}
 			}
-		case "body":
-			out.Body = string(in.String())
 		case "sent_at":
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.SentAt).UnmarshalJSON(data))
 			}
-		case "user":
-			out.Username = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -562,37 +2145,37 @@ func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(i
 		in.Consumed()
 	}
 }
-func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(out *jwriter.Writer, in LastMessage) {
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel16(out *jwriter.Writer, in ForwardInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if true {
-		const prefix string = ",\"id\":"
+	if in.UserID != nil {
+		const prefix string = ",\"user_id\":"
 		first = false
 		out.RawString(prefix[1:])
-		out.RawText((in.ID).MarshalText())
+		out.RawText((*in.UserID).MarshalText())
 	}
-	if true {
-		const prefix string = ",\"user_id\":"
+	if in.Username != "" {
+		const prefix string = ",\"user\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.RawText((in.UserID).MarshalText())
+		out.String(string(in.Username))
 	}
-	if in.Body != "" {
-		const prefix string = ",\"body\":"
+	if in.ChatID != nil {
+		const prefix string = ",\"chat_id\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.String(string(in.Body))
+		out.RawText((*in.ChatID).MarshalText())
 	}
-	if true {
+	{
 		const prefix string = ",\"sent_at\":"
 		if first {
 			first = false
@@ -602,39 +2185,136 @@ func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(o
 		}
 		out.Raw((in.SentAt).MarshalJSON())
 	}
-	if in.Username != "" {
-		const prefix string = ",\"user\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForwardInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForwardInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForwardInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForwardInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel16(l, v)
+}
+func easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel17(in *jlexer.Lexer, out *BulkDeleteInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.MessageIDs = nil
+			} else {
+				in.Delim('[')
+				if out.MessageIDs == nil {
+					if !in.IsDelim(']') {
+						out.MessageIDs = make([]uuid.UUID, 0, 4)
+					} else {
+						out.MessageIDs = []uuid.UUID{}
+					}
+				} else {
+					out.MessageIDs = (out.MessageIDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v40 uuid.UUID
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((v40).UnmarshalText(data))
+					}
+					out.MessageIDs = append(out.MessageIDs, v40)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "mode":
+			out.Mode = DeleteMode(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel17(out *jwriter.Writer, in BulkDeleteInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message_ids\":"
+		out.RawString(prefix[1:])
+		if in.MessageIDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
 		} else {
-			out.RawString(prefix)
+			out.RawByte('[')
+			for v41, v42 := range in.MessageIDs {
+				if v41 > 0 {
+					out.RawByte(',')
+				}
+				out.RawText((v42).MarshalText())
+			}
+			out.RawByte(']')
 		}
-		out.String(string(in.Username))
+	}
+	if in.Mode != "" {
+		const prefix string = ",\"mode\":"
+		out.RawString(prefix)
+		out.String(string(in.Mode))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LastMessage) MarshalJSON() ([]byte, error) {
+func (v BulkDeleteInput) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&w, v)
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LastMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(w, v)
+func (v BulkDeleteInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4086215fEncodeGithubComGoParkMailRu20251VelvetPullsInternalModel17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LastMessage) UnmarshalJSON(data []byte) error {
+func (v *BulkDeleteInput) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(&r, v)
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LastMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel4(l, v)
+func (v *BulkDeleteInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4086215fDecodeGithubComGoParkMailRu20251VelvetPullsInternalModel17(l, v)
 }
@@ -0,0 +1,113 @@
+//go:generate easyjson -all poll.go
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxPollQuestionLength = 300
+	MaxPollOptionLength   = 100
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+	// MaxPollDuration — насколько далеко вперёд можно назначить закрытие опроса
+	MaxPollDuration = 365 * 24 * time.Hour
+)
+
+// Poll — опрос в сообщении с итогами голосования. В анонимных опросах
+// список проголосовавших не раскрывается; свои голоса пользователь видит в MyVotes.
+//
+//easyjson:json
+type Poll struct {
+	Question       string       `json:"question"`
+	Options        []PollOption `json:"options"`
+	MultipleChoice bool         `json:"multiple_choice"`
+	Anonymous      bool         `json:"anonymous"`
+	ClosesAt       *time.Time   `json:"closes_at,omitempty"`
+	Closed         bool         `json:"closed"`
+	TotalVoters    int          `json:"total_voters"`
+	MyVotes        []int        `json:"my_votes,omitempty"`
+}
+
+//easyjson:json
+type PollOption struct {
+	Position int         `json:"position"`
+	Text     string      `json:"text"`
+	Votes    int         `json:"votes"`
+	Voters   []uuid.UUID `json:"voters,omitempty"`
+}
+
+// PollInput — опрос, который создаётся вместе с сообщением
+//
+//easyjson:json
+type PollInput struct {
+	Question       string     `json:"question"`
+	Options        []string   `json:"options"`
+	MultipleChoice bool       `json:"multiple_choice"`
+	Anonymous      bool       `json:"anonymous"`
+	ClosesAt       *time.Time `json:"closes_at,omitempty"`
+}
+
+func (p *PollInput) Validate() error {
+	p.Question = strings.TrimSpace(p.Question)
+	if p.Question == "" || len([]rune(p.Question)) > MaxPollQuestionLength {
+		return errors.Join(ErrValidation, fmt.Errorf("poll question must be 1-%d characters", MaxPollQuestionLength))
+	}
+
+	if len(p.Options) < MinPollOptions || len(p.Options) > MaxPollOptions {
+		return errors.Join(ErrValidation, fmt.Errorf("poll must have %d-%d options", MinPollOptions, MaxPollOptions))
+	}
+	seen := make(map[string]struct{}, len(p.Options))
+	for i := range p.Options {
+		p.Options[i] = strings.TrimSpace(p.Options[i])
+		option := p.Options[i]
+		if option == "" || len([]rune(option)) > MaxPollOptionLength {
+			return errors.Join(ErrValidation, fmt.Errorf("poll option must be 1-%d characters", MaxPollOptionLength))
+		}
+		key := strings.ToLower(option)
+		if _, ok := seen[key]; ok {
+			return errors.Join(ErrValidation, fmt.Errorf("duplicate poll option %q", option))
+		}
+		seen[key] = struct{}{}
+	}
+
+	if p.ClosesAt != nil {
+		now := time.Now()
+		if !p.ClosesAt.After(now) {
+			return errors.Join(ErrValidation, errors.New("closes_at must be in the future"))
+		}
+		if p.ClosesAt.After(now.Add(MaxPollDuration)) {
+			return errors.Join(ErrValidation, errors.New("closes_at is too far in the future"))
+		}
+	}
+	return nil
+}
+
+// PollVoteInput — выбранные варианты по их позициям; новый голос заменяет прежний
+//
+//easyjson:json
+type PollVoteInput struct {
+	Options []int `json:"options"`
+}
+
+func (v *PollVoteInput) Validate() error {
+	if len(v.Options) == 0 {
+		return errors.Join(ErrValidation, errors.New("at least one option must be chosen"))
+	}
+	seen := make(map[int]struct{}, len(v.Options))
+	for _, pos := range v.Options {
+		if pos < 0 || pos >= MaxPollOptions {
+			return errors.Join(ErrValidation, fmt.Errorf("invalid option %d", pos))
+		}
+		if _, ok := seen[pos]; ok {
+			return errors.Join(ErrValidation, fmt.Errorf("option %d chosen twice", pos))
+		}
+		seen[pos] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,561 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package model
+
+import (
+	json "encoding/json"
+	uuid "github.com/google/uuid"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(in *jlexer.Lexer, out *PollVoteInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "options":
+			if in.IsNull() {
+				in.Skip()
+				out.Options = nil
+			} else {
+				in.Delim('[')
+				if out.Options == nil {
+					if !in.IsDelim(']') {
+						out.Options = make([]int, 0, 8)
+					} else {
+						out.Options = []int{}
+					}
+				} else {
+					out.Options = (out.Options)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int
+					v1 = int(in.Int())
+					out.Options = append(out.Options, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(out *jwriter.Writer, in PollVoteInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"options\":"
+		out.RawString(prefix[1:])
+		if in.Options == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Options {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PollVoteInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PollVoteInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PollVoteInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PollVoteInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel(l, v)
+}
+func easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(in *jlexer.Lexer, out *PollOption) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "position":
+			out.Position = int(in.Int())
+		case "text":
+			out.Text = string(in.String())
+		case "votes":
+			out.Votes = int(in.Int())
+		case "voters":
+			if in.IsNull() {
+				in.Skip()
+				out.Voters = nil
+			} else {
+				in.Delim('[')
+				if out.Voters == nil {
+					if !in.IsDelim(']') {
+						out.Voters = make([]uuid.UUID, 0, 4)
+					} else {
+						out.Voters = []uuid.UUID{}
+					}
+				} else {
+					out.Voters = (out.Voters)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 uuid.UUID
+					if data := in.UnsafeBytes(); in.Ok() {
+						in.AddError((v4).UnmarshalText(data))
+					}
+					out.Voters = append(out.Voters, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(out *jwriter.Writer, in PollOption) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"position\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Position))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		out.String(string(in.Text))
+	}
+	{
+		const prefix string = ",\"votes\":"
+		out.RawString(prefix)
+		out.Int(int(in.Votes))
+	}
+	if len(in.Voters) != 0 {
+		const prefix string = ",\"voters\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Voters {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.RawText((v6).MarshalText())
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PollOption) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PollOption) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PollOption) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PollOption) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel1(l, v)
+}
+func easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(in *jlexer.Lexer, out *PollInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "question":
+			out.Question = string(in.String())
+		case "options":
+			if in.IsNull() {
+				in.Skip()
+				out.Options = nil
+			} else {
+				in.Delim('[')
+				if out.Options == nil {
+					if !in.IsDelim(']') {
+						out.Options = make([]string, 0, 4)
+					} else {
+						out.Options = []string{}
+					}
+				} else {
+					out.Options = (out.Options)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Options = append(out.Options, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "multiple_choice":
+			out.MultipleChoice = bool(in.Bool())
+		case "anonymous":
+			out.Anonymous = bool(in.Bool())
+		case "closes_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ClosesAt = nil
+			} else {
+				if out.ClosesAt == nil {
+					out.ClosesAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ClosesAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(out *jwriter.Writer, in PollInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Question))
+	}
+	{
+		const prefix string = ",\"options\":"
+		out.RawString(prefix)
+		if in.Options == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.Options {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"multiple_choice\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.MultipleChoice))
+	}
+	{
+		const prefix string = ",\"anonymous\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Anonymous))
+	}
+	if in.ClosesAt != nil {
+		const prefix string = ",\"closes_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ClosesAt).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PollInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PollInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PollInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PollInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel2(l, v)
+}
+func easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(in *jlexer.Lexer, out *Poll) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "question":
+			out.Question = string(in.String())
+		case "options":
+			if in.IsNull() {
+				in.Skip()
+				out.Options = nil
+			} else {
+				in.Delim('[')
+				if out.Options == nil {
+					if !in.IsDelim(']') {
+						out.Options = make([]PollOption, 0, 1)
+					} else {
+						out.Options = []PollOption{}
+					}
+				} else {
+					out.Options = (out.Options)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 PollOption
+					(v10).UnmarshalEasyJSON(in)
+					out.Options = append(out.Options, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "multiple_choice":
+			out.MultipleChoice = bool(in.Bool())
+		case "anonymous":
+			out.Anonymous = bool(in.Bool())
+		case "closes_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ClosesAt = nil
+			} else {
+				if out.ClosesAt == nil {
+					out.ClosesAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ClosesAt).UnmarshalJSON(data))
+				}
+			}
+		case "closed":
+			out.Closed = bool(in.Bool())
+		case "total_voters":
+			out.TotalVoters = int(in.Int())
+		case "my_votes":
+			if in.IsNull() {
+				in.Skip()
+				out.MyVotes = nil
+			} else {
+				in.Delim('[')
+				if out.MyVotes == nil {
+					if !in.IsDelim(']') {
+						out.MyVotes = make([]int, 0, 8)
+					} else {
+						out.MyVotes = []int{}
+					}
+				} else {
+					out.MyVotes = (out.MyVotes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v11 int
+					v11 = int(in.Int())
+					out.MyVotes = append(out.MyVotes, v11)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(out *jwriter.Writer, in Poll) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Question))
+	}
+	{
+		const prefix string = ",\"options\":"
+		out.RawString(prefix)
+		if in.Options == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v12, v13 := range in.Options {
+				if v12 > 0 {
+					out.RawByte(',')
+				}
+				(v13).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"multiple_choice\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.MultipleChoice))
+	}
+	{
+		const prefix string = ",\"anonymous\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Anonymous))
+	}
+	if in.ClosesAt != nil {
+		const prefix string = ",\"closes_at\":"
+		out.RawString(prefix)
+		out.Raw((*in.ClosesAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"closed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Closed))
+	}
+	{
+		const prefix string = ",\"total_voters\":"
+		out.RawString(prefix)
+		out.Int(int(in.TotalVoters))
+	}
+	if len(in.MyVotes) != 0 {
+		const prefix string = ",\"my_votes\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.MyVotes {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Poll) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Poll) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonB24b5487EncodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Poll) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Poll) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonB24b5487DecodeGithubComGoParkMailRu20251VelvetPullsInternalModel3(l, v)
+}
@@ -1,11 +1,57 @@
 package model
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type MessageEvent struct {
 	Action  string  `json:"action"`
 	Message Message `json:"payload"`
+	// Mode уточняет режим удаления для deleteMessage
+	Mode DeleteMode `json:"mode,omitempty"`
+}
+
+// MessagesDeletedEvent заменяет пачку deleteMessage: либо перечисляет
+// удалённые сообщения, либо (при очистке истории) сообщает, что удалено
+// всё отправленное не позже Before
+type MessagesDeletedEvent struct {
+	Action  string          `json:"action"`
+	Deleted MessagesDeleted `json:"payload"`
+	Mode    DeleteMode      `json:"mode,omitempty"`
+}
+
+type MessagesDeleted struct {
+	ChatID     uuid.UUID   `json:"chat_id"`
+	MessageIDs []uuid.UUID `json:"message_ids,omitempty"`
+	Before     *time.Time  `json:"before,omitempty"`
 }
 
 type ChatEvent struct {
 	Action string `json:"action"`
 	Chat   Chat   `json:"payload"`
 }
+
+type ReadEvent struct {
+	Action string    `json:"action"`
+	Read   ReadState `json:"payload"`
+}
+
+// DraftEvent синхронизирует черновик между устройствами пользователя
+type DraftEvent struct {
+	Action string `json:"action"`
+	Draft  Draft  `json:"payload"`
+}
+
+// ExportEvent сообщает запросившему о ходе выгрузки чата
+type ExportEvent struct {
+	Action string     `json:"action"`
+	Export ChatExport `json:"payload"`
+}
+
+// UserEvent — персональное событие, публикуется в user.<id>.events
+type UserEvent struct {
+	TypeOfEvent string
+	Event       interface{}
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type IBookmarkRepo interface {
+	CreateBookmark(ctx context.Context, userID, savedChatID, sourceChatID, sourceMessageID uuid.UUID, tags []string) (*model.Bookmark, error)
+	UpdateBookmarkTags(ctx context.Context, userID, messageID uuid.UUID, tags []string) error
+	SearchBookmarks(ctx context.Context, userID uuid.UUID, search model.BookmarkSearch) ([]model.Bookmark, error)
+}
+
+type bookmarkRepo struct {
+	db       *sql.DB
+	messages *messageRepo
+}
+
+func NewBookmarkRepo(db *sql.DB) IBookmarkRepo {
+	return &bookmarkRepo{db: db, messages: &messageRepo{db: db}}
+}
+
+// CreateBookmark сохраняет сообщение в «Избранное». Сообщение из другого чата
+// копируется как пересланное; сообщение самого «Избранного» помечается на месте.
+// Повторное сохранение того же сообщения возвращает ErrBookmarkExists.
+func (r *bookmarkRepo) CreateBookmark(ctx context.Context, userID, savedChatID, sourceChatID, sourceMessageID uuid.UUID, tags []string) (*model.Bookmark, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	messageID := sourceMessageID
+	if sourceChatID != savedChatID {
+		copies, err := copyMessages(ctx, tx, userID, sourceChatID, savedChatID, []uuid.UUID{sourceMessageID})
+		if err != nil {
+			rollbackTx(logger, tx)
+			return nil, err
+		}
+		messageID = copies[0]
+	}
+
+	bookmark := model.Bookmark{
+		SourceChatID:    &sourceChatID,
+		SourceMessageID: &sourceMessageID,
+		Tags:            tags,
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO bookmark (message_id, user_id, source_chat_id, source_message_id, tags)
+		SELECT m.id, $2, $3, $4, $5
+		FROM message m
+		WHERE m.id = $1 AND m.chat_id = $6 AND m.deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+		RETURNING created_at
+	`, messageID, userID, sourceChatID, sourceMessageID, pq.Array(tags), savedChatID).Scan(&bookmark.CreatedAt)
+	if err != nil {
+		rollbackTx(logger, tx)
+		if errors.Is(err, sql.ErrNoRows) {
+			if sourceChatID == savedChatID {
+				return nil, r.inPlaceConflict(ctx, sourceMessageID, savedChatID)
+			}
+			return nil, ErrBookmarkExists
+		}
+		logger.Error("insert bookmark failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	msg, err := r.messages.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	bookmark.Message = *msg
+	return &bookmark, nil
+}
+
+// inPlaceConflict различает, почему не удалось пометить сообщение «Избранного»:
+// его нет в чате или закладка на него уже есть
+func (r *bookmarkRepo) inPlaceConflict(ctx context.Context, messageID, savedChatID uuid.UUID) error {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM message WHERE id = $1 AND chat_id = $2 AND deleted_at IS NULL)
+	`, messageID, savedChatID).Scan(&exists)
+	if err != nil {
+		return ErrDatabaseOperation
+	}
+	if !exists {
+		return ErrMessagesNotFound
+	}
+	return ErrBookmarkExists
+}
+
+func (r *bookmarkRepo) UpdateBookmarkTags(ctx context.Context, userID, messageID uuid.UUID, tags []string) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE bookmark SET tags = $3 WHERE user_id = $1 AND message_id = $2
+	`, userID, messageID, pq.Array(tags))
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("update bookmark tags failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return ErrDatabaseOperation
+	}
+	if affected == 0 {
+		return ErrBookmarkNotFound
+	}
+	return nil
+}
+
+// likeEscaper экранирует спецсимволы LIKE, чтобы запрос искался как подстрока
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchBookmarks ищет закладки по подстроке текста и набору тегов, новые первыми
+func (r *bookmarkRepo) SearchBookmarks(ctx context.Context, userID uuid.UUID, search model.BookmarkSearch) ([]model.Bookmark, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	tags := search.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT b.message_id, b.source_chat_id, b.source_message_id, b.tags, b.created_at
+		FROM bookmark b
+		JOIN message m ON m.id = b.message_id
+		WHERE b.user_id = $1
+		  AND m.deleted_at IS NULL
+		  AND ($2 = '' OR m.body ILIKE '%' || $2 || '%')
+		  AND b.tags @> $3::text[]
+		  `+notHiddenFor("$1")+`
+		ORDER BY b.created_at DESC
+		LIMIT $4
+	`, userID, likeEscaper.Replace(search.Query), pq.Array(tags), search.Limit)
+	if err != nil {
+		logger.Error("search bookmarks failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+	defer rows.Close()
+
+	var bookmarks []model.Bookmark
+	var ids []string
+	for rows.Next() {
+		var b model.Bookmark
+		var sourceChatID, sourceMessageID uuid.NullUUID
+		if err := rows.Scan(&b.Message.ID, &sourceChatID, &sourceMessageID, pq.Array(&b.Tags), &b.CreatedAt); err != nil {
+			return nil, ErrDatabaseScan
+		}
+		if sourceChatID.Valid {
+			b.SourceChatID = &sourceChatID.UUID
+		}
+		if sourceMessageID.Valid {
+			b.SourceMessageID = &sourceMessageID.UUID
+		}
+		bookmarks = append(bookmarks, b)
+		ids = append(ids, b.Message.ID.String())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrDatabaseOperation
+	}
+	if len(bookmarks) == 0 {
+		return bookmarks, nil
+	}
+
+	messages, err := r.messages.queryMessages(ctx, messageSelect+`
+		WHERE m.id = ANY($1::uuid[])`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]model.Message, len(messages))
+	for _, msg := range messages {
+		byID[msg.ID] = msg
+	}
+	for i := range bookmarks {
+		bookmarks[i].Message = byID[bookmarks[i].Message.ID]
+	}
+	return bookmarks, nil
+}
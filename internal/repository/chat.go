@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -28,6 +29,11 @@ type IChatRepo interface {
 	GetUsersFromChat(ctx context.Context, chatId uuid.UUID) ([]model.UserInChat, error)
 	RemoveUserFromChatByUsername(ctx context.Context, username string, chatID uuid.UUID) error
 	RemoveUserFromChatByID(ctx context.Context, userID, chatID uuid.UUID) error
+	PinMessage(ctx context.Context, chatID, messageID, userID uuid.UUID) error
+	UnpinMessage(ctx context.Context, chatID, messageID uuid.UUID) error
+	GetPinnedMessages(ctx context.Context, chatID uuid.UUID) ([]model.PinnedMessage, error)
+	SetMessageTTL(ctx context.Context, chatID uuid.UUID, ttl *int) error
+	GetOrCreateSavedChat(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
 }
 
 type chatRepository struct {
@@ -42,19 +48,66 @@ func NewChatRepo(db *sql.DB) IChatRepo {
 func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) ([]model.Chat, uuid.UUID, error) {
 	query := `
 		SELECT 
-			c.id, c.avatar_path, c.type, c.title, uc.send_notifications,
+			c.id, c.avatar_path, c.type, c.title, uc.send_notifications, c.saved_by IS NOT NULL,
 			m.id, m.user_id, m.body, m.sent_at,
 			(
 				SELECT COUNT(*) 
 				FROM user_chat uc2 
 				WHERE uc2.chat_id = c.id
-			) AS count_users
+			) AS count_users,
+			(
+				SELECT COUNT(*)
+				FROM message um
+				WHERE um.chat_id = c.id
+				  AND um.user_id <> $1
+				  AND um.deleted_at IS NULL
+				  AND NOT EXISTS (
+					SELECT 1 FROM message_view mv
+					WHERE mv.message_id = um.id AND mv.user_id = $1
+				  )
+				  AND NOT EXISTS (
+					SELECT 1 FROM message_hidden h
+					WHERE h.user_id = $1 AND h.message_id = um.id
+				  )
+			) AS unread_count,
+			(
+				SELECT COUNT(DISTINCT me.message_id)
+				FROM message_entity me
+				JOIN message um ON um.id = me.message_id
+				WHERE me.type = 'mention'
+				  AND me.user_id = $1
+				  AND um.chat_id = c.id
+				  AND um.user_id <> $1
+				  AND um.deleted_at IS NULL
+				  AND NOT EXISTS (
+					SELECT 1 FROM message_view mv
+					WHERE mv.message_id = um.id AND mv.user_id = $1
+				  )
+				  AND NOT EXISTS (
+					SELECT 1 FROM message_hidden h
+					WHERE h.user_id = $1 AND h.message_id = um.id
+				  )
+			) AS unread_mentions,
+			(
+				SELECT mv.message_id
+				FROM message_view mv
+				JOIN message rm ON rm.id = mv.message_id
+				WHERE rm.chat_id = c.id AND mv.user_id = $1
+				ORDER BY rm.sent_at DESC
+				LIMIT 1
+			) AS last_read_message_id,
+			d.body, d.entities, d.parent_message_id, d.updated_at
 		FROM chat c
 		JOIN user_chat uc ON c.id = uc.chat_id
+		LEFT JOIN message_draft d ON d.user_id = uc.user_id AND d.chat_id = uc.chat_id
 		LEFT JOIN LATERAL (
 			SELECT m.id, m.user_id, m.body, m.sent_at
 			FROM message m
 			WHERE m.chat_id = c.id
+			  AND NOT EXISTS (
+				SELECT 1 FROM message_hidden h
+				WHERE h.user_id = $1 AND h.message_id = m.id
+			  )
 			ORDER BY m.sent_at DESC
 			LIMIT 1
 		) m ON true
@@ -77,15 +130,38 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) ([]mode
 		var msgUserID sql.NullString
 		var msgBody sql.NullString
 		var msgSentAt sql.NullTime
+		var lastReadID uuid.NullUUID
+		var draftBody sql.NullString
+		var draftEntities []byte
+		var draftParentID uuid.NullUUID
+		var draftUpdatedAt sql.NullTime
 
 		err := rows.Scan(
-			&chat.ID, &chat.AvatarPath, &chat.Type, &chat.Title, &chat.SendNotifications,
+			&chat.ID, &chat.AvatarPath, &chat.Type, &chat.Title, &chat.SendNotifications, &chat.IsSaved,
 			&msgID, &msgUserID, &msgBody, &msgSentAt, &chat.CountUsers,
+			&chat.UnreadCount, &chat.UnreadMentions, &lastReadID,
+			&draftBody, &draftEntities, &draftParentID, &draftUpdatedAt,
 		)
 		if err != nil {
 			return nil, uuid.Nil, err
 		}
 
+		if draftUpdatedAt.Valid {
+			chat.Draft = &model.Draft{ChatID: chat.ID, Body: draftBody.String, UpdatedAt: draftUpdatedAt.Time}
+			if draftParentID.Valid {
+				chat.Draft.ParentMessageID = &draftParentID.UUID
+			}
+			if len(draftEntities) > 0 {
+				if err := json.Unmarshal(draftEntities, &chat.Draft.Entities); err != nil {
+					return nil, uuid.Nil, err
+				}
+			}
+		}
+
+		if lastReadID.Valid {
+			chat.LastReadMessageID = &lastReadID.UUID
+		}
+
 		if msgID.Valid && msgUserID.Valid && msgBody.Valid && msgSentAt.Valid {
 			msgUUID, err1 := uuid.Parse(msgID.String)
 			userUUID, err2 := uuid.Parse(msgUserID.String)
@@ -111,9 +187,9 @@ func (r *chatRepository) GetChats(ctx context.Context, userID uuid.UUID) ([]mode
 }
 
 func (r *chatRepository) GetChatByID(ctx context.Context, chatID uuid.UUID) (*model.Chat, error) {
-	query := `SELECT id, avatar_path, type, title FROM chat WHERE id = $1`
+	query := `SELECT id, avatar_path, type, title, message_ttl, saved_by IS NOT NULL FROM chat WHERE id = $1`
 	var chat model.Chat
-	err := r.db.QueryRowContext(ctx, query, chatID).Scan(&chat.ID, &chat.AvatarPath, &chat.Type, &chat.Title)
+	err := r.db.QueryRowContext(ctx, query, chatID).Scan(&chat.ID, &chat.AvatarPath, &chat.Type, &chat.Title, &chat.MessageTTL, &chat.IsSaved)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrChatNotFound
@@ -337,3 +413,111 @@ func (r *chatRepository) RemoveUserFromChatByID(ctx context.Context, userID, cha
 	_, err := r.db.ExecContext(ctx, `DELETE FROM user_chat WHERE user_id = $1 AND chat_id = $2`, userID, chatID)
 	return err
 }
+
+func (r *chatRepository) PinMessage(ctx context.Context, chatID, messageID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO pinned_message (chat_id, message_id, pinned_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id, message_id) DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, chatID, messageID, userID); err != nil {
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+func (r *chatRepository) UnpinMessage(ctx context.Context, chatID, messageID uuid.UUID) error {
+	query := `DELETE FROM pinned_message WHERE chat_id = $1 AND message_id = $2`
+	if _, err := r.db.ExecContext(ctx, query, chatID, messageID); err != nil {
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+// SetMessageTTL задаёт время жизни новых сообщений чата; nil отключает удаление.
+// Уже отправленные сообщения сохраняют свой срок.
+func (r *chatRepository) SetMessageTTL(ctx context.Context, chatID uuid.UUID, ttl *int) error {
+	res, err := r.db.ExecContext(ctx, `UPDATE chat SET message_ttl = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`, ttl, chatID)
+	if err != nil {
+		return ErrDatabaseOperation
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrChatNotFound
+	}
+	return nil
+}
+
+// GetPinnedMessages возвращает закреплённые сообщения чата, последние закреплённые — первыми
+func (r *chatRepository) GetPinnedMessages(ctx context.Context, chatID uuid.UUID) ([]model.PinnedMessage, error) {
+	query := `
+		SELECT m.id, m.user_id, COALESCE(m.imported_author, u.username), m.body, m.message_type, p.pinned_by, p.pinned_at
+		FROM pinned_message p
+		JOIN message m ON m.id = p.message_id
+		JOIN public.user u ON u.id = m.user_id
+		WHERE p.chat_id = $1
+		ORDER BY p.pinned_at DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, chatID)
+	if err != nil {
+		return nil, ErrDatabaseOperation
+	}
+	defer rows.Close()
+
+	pins := []model.PinnedMessage{}
+	for rows.Next() {
+		var pin model.PinnedMessage
+		var pinnedBy uuid.NullUUID
+		if err := rows.Scan(&pin.MessageID, &pin.UserID, &pin.Username, &pin.Body, &pin.MessageType, &pinnedBy, &pin.PinnedAt); err != nil {
+			return nil, ErrDatabaseScan
+		}
+		if pinnedBy.Valid {
+			pin.PinnedBy = &pinnedBy.UUID
+		}
+		pins = append(pins, pin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrDatabaseOperation
+	}
+	return pins, nil
+}
+
+// GetOrCreateSavedChat возвращает «Избранное» пользователя. Обычно оно
+// создаётся при регистрации; для старых аккаунтов создаётся при первом обращении.
+func (r *chatRepository) GetOrCreateSavedChat(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	var chatID uuid.UUID
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM chat WHERE saved_by = $1`, userID).Scan(&chatID)
+	if err == nil {
+		return chatID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		logger.Error("get saved chat failed", zap.Error(err))
+		return uuid.Nil, ErrDatabaseOperation
+	}
+
+	err = r.db.QueryRowContext(ctx, `
+		WITH saved AS (
+			INSERT INTO chat (type, title, saved_by)
+			SELECT 'dialog', username, id FROM public.user WHERE id = $1
+			ON CONFLICT (saved_by) DO NOTHING
+			RETURNING id
+		), member AS (
+			INSERT INTO user_chat (user_id, chat_id, user_role)
+			SELECT $1, id, 'owner' FROM saved
+		)
+		SELECT id FROM saved
+	`, userID).Scan(&chatID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Параллельный запрос успел создать чат раньше, либо пользователя нет
+		err = r.db.QueryRowContext(ctx, `SELECT id FROM chat WHERE saved_by = $1`, userID).Scan(&chatID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrUserNotFound
+		}
+	}
+	if err != nil {
+		logger.Error("create saved chat failed", zap.Error(err))
+		return uuid.Nil, ErrDatabaseOperation
+	}
+	return chatID, nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type IDraftRepo interface {
+	GetDraft(ctx context.Context, userID, chatID uuid.UUID) (*model.Draft, error)
+	SaveDraft(ctx context.Context, userID uuid.UUID, draft *model.Draft) (*model.Draft, error)
+	DeleteDraft(ctx context.Context, userID, chatID uuid.UUID) (bool, error)
+}
+
+type draftRepo struct {
+	db *sql.DB
+}
+
+func NewDraftRepo(db *sql.DB) IDraftRepo {
+	return &draftRepo{db: db}
+}
+
+// GetDraft возвращает черновик пользователя в чате или ErrDraftNotFound
+func (r *draftRepo) GetDraft(ctx context.Context, userID, chatID uuid.UUID) (*model.Draft, error) {
+	draft := model.Draft{ChatID: chatID}
+	var entities []byte
+	var parentID uuid.NullUUID
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT body, entities, parent_message_id, updated_at
+		FROM message_draft
+		WHERE user_id = $1 AND chat_id = $2
+	`, userID, chatID).Scan(&draft.Body, &entities, &parentID, &draft.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDraftNotFound
+		}
+		utils.GetLoggerFromCtx(ctx).Error("get draft failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	if parentID.Valid {
+		draft.ParentMessageID = &parentID.UUID
+	}
+	if len(entities) > 0 {
+		if err := json.Unmarshal(entities, &draft.Entities); err != nil {
+			return nil, ErrDatabaseScan
+		}
+	}
+	return &draft, nil
+}
+
+// SaveDraft сохраняет черновик; время изменения ставит база
+func (r *draftRepo) SaveDraft(ctx context.Context, userID uuid.UUID, draft *model.Draft) (*model.Draft, error) {
+	var entities []byte
+	if len(draft.Entities) > 0 {
+		data, err := json.Marshal(draft.Entities)
+		if err != nil {
+			return nil, ErrDatabaseOperation
+		}
+		entities = data
+	}
+
+	saved := *draft
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO message_draft (user_id, chat_id, body, entities, parent_message_id, updated_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, chat_id) DO UPDATE
+		SET body = EXCLUDED.body, entities = EXCLUDED.entities,
+			parent_message_id = EXCLUDED.parent_message_id, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`, userID, draft.ChatID, draft.Body, entities, draft.ParentMessageID).Scan(&saved.UpdatedAt)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("save draft failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+	return &saved, nil
+}
+
+// DeleteDraft удаляет черновик и сообщает, был ли он
+func (r *draftRepo) DeleteDraft(ctx context.Context, userID, chatID uuid.UUID) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM message_draft WHERE user_id = $1 AND chat_id = $2
+	`, userID, chatID)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("delete draft failed", zap.Error(err))
+		return false, ErrDatabaseOperation
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, ErrDatabaseOperation
+	}
+	return affected > 0, nil
+}
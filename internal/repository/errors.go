@@ -18,4 +18,16 @@ var (
 	ErrSetNotifications     = errors.New("failed to update send_notifications status")
 	ErrGetNotifications     = errors.New("failed to get send_notifications status")
 	ErrContactAlreadyExists = errors.New("contact already exists")
+	ErrMessagesNotFound     = errors.New("some messages not found in chat")
+
+	ErrScheduledMessageNotFound = errors.New("scheduled message not found")
+	ErrDuplicateClientMessageID = errors.New("message with this client id was already sent")
+	ErrPollClosed               = errors.New("poll is closed")
+	ErrDraftNotFound            = errors.New("draft not found")
+	ErrBookmarkExists           = errors.New("message is already saved")
+	ErrBookmarkNotFound         = errors.New("bookmark not found")
+	ErrNotMessageAuthor         = errors.New("some messages belong to other users")
+	ErrExportInProgress         = errors.New("chat export is already in progress")
+	ErrExportNotFound           = errors.New("chat export not found")
+	ErrFileForbidden            = errors.New("no access to file")
 )
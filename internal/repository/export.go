@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type IExportRepo interface {
+	CreateExport(ctx context.Context, chatID, userID uuid.UUID) (*model.ChatExport, error)
+	GetExport(ctx context.Context, exportID, userID uuid.UUID) (*model.ChatExport, error)
+	ClaimExport(ctx context.Context, staleAfter time.Duration) (*model.ChatExport, error)
+	UpdateExportProgress(ctx context.Context, export *model.ChatExport) error
+	FinishExport(ctx context.Context, export *model.ChatExport) error
+	CountVisibleMessages(ctx context.Context, chatID, viewerID uuid.UUID) (int, error)
+}
+
+type exportRepo struct {
+	db *sql.DB
+}
+
+func NewExportRepo(db *sql.DB) IExportRepo {
+	return &exportRepo{db: db}
+}
+
+const exportColumns = `
+	id, chat_id, user_id, status, processed_messages, total_messages,
+	processed_files, total_files, file_path, error, created_at, finished_at`
+
+func scanExport(row interface{ Scan(...any) error }) (*model.ChatExport, error) {
+	var e model.ChatExport
+	var filePath, errText sql.NullString
+	var finishedAt sql.NullTime
+	err := row.Scan(&e.ID, &e.ChatID, &e.UserID, &e.Status, &e.ProcessedMessages, &e.TotalMessages,
+		&e.ProcessedFiles, &e.TotalFiles, &filePath, &errText, &e.CreatedAt, &finishedAt)
+	if err != nil {
+		return nil, err
+	}
+	e.FileURL = filePath.String
+	e.Error = errText.String
+	if finishedAt.Valid {
+		e.FinishedAt = &finishedAt.Time
+	}
+	e.UpdateProgress()
+	return &e, nil
+}
+
+// CreateExport ставит выгрузку в очередь. Одновременно у пользователя может
+// идти только одна выгрузка чата — за этим следит частичный уникальный индекс.
+func (r *exportRepo) CreateExport(ctx context.Context, chatID, userID uuid.UUID) (*model.ChatExport, error) {
+	export, err := scanExport(r.db.QueryRowContext(ctx, `
+		INSERT INTO chat_export (chat_id, user_id)
+		VALUES ($1, $2)
+		RETURNING`+exportColumns, chatID, userID))
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, ErrExportInProgress
+		}
+		utils.GetLoggerFromCtx(ctx).Error("create export failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+	return export, nil
+}
+
+func (r *exportRepo) GetExport(ctx context.Context, exportID, userID uuid.UUID) (*model.ChatExport, error) {
+	export, err := scanExport(r.db.QueryRowContext(ctx, `
+		SELECT`+exportColumns+`
+		FROM chat_export
+		WHERE id = $1 AND user_id = $2
+	`, exportID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrExportNotFound
+		}
+		utils.GetLoggerFromCtx(ctx).Error("get export failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+	return export, nil
+}
+
+// ClaimExport забирает из очереди самую старую выгрузку. Выгрузка в статусе
+// running, которая не обновлялась дольше staleAfter, считается брошенной
+// упавшей репликой и начинается заново. Возвращает nil, если очередь пуста.
+func (r *exportRepo) ClaimExport(ctx context.Context, staleAfter time.Duration) (*model.ChatExport, error) {
+	export, err := scanExport(r.db.QueryRowContext(ctx, `
+		UPDATE chat_export
+		SET status = 'running', processed_messages = 0, processed_files = 0,
+			total_files = 0, updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM chat_export
+			WHERE status = 'pending'
+			   OR (status = 'running' AND updated_at < CURRENT_TIMESTAMP - make_interval(secs => $1))
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING`+exportColumns, staleAfter.Seconds()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		utils.GetLoggerFromCtx(ctx).Error("claim export failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+	return export, nil
+}
+
+func (r *exportRepo) UpdateExportProgress(ctx context.Context, export *model.ChatExport) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE chat_export
+		SET processed_messages = $2, total_messages = $3, processed_files = $4,
+			total_files = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`, export.ID, export.ProcessedMessages, export.TotalMessages, export.ProcessedFiles, export.TotalFiles)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("update export progress failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+// FinishExport фиксирует итог выгрузки: статус, ссылку на архив или ошибку
+func (r *exportRepo) FinishExport(ctx context.Context, export *model.ChatExport) error {
+	var filePath, errText *string
+	if export.FileURL != "" {
+		filePath = &export.FileURL
+	}
+	if export.Error != "" {
+		errText = &export.Error
+	}
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE chat_export
+		SET status = $2, processed_messages = $3, total_messages = $4, processed_files = $5,
+			total_files = $6, file_path = $7, error = $8,
+			updated_at = CURRENT_TIMESTAMP, finished_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING finished_at
+	`, export.ID, export.Status, export.ProcessedMessages, export.TotalMessages, export.ProcessedFiles,
+		export.TotalFiles, filePath, errText).Scan(&export.FinishedAt)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("finish export failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+// CountVisibleMessages считает сообщения чата, которые видит пользователь
+func (r *exportRepo) CountVisibleMessages(ctx context.Context, chatID, viewerID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM message m
+		WHERE m.chat_id = $1`+notHiddenFor("$2"), chatID, viewerID).Scan(&count)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("count messages failed", zap.Error(err))
+		return 0, ErrDatabaseOperation
+	}
+	return count, nil
+}
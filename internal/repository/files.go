@@ -19,13 +19,20 @@ import (
 type IFilesRepo interface {
 	GetFile(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) (*bytes.Buffer, *model.FileMetaData, error)
 	SaveFile(ctx context.Context, buf *bytes.Buffer, filename, contentType string, size int64, allowedUsers []string) (string, error)
+	OpenFile(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) (io.ReadSeekCloser, *model.FileMetaData, error)
+	SaveStream(ctx context.Context, r io.Reader, filename, contentType string, allowedUsers []string) (string, error)
 	DeleteFile(ctx context.Context, fileID string, userID string) error
+	RemoveFile(ctx context.Context, fileID string) error
 	RewriteFile(ctx context.Context, fileID string, fileBuffer *bytes.Buffer, metadata model.FileMetaData) error
 	CreateSticker(ctx context.Context, fileBuffer *bytes.Buffer, metadata model.FileMetaData, packName string) (uuid.UUID, error)
 	GetStickerPack(ctx context.Context, packID string) (model.GetStickerPackResponse, error)
 	GetStickerPacks(ctx context.Context) (model.StickerPacks, error)
 }
 
+// allowedUsersMetaKey — ключ списка допущенных пользователей в UserMetadata
+// объекта; minio-go отдаёт его без префикса X-Amz-Meta-
+const allowedUsersMetaKey = "Allowed-Users"
+
 type filesRepository struct {
 	minioClient *minio.Client
 	db          *sql.DB
@@ -56,7 +63,7 @@ func (r *filesRepository) GetFile(ctx context.Context, fileID uuid.UUID, userID
 	if err != nil {
 		return nil, nil, err
 	}
-	if err := checkAccess(info, userID.String()); err != nil {
+	if err := r.checkAccess(ctx, info, fileID.String(), userID.String()); err != nil {
 		return nil, nil, err
 	}
 	log.Println(err)
@@ -113,17 +120,73 @@ func (r *filesRepository) SaveFile(ctx context.Context, buf *bytes.Buffer, filen
 	return fileID, nil
 }
 
+// streamPartSize — размер части multipart-загрузки потока неизвестной
+// длины; столько же памяти держит SaveStream
+const streamPartSize = 16 << 20
+
+// OpenFile отдаёт объект потоком, не читая его в память целиком. Seek
+// превращается в запрос нужного диапазона к хранилищу. Читатель обязательно закрыть.
+func (r *filesRepository) OpenFile(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) (io.ReadSeekCloser, *model.FileMetaData, error) {
+	info, err := r.minioClient.StatObject(ctx, r.bucketName, fileID.String(), minio.StatObjectOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := r.checkAccess(ctx, info, fileID.String(), userID.String()); err != nil {
+		return nil, nil, err
+	}
+
+	obj, err := r.minioClient.GetObject(ctx, r.bucketName, fileID.String(), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return obj, &model.FileMetaData{
+		Filename:    info.UserMetadata["Filename"],
+		ContentType: info.ContentType,
+		FileSize:    info.Size,
+		ModTime:     info.LastModified,
+		ETag:        info.ETag,
+	}, nil
+}
+
+// SaveStream сохраняет поток заранее неизвестной длины, например
+// собираемый на лету архив
+func (r *filesRepository) SaveStream(ctx context.Context, reader io.Reader, filename, contentType string, allowedUsers []string) (string, error) {
+	fileID := generateID()
+
+	userMetadata := map[string]string{"filename": filename}
+	if len(allowedUsers) > 0 {
+		userMetadata["allowed-users"] = strings.Join(allowedUsers, ",")
+	}
+
+	_, err := r.minioClient.PutObject(ctx, r.bucketName, fileID, reader, -1, minio.PutObjectOptions{
+		ContentType:  contentType,
+		UserMetadata: userMetadata,
+		PartSize:     streamPartSize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload stream: %w", err)
+	}
+
+	return fileID, nil
+}
+
 func (r *filesRepository) DeleteFile(ctx context.Context, fileID string, userID string) error {
 	info, err := r.minioClient.StatObject(ctx, r.bucketName, fileID, minio.StatObjectOptions{})
 	if err != nil {
 		return err
 	}
-	if err := checkAccess(info, userID); err != nil {
+	if err := r.checkAccess(ctx, info, fileID, userID); err != nil {
 		return err
 	}
 	return r.minioClient.RemoveObject(ctx, r.bucketName, fileID, minio.RemoveObjectOptions{})
 }
 
+// RemoveFile удаляет файл без проверки доступа — для системной очистки
+func (r *filesRepository) RemoveFile(ctx context.Context, fileID string) error {
+	return r.minioClient.RemoveObject(ctx, r.bucketName, fileID, minio.RemoveObjectOptions{})
+}
+
 func (r *filesRepository) RewriteFile(ctx context.Context, fileID string, fileBuffer *bytes.Buffer, metadata model.FileMetaData) error {
 	if fileBuffer == nil || fileBuffer.Len() == 0 {
 		return errors.New("file buffer is empty")
@@ -136,14 +199,14 @@ func (r *filesRepository) RewriteFile(ctx context.Context, fileID string, fileBu
 	}
 
 	// Сохраняем старый список пользователей
-	users := info.UserMetadata["X-Amz-Meta-Users"]
+	users := info.UserMetadata[allowedUsersMetaKey]
 
 	// Перезаписываем файл с новыми метаданными
 	userMeta := map[string]string{
-		"filename":     metadata.Filename,
-		"content-type": metadata.ContentType,
-		"size":         strconv.FormatInt(metadata.FileSize, 10),
-		"users":        users,
+		"filename":      metadata.Filename,
+		"content-type":  metadata.ContentType,
+		"size":          strconv.FormatInt(metadata.FileSize, 10),
+		"allowed-users": users,
 	}
 
 	_, err = r.minioClient.PutObject(ctx, r.bucketName, fileID, bytes.NewReader(fileBuffer.Bytes()), int64(fileBuffer.Len()), minio.PutObjectOptions{
@@ -287,8 +350,12 @@ func (r *filesRepository) GetStickerPack(ctx context.Context, packID string) (mo
 	}, nil
 }
 
-func checkAccess(info minio.ObjectInfo, userID string) error {
-	usersMeta := info.UserMetadata["X-Amz-Meta-Users"]
+// checkAccess пропускает пользователя из списка доступа файла. Список
+// фиксируется при загрузке, поэтому вступившие в чат позже получают доступ
+// через текущее членство в чате, где файл отправлен; вложения каналов
+// читают все, как и сами каналы.
+func (r *filesRepository) checkAccess(ctx context.Context, info minio.ObjectInfo, fileID, userID string) error {
+	usersMeta := info.UserMetadata[allowedUsersMetaKey]
 	if usersMeta == "" {
 		return nil
 	}
@@ -297,7 +364,27 @@ func checkAccess(info minio.ObjectInfo, userID string) error {
 			return nil
 		}
 	}
-	return errors.New("forbidden")
+
+	var allowed bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM message_payload p
+			JOIN message m ON m.id = p.message_id
+			JOIN chat c ON c.id = m.chat_id
+			WHERE p.file_path = $1
+			  AND (c.type = 'channel' OR EXISTS (
+				SELECT 1 FROM user_chat uc WHERE uc.chat_id = m.chat_id AND uc.user_id = $2
+			  ))
+		)
+	`, "/files/"+fileID, userID).Scan(&allowed)
+	if err != nil {
+		return fmt.Errorf("failed to check file access: %w", err)
+	}
+	if !allowed {
+		return ErrFileForbidden
+	}
+	return nil
 }
 
 func generateID() string {
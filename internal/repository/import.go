@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// importBatchSize — сколько сообщений вставляется одним запросом
+const importBatchSize = 500
+
+type IImportRepo interface {
+	ImportChat(ctx context.Context, chat *model.ImportedChat) error
+}
+
+type importRepo struct {
+	db *sql.DB
+}
+
+func NewImportRepo(db *sql.DB) IImportRepo {
+	return &importRepo{db: db}
+}
+
+// Строки для jsonb_to_recordset: так пачка сообщений уходит одним запросом
+type importedMessageRow struct {
+	ID          uuid.UUID  `json:"id"`
+	ParentID    *uuid.UUID `json:"parent_id"`
+	MessageType string     `json:"message_type"`
+	Author      string     `json:"author"`
+	Body        string     `json:"body"`
+	SentAt      time.Time  `json:"sent_at"`
+	EditedAt    *time.Time `json:"edited_at"`
+}
+
+type importedEntityRow struct {
+	MessageID uuid.UUID `json:"message_id"`
+	Type      string    `json:"type"`
+	Offset    int       `json:"offset"`
+	Length    int       `json:"length"`
+	URL       string    `json:"url"`
+}
+
+type importedPayloadRow struct {
+	MessageID   uuid.UUID `json:"message_id"`
+	FilePath    string    `json:"file_path"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	FileSize    int64     `json:"file_size"`
+	DurationMs  *int      `json:"duration_ms"`
+	Waveform    []byte    `json:"waveform"`
+}
+
+// ImportChat в одной транзакции создаёт группу с владельцем и вставляет
+// историю пачками. Все сообщения принадлежат владельцу, исходный автор
+// хранится в imported_author. Сообщения должны идти от старых к новым,
+// чтобы ответ вставлялся после своего родителя.
+func (r *importRepo) ImportChat(ctx context.Context, chat *model.ImportedChat) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("begin tx failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+	defer rollbackTx(logger, tx)
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO chat (type, title, created_at, updated_at)
+		VALUES ('group', $1, NOW(), NOW())
+		RETURNING id
+	`, chat.Title).Scan(&chat.ID)
+	if err != nil {
+		logger.Error("create imported chat failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO user_chat (user_id, chat_id, user_role, joined_at)
+		VALUES ($1, $2, 'owner', NOW())
+	`, chat.OwnerID, chat.ID); err != nil {
+		logger.Error("add import owner failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+
+	for start := 0; start < len(chat.Messages); start += importBatchSize {
+		batch := chat.Messages[start:min(start+importBatchSize, len(chat.Messages))]
+		if err := insertImportedBatch(ctx, tx, chat, batch); err != nil {
+			logger.Error("insert imported messages failed", zap.Int("offset", start), zap.Error(err))
+			return ErrDatabaseOperation
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("commit failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+func insertImportedBatch(ctx context.Context, tx *sql.Tx, chat *model.ImportedChat, batch []model.ImportedMessage) error {
+	messages := make([]importedMessageRow, 0, len(batch))
+	var entities []importedEntityRow
+	var payloads []importedPayloadRow
+	for _, m := range batch {
+		messageType := defaultMessageType
+		if len(m.Payloads) > 0 {
+			messageType = MessageWithPayloadType
+		}
+		messages = append(messages, importedMessageRow{
+			ID:          m.ID,
+			ParentID:    m.ParentMessageID,
+			MessageType: messageType,
+			Author:      m.Author,
+			Body:        m.Body,
+			SentAt:      m.SentAt,
+			EditedAt:    m.EditedAt,
+		})
+		for _, e := range m.Entities {
+			entities = append(entities, importedEntityRow{
+				MessageID: m.ID, Type: string(e.Type), Offset: e.Offset, Length: e.Length, URL: e.URL,
+			})
+		}
+		for _, p := range m.Payloads {
+			row := importedPayloadRow{
+				MessageID: m.ID, FilePath: p.URL, FileName: p.Filename, ContentType: p.ContentType, FileSize: p.Size,
+			}
+			if p.ContentType == voicePayloadType {
+				row.DurationMs, row.Waveform = &p.DurationMs, p.Waveform
+			}
+			payloads = append(payloads, row)
+		}
+	}
+
+	data, err := json.Marshal(messages)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO message (id, parent_message_id, message_type, chat_id, user_id, imported_author,
+			body, sent_at, edited_at, edit_count)
+		SELECT m.id, m.parent_id, m.message_type::message_type, $2, $3, m.author,
+			m.body, m.sent_at, m.edited_at, CASE WHEN m.edited_at IS NULL THEN 0 ELSE 1 END
+		FROM jsonb_to_recordset($1::jsonb) AS m(
+			id UUID, parent_id UUID, message_type TEXT, author TEXT,
+			body TEXT, sent_at TIMESTAMP, edited_at TIMESTAMP
+		)
+	`, data, chat.ID, chat.OwnerID); err != nil {
+		return err
+	}
+
+	if len(entities) > 0 {
+		if data, err = json.Marshal(entities); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_entity (message_id, type, entity_offset, entity_length, url)
+			SELECT e.message_id, e.type, e.offset, e.length, NULLIF(e.url, '')
+			FROM jsonb_to_recordset($1::jsonb) AS e(message_id UUID, type TEXT, "offset" INTEGER, length INTEGER, url TEXT)
+		`, data); err != nil {
+			return err
+		}
+	}
+
+	if len(payloads) > 0 {
+		if data, err = json.Marshal(payloads); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_payload (message_id, file_path, file_name, content_type, file_size, duration_ms, waveform)
+			SELECT p.message_id, p.file_path, p.file_name, p.content_type, p.file_size, p.duration_ms, decode(p.waveform, 'base64')
+			FROM jsonb_to_recordset($1::jsonb) AS p(
+				message_id UUID, file_path TEXT, file_name TEXT, content_type TEXT, file_size INTEGER,
+				duration_ms INTEGER, waveform TEXT
+			)
+		`, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,10 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"log"
+	"slices"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
 )
 
 const (
@@ -14,18 +21,44 @@ const (
 	defaultMessageType     = "default"
 	MessageWithPayloadType = "with_payload"
 	stickerMessageType     = "sticker"
+	pollMessageType        = "poll"
 	filePayloadType        = "file"
 	photoPayloadType       = "photo"
+	voicePayloadType       = "voice"
 )
 
 type IMessageRepo interface {
-	GetMessages(ctx context.Context, chatID uuid.UUID) ([]model.Message, error)
-	GetMessagesBefore(ctx context.Context, chatID, beforeMessageID uuid.UUID) ([]model.Message, error)
-	GetMessagesAfter(ctx context.Context, chatID, afterMessageID uuid.UUID) ([]model.Message, error)
+	GetMessagePage(ctx context.Context, chatID, viewerID uuid.UUID, query model.HistoryQuery) (*model.MessagePage, error)
+	GetMessageWindow(ctx context.Context, chatID, viewerID uuid.UUID, anchor model.MessageCursor, limit int) (*model.MessagePage, error)
 	GetMessage(ctx context.Context, id uuid.UUID) (*model.Message, error)
+	GetReplies(ctx context.Context, parentMessageID, viewerID uuid.UUID, afterReplyID *uuid.UUID) ([]model.Message, error)
 	CreateMessage(ctx context.Context, message *model.Message) (*model.Message, error)
-	UpdateMessage(ctx context.Context, messageID uuid.UUID, newBody string) (*model.Message, error)
-	DeleteMessage(ctx context.Context, messageID uuid.UUID) (*model.Message, error)
+	FindMessageByClientID(ctx context.Context, userID, chatID uuid.UUID, clientMessageID string) (*model.Message, error)
+	PurgeClientMessageIDs(ctx context.Context) error
+	UpdateMessage(ctx context.Context, messageID uuid.UUID, newBody string, entities []model.MessageEntity) (*model.Message, error)
+	GetMessageRevisions(ctx context.Context, messageID uuid.UUID) ([]model.MessageRevision, error)
+	DeleteMessage(ctx context.Context, messageID uuid.UUID) (*model.Message, []string, error)
+	HideMessage(ctx context.Context, messageID, userID uuid.UUID) error
+	DeleteMessages(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, authorID *uuid.UUID) ([]string, error)
+	HideMessages(ctx context.Context, chatID, userID uuid.UUID, messageIDs []uuid.UUID) error
+	ClearHistory(ctx context.Context, chatID uuid.UUID) (*time.Time, []string, error)
+	HideHistory(ctx context.Context, chatID, userID uuid.UUID) (*time.Time, error)
+	SetReaction(ctx context.Context, messageID, userID uuid.UUID, reaction string) error
+	DeleteReaction(ctx context.Context, messageID, userID uuid.UUID) error
+	MarkRead(ctx context.Context, chatID, userID, messageID uuid.UUID) (int64, error)
+	ForwardMessages(ctx context.Context, userID, fromChatID, toChatID uuid.UUID, messageIDs []uuid.UUID) ([]model.Message, error)
+	CreateScheduledMessage(ctx context.Context, message *model.Message, sendAt time.Time) (*model.ScheduledMessage, error)
+	GetScheduledMessages(ctx context.Context, chatID, userID uuid.UUID) ([]model.ScheduledMessage, error)
+	GetScheduledMessage(ctx context.Context, id uuid.UUID) (*model.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, id uuid.UUID, body *string, entities []model.MessageEntity, sendAt *time.Time) (*model.ScheduledMessage, error)
+	DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error
+	DispatchDueMessages(ctx context.Context, batchSize int) ([]model.Message, []string, error)
+	DeleteExpiredMessages(ctx context.Context, batchSize int) ([]model.Message, []string, error)
+	GetNextUnreadMention(ctx context.Context, chatID, userID uuid.UUID, afterMessageID *uuid.UUID) (*model.Message, error)
+	AttachLinkPreview(ctx context.Context, messageID uuid.UUID, editCount int, preview model.LinkPreview) error
+	VotePoll(ctx context.Context, messageID, userID uuid.UUID, positions []int) error
+	RetractPollVote(ctx context.Context, messageID, userID uuid.UUID) error
+	GetPollVotes(ctx context.Context, messageID, userID uuid.UUID) ([]int, error)
 }
 
 type messageRepo struct {
@@ -36,9 +69,9 @@ func NewMessageRepo(db *sql.DB) IMessageRepo {
 	return &messageRepo{db: db}
 }
 
-func (r *messageRepo) GetMessages(ctx context.Context, chatID uuid.UUID) ([]model.Message, error) {
-	query := `
-		SELECT 
+// messageSelect — общий список полей сообщения для всех выборок истории
+const messageSelect = `
+		SELECT
 			m.id,
 			m.parent_message_id,
 			m.chat_id,
@@ -46,18 +79,280 @@ func (r *messageRepo) GetMessages(ctx context.Context, chatID uuid.UUID) ([]mode
 			m.body,
 			m.sent_at,
 			m.is_redacted,
-			u.avatar_path,
-			u.username,
+			CASE WHEN m.imported_author IS NULL THEN u.avatar_path END,
+			COALESCE(m.imported_author, u.username),
 			m.message_type,
-			m.sticker_path
+			m.sticker_path,
+			(
+				SELECT COALESCE(json_agg(json_build_object('reaction', rc.reaction, 'count', rc.cnt) ORDER BY rc.cnt DESC, rc.reaction), '[]')
+				FROM (
+					SELECT reaction, COUNT(*) AS cnt
+					FROM message_reaction
+					WHERE message_id = m.id
+					GROUP BY reaction
+				) rc
+			) AS reactions,
+			pm.id,
+			pm.user_id,
+			COALESCE(pm.imported_author, pu.username),
+			LEFT(pm.body, 100),
+			pm.message_type,
+			(
+				SELECT COUNT(*)
+				FROM message rm
+				WHERE rm.parent_message_id = m.id
+			) AS reply_count,
+			m.forwarded_from_user_id,
+			fu.username,
+			m.forwarded_from_chat_id,
+			m.forwarded_sent_at,
+			m.expires_at,
+			m.edit_count,
+			m.edited_at,
+			m.deleted_at,
+			(
+				SELECT COALESCE(json_agg(json_strip_nulls(json_build_object(
+					'type', me.type, 'offset', me.entity_offset, 'length', me.entity_length,
+					'user_id', me.user_id, 'url', me.url
+				)) ORDER BY me.entity_offset, me.type), '[]')
+				FROM message_entity me
+				WHERE me.message_id = m.id
+			) AS entities,
+			(
+				SELECT json_strip_nulls(json_build_object(
+					'url', lp.url, 'title', lp.title, 'description', lp.description,
+					'image_url', lp.image_url, 'site_name', lp.site_name
+				))
+				FROM link_preview lp
+				WHERE lp.url = m.link_preview_url
+			) AS link_preview,
+			(
+				SELECT json_build_object(
+					'question', p.question,
+					'multiple_choice', p.multiple_choice,
+					'anonymous', p.anonymous,
+					'closes_at', p.closes_at,
+					'closed', COALESCE(p.closes_at <= CURRENT_TIMESTAMP, FALSE),
+					'total_voters', (SELECT COUNT(DISTINCT pv.user_id) FROM poll_vote pv WHERE pv.message_id = p.message_id),
+					'options', (
+						SELECT json_agg(json_strip_nulls(json_build_object(
+							'position', po.position,
+							'text', po.text,
+							'votes', (
+								SELECT COUNT(*) FROM poll_vote pv
+								WHERE pv.message_id = po.message_id AND pv.position = po.position
+							),
+							'voters', CASE WHEN NOT p.anonymous THEN (
+								SELECT json_agg(pv.user_id ORDER BY pv.voted_at)
+								FROM poll_vote pv
+								WHERE pv.message_id = po.message_id AND pv.position = po.position
+							) END
+						)) ORDER BY po.position)
+						FROM poll_option po
+						WHERE po.message_id = p.message_id
+					)
+				)
+				FROM poll p
+				WHERE p.message_id = m.id
+			) AS poll
 		FROM message m
 		JOIN public.user u ON m.user_id = u.id
-		WHERE m.chat_id = $1
-		ORDER BY m.sent_at DESC
-		LIMIT $2
+		LEFT JOIN message pm ON pm.id = m.parent_message_id
+		LEFT JOIN public.user pu ON pu.id = pm.user_id
+		LEFT JOIN public.user fu ON fu.id = m.forwarded_from_user_id`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMessage(row rowScanner) (model.Message, error) {
+	var msg model.Message
+	var parentMsgID sql.NullString
+	var avatarPath sql.NullString
+	var stickerPath sql.NullString
+	var reactions []byte
+	var parentID, parentUserID uuid.NullUUID
+	var parentUsername, parentBody, parentType sql.NullString
+	var fwdUserID, fwdChatID uuid.NullUUID
+	var fwdUsername sql.NullString
+	var fwdSentAt sql.NullTime
+	var expiresAt sql.NullTime
+	var editedAt sql.NullTime
+	var deletedAt sql.NullTime
+	var entities, linkPreview, poll []byte
+
+	err := row.Scan(
+		&msg.ID,
+		&parentMsgID,
+		&msg.ChatID,
+		&msg.UserID,
+		&msg.Body,
+		&msg.SentAt,
+		&msg.IsRedacted,
+		&avatarPath,
+		&msg.Username,
+		&msg.MessageType,
+		&stickerPath,
+		&reactions,
+		&parentID,
+		&parentUserID,
+		&parentUsername,
+		&parentBody,
+		&parentType,
+		&msg.ReplyCount,
+		&fwdUserID,
+		&fwdUsername,
+		&fwdChatID,
+		&fwdSentAt,
+		&expiresAt,
+		&msg.EditCount,
+		&editedAt,
+		&deletedAt,
+		&entities,
+		&linkPreview,
+		&poll,
+	)
+	if err != nil {
+		return msg, err
+	}
+
+	if expiresAt.Valid {
+		msg.ExpiresAt = &expiresAt.Time
+	}
+	if editedAt.Valid {
+		msg.EditedAt = &editedAt.Time
+	}
+	if deletedAt.Valid {
+		msg.DeletedAt = &deletedAt.Time
+	}
+
+	if fwdSentAt.Valid {
+		msg.Forward = &model.ForwardInfo{
+			Username: fwdUsername.String,
+			SentAt:   fwdSentAt.Time,
+		}
+		if fwdUserID.Valid {
+			msg.Forward.UserID = &fwdUserID.UUID
+		}
+		if fwdChatID.Valid {
+			msg.Forward.ChatID = &fwdChatID.UUID
+		}
+	}
+
+	if parentID.Valid {
+		msg.Parent = &model.ParentPreview{
+			ID:          parentID.UUID,
+			UserID:      parentUserID.UUID,
+			Username:    parentUsername.String,
+			Body:        parentBody.String,
+			MessageType: parentType.String,
+		}
+	}
+
+	if parentMsgID.Valid {
+		id, err := uuid.Parse(parentMsgID.String)
+		if err == nil {
+			msg.ParentMessageID = &id
+		}
+	}
+
+	if avatarPath.Valid {
+		msg.AvatarPath = &avatarPath.String
+	}
+
+	if stickerPath.Valid {
+		msg.Sticker = stickerPath.String
+	}
+
+	if len(reactions) > 0 {
+		if err := json.Unmarshal(reactions, &msg.Reactions); err != nil {
+			return msg, err
+		}
+	}
+
+	if len(entities) > 0 {
+		if err := json.Unmarshal(entities, &msg.Entities); err != nil {
+			return msg, err
+		}
+	}
+
+	if len(linkPreview) > 0 {
+		if err := json.Unmarshal(linkPreview, &msg.LinkPreview); err != nil {
+			return msg, err
+		}
+	}
+
+	if len(poll) > 0 {
+		if err := json.Unmarshal(poll, &msg.Poll); err != nil {
+			return msg, err
+		}
+	}
+
+	return msg, nil
+}
+
+// loadPayloads подгружает файлы, фото и голосовые сообщений одним запросом
+func (r *messageRepo) loadPayloads(ctx context.Context, messages []model.Message) error {
+	byID := make(map[uuid.UUID]*model.Message)
+	var ids []string
+	for i := range messages {
+		if messages[i].MessageType == MessageWithPayloadType {
+			byID[messages[i].ID] = &messages[i]
+			ids = append(ids, messages[i].ID.String())
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	payloadQuery := `
+		SELECT message_id, file_path, file_name, content_type, file_size, duration_ms, waveform
+		FROM public.message_payload
+		WHERE message_id = ANY($1::uuid[])
 	`
+	rows, err := r.db.QueryContext(ctx, payloadQuery, pq.Array(ids))
+	if err != nil {
+		log.Println("get payloads:", err)
+		return ErrDatabaseOperation
+	}
+	defer rows.Close()
 
-	rows, err := r.db.QueryContext(ctx, query, chatID, limit)
+	for rows.Next() {
+		var messageID uuid.UUID
+		var path, filename, contentType string
+		var size int64
+		var duration sql.NullInt32
+		var waveform []byte
+		if err := rows.Scan(&messageID, &path, &filename, &contentType, &size, &duration, &waveform); err != nil {
+			log.Printf("scan payload error: %v", err)
+			return ErrDatabaseScan
+		}
+		msg, ok := byID[messageID]
+		if !ok {
+			continue
+		}
+		payload := model.Payload{
+			URL:         path,
+			Filename:    filename,
+			Size:        size,
+			ContentType: contentType,
+			DurationMs:  int(duration.Int32),
+			Waveform:    waveform,
+		}
+		switch contentType {
+		case filePayloadType:
+			msg.FilesDTO = append(msg.FilesDTO, payload)
+		case photoPayloadType:
+			msg.PhotosDTO = append(msg.PhotosDTO, payload)
+		case voicePayloadType:
+			msg.VoiceDTO = &payload
+		}
+	}
+	return rows.Err()
+}
+
+func (r *messageRepo) queryMessages(ctx context.Context, query string, args ...any) ([]model.Message, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		log.Println("get messages:", err)
 		return nil, ErrDatabaseOperation
@@ -65,526 +360,1515 @@ func (r *messageRepo) GetMessages(ctx context.Context, chatID uuid.UUID) ([]mode
 	defer rows.Close()
 
 	var messages []model.Message
-
 	for rows.Next() {
-		var msg model.Message
-		var parentMsgID sql.NullString
-		var avatarPath sql.NullString
-		var stickerPath sql.NullString
-
-		err := rows.Scan(
-			&msg.ID,
-			&parentMsgID,
-			&msg.ChatID,
-			&msg.UserID,
-			&msg.Body,
-			&msg.SentAt,
-			&msg.IsRedacted,
-			&avatarPath,
-			&msg.Username,
-			&msg.MessageType,
-			&stickerPath,
-		)
+		msg, err := scanMessage(rows)
 		if err != nil {
 			log.Println("scan message:", err)
 			return nil, ErrDatabaseScan
 		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println("rows error:", err)
+		return nil, ErrDatabaseOperation
+	}
+
+	if err := r.loadPayloads(ctx, messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// notHiddenFor отсекает сообщения, которые зритель удалил «для себя»;
+// параметр — номер плейсхолдера с ID зрителя
+func notHiddenFor(param string) string {
+	return `
+		  AND NOT EXISTS (
+			SELECT 1 FROM message_hidden h
+			WHERE h.user_id = ` + param + ` AND h.message_id = m.id
+		  )`
+}
+
+// GetMessagePage отдаёт страницу истории чата по ключу (sent_at, id).
+// Наличие сообщений с другой стороны курсора проверяется отдельным EXISTS.
+func (r *messageRepo) GetMessagePage(ctx context.Context, chatID, viewerID uuid.UUID, q model.HistoryQuery) (*model.MessagePage, error) {
+	page := &model.MessagePage{}
+	var cursor *model.MessageCursor
+	var err error
+	switch {
+	case q.After != nil:
+		cursor = q.After
+		page.Messages, page.HasNewer, err = r.queryHistorySide(ctx, chatID, viewerID, cursor, ">", q.Limit)
+		if err == nil {
+			page.HasOlder, err = r.hasMessagesBeyond(ctx, chatID, viewerID, *cursor, "<=")
+		}
+	case q.Before != nil:
+		cursor = q.Before
+		page.Messages, page.HasOlder, err = r.queryHistorySide(ctx, chatID, viewerID, cursor, "<", q.Limit)
+		if err == nil {
+			page.HasNewer, err = r.hasMessagesBeyond(ctx, chatID, viewerID, *cursor, ">=")
+		}
+	default:
+		page.Messages, page.HasOlder, err = r.queryHistorySide(ctx, chatID, viewerID, nil, "", q.Limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(page.Messages) > 0 {
+		page.NewerCursor = model.NewMessageCursor(page.Messages[0]).Encode()
+		page.OlderCursor = model.NewMessageCursor(page.Messages[len(page.Messages)-1]).Encode()
+	} else if cursor != nil {
+		// Пустая страница: курсор остаётся прежним, чтобы клиент мог повторить запрос позже
+		page.NewerCursor = cursor.Encode()
+		page.OlderCursor = cursor.Encode()
+	}
+	return page, nil
+}
+
+// GetMessageWindow отдаёт окно истории вокруг опорной точки: до limit
+// сообщений не раньше неё и до limit сообщений раньше. Опорным считается
+// первое сообщение не раньше точки, а если таких нет — последнее до неё.
+func (r *messageRepo) GetMessageWindow(ctx context.Context, chatID, viewerID uuid.UUID, anchor model.MessageCursor, limit int) (*model.MessagePage, error) {
+	newer, hasNewer, err := r.queryHistorySide(ctx, chatID, viewerID, &anchor, ">=", limit)
+	if err != nil {
+		return nil, err
+	}
+	older, hasOlder, err := r.queryHistorySide(ctx, chatID, viewerID, &anchor, "<", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.MessagePage{
+		Messages: append(newer, older...),
+		HasOlder: hasOlder,
+		HasNewer: hasNewer,
+	}
+	switch {
+	case len(newer) > 0:
+		page.AnchorID = &newer[len(newer)-1].ID
+	case len(older) > 0:
+		page.AnchorID = &older[0].ID
+	}
+
+	if len(page.Messages) > 0 {
+		page.NewerCursor = model.NewMessageCursor(page.Messages[0]).Encode()
+		page.OlderCursor = model.NewMessageCursor(page.Messages[len(page.Messages)-1]).Encode()
+	} else {
+		page.NewerCursor = anchor.Encode()
+		page.OlderCursor = anchor.Encode()
+	}
+	return page, nil
+}
+
+// queryHistorySide выбирает сообщения по одну сторону курсора (op — оператор
+// сравнения ключа, пустой без курсора) и возвращает их от новых к старым.
+// Запрашивается на одно сообщение больше лимита, чтобы без COUNT узнать,
+// есть ли ещё сообщения в эту сторону.
+func (r *messageRepo) queryHistorySide(ctx context.Context, chatID, viewerID uuid.UUID, cursor *model.MessageCursor, op string, limit int) ([]model.Message, bool, error) {
+	args := []any{chatID, viewerID, limit + 1}
+	keyset := ""
+	if cursor != nil {
+		keyset = `
+		  AND (m.sent_at, m.id) ` + op + ` ($4, $5)`
+		args = append(args, cursor.SentAt, cursor.ID)
+	}
+	ascending := op == ">" || op == ">="
+	order := "DESC"
+	if ascending {
+		order = "ASC"
+	}
+
+	query := messageSelect + `
+		WHERE m.chat_id = $1` + keyset + notHiddenFor("$2") + `
+		ORDER BY m.sent_at ` + order + `, m.id ` + order + `
+		LIMIT $3
+	`
+	messages, err := r.queryMessages(ctx, query, args...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	more := len(messages) > limit
+	if more {
+		messages = messages[:limit]
+	}
+	if ascending {
+		slices.Reverse(messages)
+	}
+	return messages, more, nil
+}
+
+// hasMessagesBeyond проверяет, есть ли видимые зрителю сообщения по другую
+// сторону курсора; op включает само сообщение курсора
+func (r *messageRepo) hasMessagesBeyond(ctx context.Context, chatID, viewerID uuid.UUID, cursor model.MessageCursor, op string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM message m
+			WHERE m.chat_id = $1
+			  AND (m.sent_at, m.id) `+op+` ($3, $4)`+notHiddenFor("$2")+`
+		)
+	`, chatID, viewerID, cursor.SentAt, cursor.ID).Scan(&exists)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("check adjacent messages failed", zap.Error(err))
+		return false, ErrDatabaseOperation
+	}
+	return exists, nil
+}
+
+// GetReplies возвращает ответы на сообщение в хронологическом порядке.
+// Если задан afterReplyID, выдача начинается со следующего за ним ответа.
+func (r *messageRepo) GetReplies(ctx context.Context, parentMessageID, viewerID uuid.UUID, afterReplyID *uuid.UUID) ([]model.Message, error) {
+	if afterReplyID == nil {
+		query := messageSelect + `
+		WHERE m.parent_message_id = $1` + notHiddenFor("$3") + `
+		ORDER BY m.sent_at ASC, m.id ASC
+		LIMIT $2
+	`
+		return r.queryMessages(ctx, query, parentMessageID, limit, viewerID)
+	}
+
+	query := `
+		WITH ref_message AS (
+			SELECT sent_at, id FROM message WHERE id = $2
+		)` + messageSelect + `
+		JOIN ref_message r ON TRUE
+		WHERE m.parent_message_id = $1
+		  AND (
+			m.sent_at > r.sent_at
+			OR (m.sent_at = r.sent_at AND m.id::text > r.id::text)
+		  )` + notHiddenFor("$4") + `
+		ORDER BY m.sent_at ASC, m.id ASC
+		LIMIT $3
+	`
+	return r.queryMessages(ctx, query, parentMessageID, *afterReplyID, limit, viewerID)
+}
+
+func (r *messageRepo) GetMessage(ctx context.Context, id uuid.UUID) (*model.Message, error) {
+	query := messageSelect + `
+		WHERE m.id = $1
+	`
+
+	msg, err := scanMessage(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		log.Println("get message:", err)
+		return nil, ErrDatabaseOperation
+	}
+
+	messages := []model.Message{msg}
+	if err := r.loadPayloads(ctx, messages); err != nil {
+		return nil, err
+	}
+	msg = messages[0]
+
+	return &msg, nil
+}
+
+// queryer — общее подмножество *sql.DB и *sql.Tx для вставки сообщений
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// insertMessage сохраняет сообщение вместе с вложениями и заполняет message.ID
+func insertMessage(ctx context.Context, q queryer, message *model.Message) error {
+	messageType := defaultMessageType
+	if message.PollInput != nil {
+		messageType = pollMessageType
+	} else if message.Sticker != "" {
+		messageType = stickerMessageType
+	} else if len(message.Payloads()) > 0 {
+		messageType = MessageWithPayloadType
+	}
+
+	query := `
+		INSERT INTO message (user_id, chat_id, body, message_type, sticker_path, parent_message_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6,
+			CURRENT_TIMESTAMP + make_interval(secs => COALESCE($7::int, (SELECT message_ttl FROM chat WHERE id = $2))))
+		RETURNING id
+	`
+
+	err := q.QueryRowContext(ctx, query,
+		message.UserID,
+		message.ChatID,
+		message.Body,
+		messageType,
+		message.Sticker,
+		message.ParentMessageID,
+		message.TTL,
+	).Scan(&message.ID)
+	if err != nil {
+		log.Println("insert message:", err)
+		return ErrDatabaseOperation
+	}
+
+	if err := insertEntities(ctx, q, message.ID, message.Entities); err != nil {
+		return err
+	}
+
+	if message.PollInput != nil {
+		return insertPoll(ctx, q, message.ID, message.PollInput)
+	}
+
+	if messageType != MessageWithPayloadType {
+		return nil
+	}
+
+	for _, payload := range message.Payloads() {
+		// Длительность и огибающая есть только у голосовых, у остальных вложений NULL
+		var duration, waveform any
+		if payload.ContentType == voicePayloadType {
+			duration, waveform = payload.DurationMs, payload.Waveform
+		}
+		_, err = q.ExecContext(ctx, `
+			INSERT INTO message_payload (id, message_id, file_path, file_name, content_type, file_size, duration_ms, waveform)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, uuid.New(), message.ID, payload.URL, payload.Filename, payload.ContentType, payload.Size, duration, waveform)
+		if err != nil {
+			log.Println("insert payload:", err)
+			return ErrDatabaseOperation
+		}
+	}
+	return nil
+}
+
+// insertEntities сохраняет разметку текста сообщения (форматирование
+// и упоминания) одним запросом
+func insertEntities(ctx context.Context, q queryer, messageID uuid.UUID, entities []model.MessageEntity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(entities)
+	if err != nil {
+		return ErrDatabaseOperation
+	}
+
+	_, err = q.ExecContext(ctx, `
+		INSERT INTO message_entity (message_id, type, entity_offset, entity_length, user_id, url)
+		SELECT $1, e.type, e.offset, e.length, e.user_id, NULLIF(e.url, '')
+		FROM jsonb_to_recordset($2::jsonb) AS e(type TEXT, "offset" INTEGER, length INTEGER, user_id UUID, url TEXT)
+	`, messageID, data)
+	if err != nil {
+		log.Println("insert entities:", err)
+		return ErrDatabaseOperation
+	}
+	return nil
+}
 
-		if parentMsgID.Valid {
-			id, err := uuid.Parse(parentMsgID.String)
-			if err == nil {
-				msg.ParentMessageID = &id
+// insertPoll сохраняет опрос и его варианты; позиция варианта — его индекс во входном списке
+func insertPoll(ctx context.Context, q queryer, messageID uuid.UUID, poll *model.PollInput) error {
+	_, err := q.ExecContext(ctx, `
+		WITH p AS (
+			INSERT INTO poll (message_id, question, multiple_choice, anonymous, closes_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING message_id
+		)
+		INSERT INTO poll_option (message_id, position, text)
+		SELECT p.message_id, o.ord - 1, o.text
+		FROM p, unnest($6::text[]) WITH ORDINALITY AS o(text, ord)
+	`, messageID, poll.Question, poll.MultipleChoice, poll.Anonymous, poll.ClosesAt, pq.Array(poll.Options))
+	if err != nil {
+		log.Println("insert poll:", err)
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+// idempotencyWindowSecs — окно идемпотентности в секундах для make_interval
+var idempotencyWindowSecs = int(model.IdempotencyWindow.Seconds())
+
+// CreateMessage атомарно вставляет сообщение и строки вложений. Если задан
+// client_message_id, в той же транзакции занимается ключ: когда он уже занят
+// в пределах окна, вставка откатывается и возвращается ErrDuplicateClientMessageID.
+// Параллельный повтор ждёт на первичном ключе, поэтому дубль не проходит и при гонке.
+func (r *messageRepo) CreateMessage(ctx context.Context, message *model.Message) (*model.Message, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	if err := insertMessage(ctx, tx, message); err != nil {
+		logger.Error("insert message failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, err
+	}
+
+	if message.ClientMessageID != "" {
+		var claimedID uuid.UUID
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO message_client_id (user_id, chat_id, client_message_id, message_id)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id, chat_id, client_message_id) DO UPDATE
+			SET message_id = EXCLUDED.message_id, created_at = CURRENT_TIMESTAMP
+			WHERE message_client_id.created_at < CURRENT_TIMESTAMP - make_interval(secs => $5)
+			RETURNING message_id
+		`, message.UserID, message.ChatID, message.ClientMessageID, message.ID, idempotencyWindowSecs).Scan(&claimedID)
+		if err != nil {
+			rollbackTx(logger, tx)
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, ErrDuplicateClientMessageID
 			}
+			logger.Error("claim client message id failed", zap.Error(err))
+			return nil, ErrDatabaseOperation
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	// Сообщение уже сохранено: ошибку перечитывания не выдаём за сбой записи,
+	// иначе вызывающий удалит файлы живого сообщения
+	messageOut, err := r.GetMessage(ctx, message.ID)
+	if err != nil {
+		logger.Error("reload created message failed", zap.Error(err))
+		return message, nil
+	}
+	return messageOut, nil
+}
+
+// FindMessageByClientID ищет сообщение, отправленное с этим client_message_id
+// в пределах окна идемпотентности; если его нет, возвращает nil без ошибки
+func (r *messageRepo) FindMessageByClientID(ctx context.Context, userID, chatID uuid.UUID, clientMessageID string) (*model.Message, error) {
+	var messageID uuid.UUID
+	err := r.db.QueryRowContext(ctx, `
+		SELECT message_id
+		FROM message_client_id
+		WHERE user_id = $1 AND chat_id = $2 AND client_message_id = $3
+		  AND created_at >= CURRENT_TIMESTAMP - make_interval(secs => $4)
+	`, userID, chatID, clientMessageID, idempotencyWindowSecs).Scan(&messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, ErrDatabaseOperation
+	}
+
+	return r.GetMessage(ctx, messageID)
+}
+
+// PurgeClientMessageIDs удаляет ключи идемпотентности старше окна
+func (r *messageRepo) PurgeClientMessageIDs(ctx context.Context) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM message_client_id
+		WHERE created_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+	`, idempotencyWindowSecs)
+	if err != nil {
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+// UpdateMessage меняет текст сообщения, сохраняя прежний в message_version,
+// и заменяет его разметку. Строка блокируется, чтобы параллельные правки
+// не получили один номер версии.
+func (r *messageRepo) UpdateMessage(ctx context.Context, messageID uuid.UUID, newBody string, entities []model.MessageEntity) (*model.Message, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, ErrUpdateFailed
+	}
+
+	query := `
+		WITH prev AS (
+			SELECT id, edit_count, body, COALESCE(edited_at, sent_at) AS created_at
+			FROM message
+			WHERE id = $2
+			FOR UPDATE
+		), saved AS (
+			INSERT INTO message_version (message_id, version, body, created_at)
+			SELECT id, edit_count + 1, body, created_at FROM prev
+		)
+		UPDATE message m
+		SET body = $1, is_redacted = true, edit_count = prev.edit_count + 1, edited_at = CURRENT_TIMESTAMP,
+			link_preview_url = CASE WHEN strpos($1, m.link_preview_url) > 0 THEN m.link_preview_url END
+		FROM prev
+		WHERE m.id = prev.id
+		RETURNING m.id
+	`
+
+	var updatedID uuid.UUID
+	err = tx.QueryRowContext(ctx, query, newBody, messageID).Scan(&updatedID)
+	if err != nil {
+		rollbackTx(logger, tx)
+		return nil, ErrUpdateFailed
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_entity WHERE message_id = $1`, updatedID); err != nil {
+		logger.Error("clear entities failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, ErrUpdateFailed
+	}
+	if err := insertEntities(ctx, tx, updatedID, entities); err != nil {
+		rollbackTx(logger, tx)
+		return nil, ErrUpdateFailed
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, ErrUpdateFailed
+	}
+
+	return r.GetMessage(ctx, updatedID)
+}
+
+// GetMessageRevisions возвращает прежние версии текста сообщения, от исходной
+func (r *messageRepo) GetMessageRevisions(ctx context.Context, messageID uuid.UUID) ([]model.MessageRevision, error) {
+	query := `
+		SELECT version, body, created_at, replaced_at
+		FROM message_version
+		WHERE message_id = $1
+		ORDER BY version
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, messageID)
+	if err != nil {
+		return nil, ErrDatabaseOperation
+	}
+	defer rows.Close()
+
+	var revisions []model.MessageRevision
+	for rows.Next() {
+		var rev model.MessageRevision
+		var replacedAt sql.NullTime
+		if err := rows.Scan(&rev.Version, &rev.Body, &rev.CreatedAt, &replacedAt); err != nil {
+			return nil, ErrDatabaseScan
+		}
+		if replacedAt.Valid {
+			rev.ReplacedAt = &replacedAt.Time
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrDatabaseOperation
+	}
+
+	return revisions, nil
+}
+
+// DeleteMessage удаляет сообщение для всех: строка остаётся «надгробием»
+// без текста, вложений, реакций и истории правок, чтобы не ломать ответы и ленту
+func (r *messageRepo) DeleteMessage(ctx context.Context, messageID uuid.UUID) (*model.Message, []string, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
+	}
+
+	deleted := model.Message{ID: messageID}
+	var deletedAt time.Time
+	err = tx.QueryRowContext(ctx, `
+		UPDATE message
+		SET body = '', sticker_path = NULL, message_type = 'default', is_redacted = false,
+			link_preview_url = NULL, deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING chat_id, deleted_at
+	`, messageID).Scan(&deleted.ChatID, &deletedAt)
+	if err != nil {
+		rollbackTx(logger, tx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, ErrMessagesNotFound
+		}
+		logger.Error("tombstone message failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
+	}
+	deleted.DeletedAt = &deletedAt
+
+	// Файлы, общие с пересланными копиями, остаются в хранилище
+	var orphaned []string
+	err = tx.QueryRowContext(ctx, `
+		WITH removed AS (
+			DELETE FROM message_payload WHERE message_id = $1
+			RETURNING file_path
+		)
+		SELECT COALESCE(array_agg(DISTINCT r.file_path), '{}')
+		FROM removed r
+		WHERE NOT EXISTS (
+			SELECT 1 FROM message_payload other
+			WHERE other.file_path = r.file_path AND other.message_id <> $1
+		)
+	`, messageID).Scan(pq.Array(&orphaned))
+	if err != nil {
+		logger.Error("delete payloads failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, nil, ErrDatabaseOperation
+	}
+
+	for _, query := range []string{
+		`DELETE FROM message_reaction WHERE message_id = $1`,
+		`DELETE FROM message_version WHERE message_id = $1`,
+		`DELETE FROM message_entity WHERE message_id = $1`,
+		`DELETE FROM poll WHERE message_id = $1`,
+		`DELETE FROM pinned_message WHERE message_id = $1`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, messageID); err != nil {
+			logger.Error("clear tombstone content failed", zap.Error(err))
+			rollbackTx(logger, tx)
+			return nil, nil, ErrDatabaseOperation
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
+	}
+
+	return &deleted, orphaned, nil
+}
+
+// HideMessage скрывает сообщение из истории одного пользователя
+func (r *messageRepo) HideMessage(ctx context.Context, messageID, userID uuid.UUID) error {
+	query := `
+		INSERT INTO message_hidden (message_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := r.db.ExecContext(ctx, query, messageID, userID); err != nil {
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+// DeleteMessages превращает пачку сообщений чата в «надгробия», как
+// DeleteMessage, за одну транзакцию. Если authorID задан, все сообщения
+// должны принадлежать ему. Удаляется всё или ничего. Возвращает пути файлов,
+// на которые больше не ссылается ни одно сообщение.
+func (r *messageRepo) DeleteMessages(ctx context.Context, chatID uuid.UUID, messageIDs []uuid.UUID, authorID *uuid.UUID) ([]string, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	ids := make([]string, len(messageIDs))
+	for i, id := range messageIDs {
+		ids[i] = id.String()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT user_id
+		FROM message
+		WHERE chat_id = $1 AND id = ANY($2::uuid[]) AND deleted_at IS NULL
+		FOR UPDATE
+	`, chatID, pq.Array(ids))
+	if err != nil {
+		logger.Error("lock messages failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, ErrDatabaseOperation
+	}
+	found := 0
+	foreign := false
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			rows.Close()
+			rollbackTx(logger, tx)
+			return nil, ErrDatabaseScan
+		}
+		found++
+		if authorID != nil && userID != *authorID {
+			foreign = true
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		rollbackTx(logger, tx)
+		return nil, ErrDatabaseOperation
+	}
+	if found != len(ids) {
+		rollbackTx(logger, tx)
+		return nil, ErrMessagesNotFound
+	}
+	if foreign {
+		rollbackTx(logger, tx)
+		return nil, ErrNotMessageAuthor
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE message
+		SET body = '', sticker_path = NULL, message_type = 'default', is_redacted = false,
+			link_preview_url = NULL, deleted_at = CURRENT_TIMESTAMP
+		WHERE id = ANY($1::uuid[])
+	`, pq.Array(ids)); err != nil {
+		logger.Error("tombstone messages failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, ErrDatabaseOperation
+	}
+
+	// Пересланные копии делят файлы с оригиналом, поэтому убирать можно
+	// только файлы, на которые не ссылаются другие сообщения
+	fileRows, err := tx.QueryContext(ctx, `
+		WITH removed AS (
+			DELETE FROM message_payload WHERE message_id = ANY($1::uuid[])
+			RETURNING file_path
+		)
+		SELECT DISTINCT r.file_path
+		FROM removed r
+		WHERE NOT EXISTS (
+			SELECT 1 FROM message_payload other
+			WHERE other.file_path = r.file_path
+			  AND other.message_id <> ALL($1::uuid[])
+		)
+	`, pq.Array(ids))
+	if err != nil {
+		logger.Error("delete payloads failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, ErrDatabaseOperation
+	}
+	var orphaned []string
+	for fileRows.Next() {
+		var path string
+		if err := fileRows.Scan(&path); err != nil {
+			fileRows.Close()
+			rollbackTx(logger, tx)
+			return nil, ErrDatabaseScan
+		}
+		orphaned = append(orphaned, path)
+	}
+	fileRows.Close()
+	if err := fileRows.Err(); err != nil {
+		rollbackTx(logger, tx)
+		return nil, ErrDatabaseOperation
+	}
+
+	for _, query := range []string{
+		`DELETE FROM message_reaction WHERE message_id = ANY($1::uuid[])`,
+		`DELETE FROM message_version WHERE message_id = ANY($1::uuid[])`,
+		`DELETE FROM message_entity WHERE message_id = ANY($1::uuid[])`,
+		`DELETE FROM poll WHERE message_id = ANY($1::uuid[])`,
+		`DELETE FROM pinned_message WHERE message_id = ANY($1::uuid[])`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, pq.Array(ids)); err != nil {
+			logger.Error("clear tombstone content failed", zap.Error(err))
+			rollbackTx(logger, tx)
+			return nil, ErrDatabaseOperation
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+	return orphaned, nil
+}
+
+// HideMessages скрывает пачку сообщений чата у одного пользователя.
+// Если хоть одного сообщения нет в чате, не скрывается ничего.
+func (r *messageRepo) HideMessages(ctx context.Context, chatID, userID uuid.UUID, messageIDs []uuid.UUID) error {
+	ids := make([]string, len(messageIDs))
+	for i, id := range messageIDs {
+		ids[i] = id.String()
+	}
+
+	var found int
+	err := r.db.QueryRowContext(ctx, `
+		WITH target AS (
+			SELECT id FROM message WHERE chat_id = $1 AND id = ANY($3::uuid[])
+		), hidden AS (
+			INSERT INTO message_hidden (message_id, user_id)
+			SELECT id, $2 FROM target
+			WHERE (SELECT COUNT(*) FROM target) = $4
+			ON CONFLICT DO NOTHING
+		)
+		SELECT COUNT(*) FROM target
+	`, chatID, userID, pq.Array(ids), len(ids)).Scan(&found)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("hide messages failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+	if found != len(ids) {
+		return ErrMessagesNotFound
+	}
+	return nil
+}
+
+// ClearHistory удаляет все сообщения чата одним запросом. Возвращает время
+// самого позднего удалённого сообщения (nil, если чат был пуст) и пути
+// файлов, на которые больше не ссылается ни одно сообщение.
+//
+// В отличие от DeleteMessage и DeleteMessages надгробия не остаются: они
+// нужны, чтобы ответы и лента не теряли удалённое звено, а после очистки
+// в чате не остаётся ни ответов, ни ленты. Так же удаляет сообщения и TTL.
+// Копии в «Избранном» и пересланные копии живут отдельно и не страдают,
+// закладка лишь теряет ссылку на исходное сообщение (source_message_id = NULL).
+func (r *messageRepo) ClearHistory(ctx context.Context, chatID uuid.UUID) (*time.Time, []string, error) {
+	var before sql.NullTime
+	var orphaned []string
+	err := r.db.QueryRowContext(ctx, `
+		WITH deleted AS (
+			DELETE FROM message WHERE chat_id = $1
+			RETURNING id, sent_at
+		), orphaned AS (
+			SELECT DISTINCT p.file_path
+			FROM message_payload p
+			JOIN deleted d ON d.id = p.message_id
+			WHERE NOT EXISTS (
+				SELECT 1 FROM message_payload other
+				WHERE other.file_path = p.file_path
+				  AND other.message_id NOT IN (SELECT id FROM deleted)
+			)
+		)
+		SELECT (SELECT MAX(sent_at) FROM deleted),
+			COALESCE((SELECT array_agg(file_path) FROM orphaned), '{}')
+	`, chatID).Scan(&before, pq.Array(&orphaned))
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("clear history failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
+	}
+	if !before.Valid {
+		return nil, nil, nil
+	}
+	return &before.Time, orphaned, nil
+}
+
+// HideHistory скрывает у пользователя все сообщения чата одним запросом.
+// Возвращает время самого позднего скрытого сообщения (nil, если скрывать нечего).
+func (r *messageRepo) HideHistory(ctx context.Context, chatID, userID uuid.UUID) (*time.Time, error) {
+	var before sql.NullTime
+	err := r.db.QueryRowContext(ctx, `
+		WITH target AS (
+			SELECT id, sent_at FROM message WHERE chat_id = $1
+		), hidden AS (
+			INSERT INTO message_hidden (message_id, user_id)
+			SELECT id, $2 FROM target
+			ON CONFLICT DO NOTHING
+		)
+		SELECT MAX(sent_at) FROM target
+	`, chatID, userID).Scan(&before)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("hide history failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+	if !before.Valid {
+		return nil, nil
+	}
+	return &before.Time, nil
+}
+
+// SetReaction ставит реакцию пользователя на сообщение, заменяя предыдущую
+func (r *messageRepo) SetReaction(ctx context.Context, messageID, userID uuid.UUID, reaction string) error {
+	query := `
+		INSERT INTO message_reaction (message_id, user_id, reaction)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (message_id, user_id)
+		DO UPDATE SET reaction = EXCLUDED.reaction, reacted_at = CURRENT_TIMESTAMP
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, messageID, userID, reaction); err != nil {
+		log.Println("set reaction:", err)
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+func (r *messageRepo) DeleteReaction(ctx context.Context, messageID, userID uuid.UUID) error {
+	query := `
+		DELETE FROM message_reaction
+		WHERE message_id = $1 AND user_id = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, messageID, userID); err != nil {
+		log.Println("delete reaction:", err)
+		return ErrDatabaseOperation
+	}
+	return nil
+}
+
+// MarkRead отмечает прочитанными все чужие сообщения чата вплоть до messageID
+// и возвращает число новых отметок
+func (r *messageRepo) MarkRead(ctx context.Context, chatID, userID, messageID uuid.UUID) (int64, error) {
+	query := `
+		INSERT INTO message_view (message_id, user_id)
+		SELECT m.id, $2
+		FROM message m
+		JOIN message ref ON ref.id = $3
+		WHERE m.chat_id = $1
+		  AND m.user_id <> $2
+		  AND m.sent_at <= ref.sent_at
+		ON CONFLICT (message_id, user_id) DO NOTHING
+	`
+
+	res, err := r.db.ExecContext(ctx, query, chatID, userID, messageID)
+	if err != nil {
+		log.Println("mark read:", err)
+		return 0, ErrDatabaseOperation
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrDatabaseOperation
+	}
+	return affected, nil
+}
+
+// ForwardMessages копирует сообщения вместе с вложениями и стикерами в другой чат.
+// Копии сохраняют порядок исходных сообщений, а в forwarded_* остаётся
+// первоисточник, даже если пересылается уже пересланное сообщение.
+func (r *messageRepo) ForwardMessages(ctx context.Context, userID, fromChatID, toChatID uuid.UUID, messageIDs []uuid.UUID) ([]model.Message, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	newIDs, err := copyMessages(ctx, tx, userID, fromChatID, toChatID, messageIDs)
+	if err != nil {
+		rollbackTx(logger, tx)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
+	}
+
+	forwarded := make([]model.Message, 0, len(newIDs))
+	for _, id := range newIDs {
+		msg, err := r.GetMessage(ctx, id)
+		if err != nil {
+			return nil, err
 		}
+		forwarded = append(forwarded, *msg)
+	}
+	return forwarded, nil
+}
 
-		if avatarPath.Valid {
-			msg.AvatarPath = &avatarPath.String
+// copyMessages копирует сообщения чата внутри транзакции и возвращает ID копий
+// в исходном порядке. Откат транзакции при ошибке — на вызывающем.
+func copyMessages(ctx context.Context, tx *sql.Tx, userID, fromChatID, toChatID uuid.UUID, messageIDs []uuid.UUID) ([]uuid.UUID, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	ids := make([]string, 0, len(messageIDs))
+	for _, id := range messageIDs {
+		ids = append(ids, id.String())
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM message
+		WHERE chat_id = $1 AND id = ANY($2::uuid[]) AND deleted_at IS NULL
+		ORDER BY sent_at, id
+	`, fromChatID, pq.Array(ids))
+	if err != nil {
+		return nil, ErrDatabaseOperation
+	}
+	var sourceIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, ErrDatabaseScan
 		}
+		sourceIDs = append(sourceIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, ErrDatabaseOperation
+	}
+	if len(sourceIDs) != len(messageIDs) {
+		return nil, ErrMessagesNotFound
+	}
 
-		if stickerPath.Valid {
-			msg.Sticker = stickerPath.String
+	// Внутри транзакции CURRENT_TIMESTAMP одинаков, поэтому копии разводим
+	// на микросекунды, чтобы сохранить исходный порядок
+	forwardInsertQuery := `
+		INSERT INTO message (
+			user_id, chat_id, body, message_type, sticker_path, link_preview_url,
+			forwarded_from_user_id, forwarded_from_chat_id, forwarded_sent_at, sent_at, expires_at
+		)
+		SELECT $2, $3, body, message_type, sticker_path, link_preview_url,
+			CASE WHEN forwarded_sent_at IS NULL THEN user_id ELSE forwarded_from_user_id END,
+			CASE WHEN forwarded_sent_at IS NULL THEN chat_id ELSE forwarded_from_chat_id END,
+			COALESCE(forwarded_sent_at, sent_at),
+			CURRENT_TIMESTAMP - $4 * INTERVAL '1 microsecond',
+			CURRENT_TIMESTAMP + make_interval(secs => (SELECT message_ttl FROM chat WHERE id = $3))
+		FROM message
+		WHERE id = $1
+		RETURNING id
+	`
+	copyPayloads := `
+		INSERT INTO message_payload (message_id, file_path, file_name, content_type, file_size, duration_ms, waveform)
+		SELECT $1, file_path, file_name, content_type, file_size, duration_ms, waveform
+		FROM message_payload
+		WHERE message_id = $2
+	`
+	// Разметка переносится вместе с текстом. Упоминания остаются простым
+	// текстом: в целевом чате они не должны слать уведомления
+	copyEntities := `
+		INSERT INTO message_entity (message_id, type, entity_offset, entity_length, user_id, url)
+		SELECT $1, type, entity_offset, entity_length, user_id, url
+		FROM message_entity
+		WHERE message_id = $2 AND type <> 'mention'
+	`
+	// Опрос пересылается с теми же вариантами, но голосование в копии начинается заново
+	copyPoll := `
+		WITH p AS (
+			INSERT INTO poll (message_id, question, multiple_choice, anonymous, closes_at)
+			SELECT $1, question, multiple_choice, anonymous, closes_at
+			FROM poll
+			WHERE message_id = $2
+			RETURNING message_id
+		)
+		INSERT INTO poll_option (message_id, position, text)
+		SELECT p.message_id, po.position, po.text
+		FROM p JOIN poll_option po ON po.message_id = $2
+	`
+
+	newIDs := make([]uuid.UUID, 0, len(sourceIDs))
+	for i, srcID := range sourceIDs {
+		var newID uuid.UUID
+		offset := len(sourceIDs) - 1 - i
+		if err := tx.QueryRowContext(ctx, forwardInsertQuery, srcID, userID, toChatID, offset).Scan(&newID); err != nil {
+			logger.Error("forward message insert failed", zap.Error(err))
+			return nil, ErrDatabaseOperation
+		}
+		if _, err := tx.ExecContext(ctx, copyPayloads, newID, srcID); err != nil {
+			logger.Error("forward payload copy failed", zap.Error(err))
+			return nil, ErrDatabaseOperation
+		}
+		if _, err := tx.ExecContext(ctx, copyEntities, newID, srcID); err != nil {
+			logger.Error("forward entity copy failed", zap.Error(err))
+			return nil, ErrDatabaseOperation
 		}
+		if _, err := tx.ExecContext(ctx, copyPoll, newID, srcID); err != nil {
+			logger.Error("forward poll copy failed", zap.Error(err))
+			return nil, ErrDatabaseOperation
+		}
+		newIDs = append(newIDs, newID)
+	}
+
+	return newIDs, nil
+}
+
+const scheduledSelect = `
+		SELECT id, chat_id, user_id, body, sticker_path, parent_message_id, payloads, entities, send_at, ttl, created_at
+		FROM scheduled_message`
+
+func scanScheduledMessage(row rowScanner) (model.ScheduledMessage, error) {
+	var msg model.ScheduledMessage
+	var stickerPath sql.NullString
+	var parentID uuid.NullUUID
+	var payloads, entities []byte
+	var ttl sql.NullInt32
+
+	err := row.Scan(&msg.ID, &msg.ChatID, &msg.UserID, &msg.Body, &stickerPath, &parentID, &payloads, &entities, &msg.SendAt, &ttl, &msg.CreatedAt)
+	if err != nil {
+		return msg, err
+	}
+	if stickerPath.Valid {
+		msg.Sticker = stickerPath.String
+	}
+	if parentID.Valid {
+		msg.ParentMessageID = &parentID.UUID
+	}
+	if ttl.Valid {
+		seconds := int(ttl.Int32)
+		msg.TTL = &seconds
+	}
 
-		if msg.MessageType == MessageWithPayloadType {
-			payloadQuery := `
-				SELECT file_path, file_name, content_type, file_size
-				FROM public.message_payload
-				WHERE message_id = $1
-			`
-			payloadRows, err := r.db.QueryContext(ctx, payloadQuery, msg.ID)
-			if err != nil {
-				log.Println("get payloads:", err)
-				return nil, ErrDatabaseOperation
-			}
+	if err := json.Unmarshal(entities, &msg.Entities); err != nil {
+		return msg, err
+	}
 
-			for payloadRows.Next() {
-				var path, filename, contentType string
-				var size int64
-				err := payloadRows.Scan(&path, &filename, &contentType, &size)
-				if err != nil {
-					payloadRows.Close()
-					log.Printf("scan payload error: %v", err)
-					return nil, err
-				}
-				payload := model.Payload{
-					URL:         path,
-					Filename:    filename,
-					Size:        size,
-					ContentType: contentType,
-				}
-				if contentType == filePayloadType {
-					msg.FilesDTO = append(msg.FilesDTO, payload)
-				} else if contentType == photoPayloadType {
-					msg.PhotosDTO = append(msg.PhotosDTO, payload)
-				}
-			}
-			payloadRows.Close()
+	var all []model.Payload
+	if err := json.Unmarshal(payloads, &all); err != nil {
+		return msg, err
+	}
+	for _, p := range all {
+		switch p.ContentType {
+		case filePayloadType:
+			msg.FilesDTO = append(msg.FilesDTO, p)
+		case photoPayloadType:
+			msg.PhotosDTO = append(msg.PhotosDTO, p)
+		case voicePayloadType:
+			msg.VoiceDTO = &p
 		}
+	}
+	return msg, nil
+}
 
-		messages = append(messages, msg)
+// CreateScheduledMessage откладывает отправку сообщения до sendAt.
+// Вложения к этому моменту уже загружены, в записи хранятся только ссылки на них.
+func (r *messageRepo) CreateScheduledMessage(ctx context.Context, message *model.Message, sendAt time.Time) (*model.ScheduledMessage, error) {
+	payloads, err := json.Marshal(message.Payloads())
+	if err != nil {
+		return nil, ErrDatabaseOperation
 	}
-	if err := rows.Err(); err != nil {
-		log.Println("rows error:", err)
+	entities, err := json.Marshal(append([]model.MessageEntity{}, message.Entities...))
+	if err != nil {
 		return nil, ErrDatabaseOperation
 	}
 
-	return messages, nil
-}
+	var sticker *string
+	if message.Sticker != "" {
+		sticker = &message.Sticker
+	}
 
-func (r *messageRepo) GetMessagesBefore(ctx context.Context, chatID, beforeMessageID uuid.UUID) ([]model.Message, error) {
 	query := `
-		WITH ref_message AS (
-			SELECT sent_at, id FROM message WHERE id = $2
-		)
-		SELECT 
-			m.id,
-			m.parent_message_id,
-			m.chat_id,
-			m.user_id,
-			m.body,
-			m.sent_at,
-			m.is_redacted,
-			u.avatar_path,
-			u.username,
-			m.message_type,
-			m.sticker_path
-		FROM message m
-		JOIN public.user u ON m.user_id = u.id
-		JOIN ref_message r ON TRUE
-		WHERE m.chat_id = $1
-		  AND (
-			m.sent_at < r.sent_at
-			OR (m.sent_at = r.sent_at AND m.id::text < r.id::text)
-		  )
-		ORDER BY m.sent_at DESC, m.id DESC
-		LIMIT $3
+		INSERT INTO scheduled_message (chat_id, user_id, body, sticker_path, parent_message_id, payloads, entities, send_at, ttl)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
 	`
+	var id uuid.UUID
+	err = r.db.QueryRowContext(ctx, query,
+		message.ChatID, message.UserID, message.Body, sticker, message.ParentMessageID, payloads, entities, sendAt, message.TTL,
+	).Scan(&id)
+	if err != nil {
+		log.Println("insert scheduled message:", err)
+		return nil, ErrDatabaseOperation
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, chatID, beforeMessageID, 5)
+	return r.GetScheduledMessage(ctx, id)
+}
+
+// GetScheduledMessages возвращает ожидающие отправки сообщения пользователя в чате
+func (r *messageRepo) GetScheduledMessages(ctx context.Context, chatID, userID uuid.UUID) ([]model.ScheduledMessage, error) {
+	rows, err := r.db.QueryContext(ctx, scheduledSelect+`
+		WHERE chat_id = $1 AND user_id = $2
+		ORDER BY send_at, id
+	`, chatID, userID)
 	if err != nil {
+		log.Println("get scheduled messages:", err)
 		return nil, ErrDatabaseOperation
 	}
 	defer rows.Close()
 
-	var messages []model.Message
-
+	messages := []model.ScheduledMessage{}
 	for rows.Next() {
-		var msg model.Message
-		var parentMsgID sql.NullString
-		var avatarPath sql.NullString
-		var stickerPath sql.NullString
-
-		if err := rows.Scan(
-			&msg.ID,
-			&parentMsgID,
-			&msg.ChatID,
-			&msg.UserID,
-			&msg.Body,
-			&msg.SentAt,
-			&msg.IsRedacted,
-			&avatarPath,
-			&msg.Username,
-			&msg.MessageType,
-			&stickerPath,
-		); err != nil {
+		msg, err := scanScheduledMessage(rows)
+		if err != nil {
+			log.Println("scan scheduled message:", err)
 			return nil, ErrDatabaseScan
 		}
-
-		if parentMsgID.Valid {
-			id, err := uuid.Parse(parentMsgID.String)
-			if err == nil {
-				msg.ParentMessageID = &id
-			}
-		}
-
-		if avatarPath.Valid {
-			msg.AvatarPath = &avatarPath.String
-		}
-
-		if stickerPath.Valid {
-			msg.Sticker = stickerPath.String
-		}
-
-		if msg.MessageType == MessageWithPayloadType {
-			payloadQuery := `
-				SELECT file_path, file_name, content_type, file_size
-				FROM public.message_payload
-				WHERE message_id = $1
-			`
-			payloadRows, err := r.db.QueryContext(ctx, payloadQuery, msg.ID)
-			if err != nil {
-				log.Println("get payloads:", err)
-				return nil, ErrDatabaseOperation
-			}
-
-			for payloadRows.Next() {
-				var path, filename, contentType string
-				var size int64
-				err := payloadRows.Scan(&path, &filename, &contentType, &size)
-				if err != nil {
-					payloadRows.Close()
-					log.Printf("scan payload error: %v", err)
-					return nil, err
-				}
-				payload := model.Payload{
-					URL:         path,
-					Filename:    filename,
-					Size:        size,
-					ContentType: contentType,
-				}
-				if contentType == filePayloadType {
-					msg.FilesDTO = append(msg.FilesDTO, payload)
-				} else if contentType == photoPayloadType {
-					msg.PhotosDTO = append(msg.PhotosDTO, payload)
-				}
-			}
-			payloadRows.Close()
-		}
-
 		messages = append(messages, msg)
 	}
 	if err := rows.Err(); err != nil {
-		log.Println("rows error:", err)
 		return nil, ErrDatabaseOperation
 	}
-
 	return messages, nil
 }
 
-func (r *messageRepo) GetMessagesAfter(ctx context.Context, chatID, afterMessageID uuid.UUID) ([]model.Message, error) {
+func (r *messageRepo) GetScheduledMessage(ctx context.Context, id uuid.UUID) (*model.ScheduledMessage, error) {
+	msg, err := scanScheduledMessage(r.db.QueryRowContext(ctx, scheduledSelect+`
+		WHERE id = $1
+	`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrScheduledMessageNotFound
+		}
+		log.Println("get scheduled message:", err)
+		return nil, ErrDatabaseOperation
+	}
+	return &msg, nil
+}
+
+// UpdateScheduledMessage меняет текст и/или время отправки; разметка
+// заменяется только вместе с текстом
+func (r *messageRepo) UpdateScheduledMessage(ctx context.Context, id uuid.UUID, body *string, entities []model.MessageEntity, sendAt *time.Time) (*model.ScheduledMessage, error) {
+	var entitiesJSON []byte
+	if body != nil {
+		data, err := json.Marshal(append([]model.MessageEntity{}, entities...))
+		if err != nil {
+			return nil, ErrUpdateFailed
+		}
+		entitiesJSON = data
+	}
+
 	query := `
-		WITH ref_message AS (
-			SELECT sent_at, id FROM message WHERE id = $2
-		)
-		SELECT 
-			m.id,
-			m.parent_message_id,
-			m.chat_id,
-			m.user_id,
-			m.body,
-			m.sent_at,
-			m.is_redacted,
-			u.avatar_path,
-			u.username,
-			m.message_type,
-			m.sticker_path
-		FROM message m
-		JOIN public.user u ON m.user_id = u.id
-		JOIN ref_message r ON TRUE
-		WHERE m.chat_id = $1
-		  AND (
-			m.sent_at > r.sent_at
-			OR (m.sent_at = r.sent_at AND m.id::text > r.id::text)
-		  )
-		ORDER BY m.sent_at ASC, m.id ASC
-		LIMIT $3
+		UPDATE scheduled_message
+		SET body = COALESCE($2, body), entities = COALESCE($4::jsonb, entities), send_at = COALESCE($3, send_at)
+		WHERE id = $1
 	`
+	res, err := r.db.ExecContext(ctx, query, id, body, sendAt, entitiesJSON)
+	if err != nil {
+		log.Println("update scheduled message:", err)
+		return nil, ErrUpdateFailed
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return nil, ErrScheduledMessageNotFound
+	}
+	return r.GetScheduledMessage(ctx, id)
+}
 
-	rows, err := r.db.QueryContext(ctx, query, chatID, afterMessageID, 5)
+func (r *messageRepo) DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM scheduled_message WHERE id = $1`, id)
 	if err != nil {
-		return nil, ErrDatabaseOperation
+		log.Println("delete scheduled message:", err)
+		return ErrDatabaseOperation
 	}
-	defer rows.Close()
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrScheduledMessageNotFound
+	}
+	return nil
+}
 
-	var messages []model.Message
+// DispatchDueMessages переносит наступившие отложенные сообщения в чат.
+// SKIP LOCKED позволяет нескольким репликам разбирать очередь без дублей.
+// Если автор больше не состоит в чате, сообщение отбрасывается, а пути его
+// вложений возвращаются вторым значением — их нужно убрать из хранилища.
+func (r *messageRepo) DispatchDueMessages(ctx context.Context, batchSize int) ([]model.Message, []string, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
 
-	for rows.Next() {
-		var msg model.Message
-		var parentMsgID sql.NullString
-		var avatarPath sql.NullString
-		var stickerPath sql.NullString
-
-		if err := rows.Scan(
-			&msg.ID,
-			&parentMsgID,
-			&msg.ChatID,
-			&msg.UserID,
-			&msg.Body,
-			&msg.SentAt,
-			&msg.IsRedacted,
-			&avatarPath,
-			&msg.Username,
-			&msg.MessageType,
-			&stickerPath,
-		); err != nil {
-			return nil, ErrDatabaseScan
-		}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
+	}
 
-		if parentMsgID.Valid {
-			id, err := uuid.Parse(parentMsgID.String)
-			if err == nil {
-				msg.ParentMessageID = &id
-			}
-		}
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, chat_id, user_id, body, sticker_path, parent_message_id, payloads, entities, send_at, ttl, created_at
+		FROM scheduled_message
+		WHERE send_at <= CURRENT_TIMESTAMP
+		ORDER BY send_at, id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, batchSize)
+	if err != nil {
+		logger.Error("select due scheduled messages failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, nil, ErrDatabaseOperation
+	}
 
-		if avatarPath.Valid {
-			msg.AvatarPath = &avatarPath.String
+	var due []model.ScheduledMessage
+	for rows.Next() {
+		msg, err := scanScheduledMessage(rows)
+		if err != nil {
+			rows.Close()
+			rollbackTx(logger, tx)
+			return nil, nil, ErrDatabaseScan
 		}
+		due = append(due, msg)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		rollbackTx(logger, tx)
+		return nil, nil, ErrDatabaseOperation
+	}
 
-		if stickerPath.Valid {
-			msg.Sticker = stickerPath.String
+	var (
+		sentIDs []uuid.UUID
+		dropped []string
+	)
+	for _, scheduled := range due {
+		var isMember bool
+		err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM user_chat WHERE chat_id = $1 AND user_id = $2)`,
+			scheduled.ChatID, scheduled.UserID,
+		).Scan(&isMember)
+		if err != nil {
+			rollbackTx(logger, tx)
+			return nil, nil, ErrDatabaseOperation
 		}
 
-		if msg.MessageType == MessageWithPayloadType {
-			payloadQuery := `
-				SELECT file_path, file_name, content_type, file_size
-				FROM public.message_payload
-				WHERE message_id = $1
-			`
-			payloadRows, err := r.db.QueryContext(ctx, payloadQuery, msg.ID)
-			if err != nil {
-				log.Println("get payloads:", err)
-				return nil, ErrDatabaseOperation
+		if isMember {
+			msg := model.Message{
+				ChatID:          scheduled.ChatID,
+				UserID:          scheduled.UserID,
+				Body:            scheduled.Body,
+				Sticker:         scheduled.Sticker,
+				ParentMessageID: scheduled.ParentMessageID,
+				FilesDTO:        scheduled.FilesDTO,
+				PhotosDTO:       scheduled.PhotosDTO,
+				VoiceDTO:        scheduled.VoiceDTO,
+				Entities:        scheduled.Entities,
+				TTL:             scheduled.TTL,
 			}
-
-			for payloadRows.Next() {
-				var path, filename, contentType string
-				var size int64
-				err := payloadRows.Scan(&path, &filename, &contentType, &size)
-				if err != nil {
-					payloadRows.Close()
-					log.Printf("scan payload error: %v", err)
-					return nil, err
-				}
-				payload := model.Payload{
-					URL:         path,
-					Filename:    filename,
-					Size:        size,
-					ContentType: contentType,
-				}
-				if contentType == filePayloadType {
-					msg.FilesDTO = append(msg.FilesDTO, payload)
-				} else if contentType == photoPayloadType {
-					msg.PhotosDTO = append(msg.PhotosDTO, payload)
-				}
+			if err := insertMessage(ctx, tx, &msg); err != nil {
+				rollbackTx(logger, tx)
+				return nil, nil, err
+			}
+			sentIDs = append(sentIDs, msg.ID)
+		} else {
+			logger.Warn("scheduled message dropped: author left chat", zap.String("id", scheduled.ID.String()))
+			for _, p := range scheduled.Payloads() {
+				dropped = append(dropped, p.URL)
 			}
-			payloadRows.Close()
 		}
 
-		messages = append(messages, msg)
+		if _, err := tx.ExecContext(ctx, `DELETE FROM scheduled_message WHERE id = $1`, scheduled.ID); err != nil {
+			rollbackTx(logger, tx)
+			return nil, nil, ErrDatabaseOperation
+		}
 	}
-	if err := rows.Err(); err != nil {
-		log.Println("rows error:", err)
-		return nil, ErrDatabaseOperation
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
 	}
 
-	return messages, nil
+	sent := make([]model.Message, 0, len(sentIDs))
+	for _, id := range sentIDs {
+		msg, err := r.GetMessage(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		sent = append(sent, *msg)
+	}
+	return sent, dropped, nil
 }
 
-func (r *messageRepo) GetMessage(ctx context.Context, id uuid.UUID) (*model.Message, error) {
-	query := `
-		SELECT 
-			m.id,
-			m.parent_message_id,
-			m.chat_id,
-			m.user_id,
-			m.body,
-			m.sent_at,
-			m.is_redacted,
-			u.avatar_path,
-			u.username,
-			m.message_type,
-			m.sticker_path
-		FROM message m
-		JOIN public.user u ON m.user_id = u.id
-		WHERE m.id = $1
-	`
-
-	var msg model.Message
-	var parentMsgID sql.NullString
-	var avatarPath sql.NullString
-	var messageType string
-	var stickerPath sql.NullString
+// DeleteExpiredMessages удаляет пачку сообщений с истёкшим сроком жизни.
+// Возвращает удалённые сообщения (ID и чат) и пути файлов, на которые больше
+// не ссылается ни одно сообщение — их можно убирать из хранилища.
+// SKIP LOCKED позволяет нескольким репликам чистить таблицу параллельно.
+func (r *messageRepo) DeleteExpiredMessages(ctx context.Context, batchSize int) ([]model.Message, []string, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&msg.ID,
-		&parentMsgID,
-		&msg.ChatID,
-		&msg.UserID,
-		&msg.Body,
-		&msg.SentAt,
-		&msg.IsRedacted,
-		&avatarPath,
-		&msg.Username,
-		&messageType,
-		&stickerPath,
-	)
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Println("get message:", err)
-		return nil, ErrDatabaseOperation
+		logger.Error("BeginTx failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
 	}
 
-	msg.MessageType = messageType
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, chat_id
+		FROM message
+		WHERE expires_at <= CURRENT_TIMESTAMP
+		ORDER BY expires_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, batchSize)
+	if err != nil {
+		logger.Error("select expired messages failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, nil, ErrDatabaseOperation
+	}
 
-	if parentMsgID.Valid {
-		parentID, err := uuid.Parse(parentMsgID.String)
-		if err == nil {
-			msg.ParentMessageID = &parentID
+	var expired []model.Message
+	var ids []string
+	for rows.Next() {
+		var msg model.Message
+		if err := rows.Scan(&msg.ID, &msg.ChatID); err != nil {
+			rows.Close()
+			rollbackTx(logger, tx)
+			return nil, nil, ErrDatabaseScan
 		}
+		expired = append(expired, msg)
+		ids = append(ids, msg.ID.String())
 	}
-
-	if avatarPath.Valid {
-		msg.AvatarPath = &avatarPath.String
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		rollbackTx(logger, tx)
+		return nil, nil, ErrDatabaseOperation
+	}
+	if len(expired) == 0 {
+		rollbackTx(logger, tx)
+		return nil, nil, nil
 	}
 
-	if stickerPath.Valid {
-		msg.Sticker = stickerPath.String
+	// Файлы, оставшиеся без ссылок после удаления (пересланные копии делят файлы с оригиналом)
+	fileRows, err := tx.QueryContext(ctx, `
+		WITH deleted AS (
+			DELETE FROM message WHERE id = ANY($1::uuid[])
+			RETURNING id
+		)
+		SELECT DISTINCT p.file_path
+		FROM message_payload p
+		JOIN deleted d ON d.id = p.message_id
+		WHERE NOT EXISTS (
+			SELECT 1 FROM message_payload other
+			WHERE other.file_path = p.file_path
+			  AND other.message_id <> ALL($1::uuid[])
+		)
+	`, pq.Array(ids))
+	if err != nil {
+		logger.Error("delete expired messages failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return nil, nil, ErrDatabaseOperation
 	}
 
-	if messageType == MessageWithPayloadType {
-		payloadQuery := `
-			SELECT file_path, file_name, content_type, file_size
-			FROM message_payload
-			WHERE message_id = $1
-		`
-		rows, err := r.db.QueryContext(ctx, payloadQuery, msg.ID)
-		if err != nil {
-			log.Println("get payload:", err)
-			return nil, ErrDatabaseOperation
+	var orphaned []string
+	for fileRows.Next() {
+		var path string
+		if err := fileRows.Scan(&path); err != nil {
+			fileRows.Close()
+			rollbackTx(logger, tx)
+			return nil, nil, ErrDatabaseScan
 		}
-		defer rows.Close()
+		orphaned = append(orphaned, path)
+	}
+	fileRows.Close()
+	if err := fileRows.Err(); err != nil {
+		rollbackTx(logger, tx)
+		return nil, nil, ErrDatabaseOperation
+	}
 
-		for rows.Next() {
-			var path, filename, contentType string
-			var size int64
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return nil, nil, ErrDatabaseOperation
+	}
 
-			err = rows.Scan(&path, &filename, &contentType, &size)
-			if err != nil {
-				log.Printf("scan payload error: %v", err)
-				return nil, err
-			}
+	return expired, orphaned, nil
+}
 
-			payload := model.Payload{
-				URL:         path,
-				Filename:    filename,
-				Size:        size,
-				ContentType: contentType,
-			}
+// GetNextUnreadMention возвращает самое раннее непрочитанное сообщение чата,
+// в котором упомянут пользователь. С afterMessageID поиск идёт после него,
+// чтобы клиент мог перебирать упоминания по очереди.
+func (r *messageRepo) GetNextUnreadMention(ctx context.Context, chatID, userID uuid.UUID, afterMessageID *uuid.UUID) (*model.Message, error) {
+	query := messageSelect + `
+		WHERE m.chat_id = $1
+		  AND m.user_id <> $2
+		  AND m.deleted_at IS NULL
+		  AND EXISTS (
+			SELECT 1 FROM message_entity me
+			WHERE me.message_id = m.id AND me.type = 'mention' AND me.user_id = $2
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM message_view mv
+			WHERE mv.message_id = m.id AND mv.user_id = $2
+		  )
+		  AND ($3::uuid IS NULL OR (m.sent_at, m.id) > (
+			SELECT sent_at, id FROM message WHERE id = $3
+		  ))` + notHiddenFor("$2") + `
+		ORDER BY m.sent_at, m.id
+		LIMIT 1`
 
-			switch contentType {
-			case filePayloadType:
-				msg.FilesDTO = append(msg.FilesDTO, payload)
-			case photoPayloadType:
-				msg.PhotosDTO = append(msg.PhotosDTO, payload)
-			}
+	msg, err := scanMessage(r.db.QueryRowContext(ctx, query, chatID, userID, afterMessageID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMessagesNotFound
 		}
+		utils.GetLoggerFromCtx(ctx).Error("get next mention failed", zap.Error(err))
+		return nil, ErrDatabaseOperation
 	}
 
+	messages := []model.Message{msg}
+	if err := r.loadPayloads(ctx, messages); err != nil {
+		return nil, err
+	}
+	msg = messages[0]
 	return &msg, nil
 }
 
-func (r *messageRepo) CreateMessage(ctx context.Context, message *model.Message) (*model.Message, error) {
-	messageType := defaultMessageType
-	if message.Sticker != "" {
-		messageType = stickerMessageType
-	} else if len(message.Files) > 0 || len(message.Photos) > 0 {
-		messageType = MessageWithPayloadType
-	}
-
+// AttachLinkPreview сохраняет превью в общую таблицу по URL и привязывает
+// его к сообщению. Если сообщение успели отредактировать (edit_count
+// изменился) или удалить, превью не привязывается и возвращается
+// ErrMessagesNotFound — оно относится к уже неактуальному тексту.
+func (r *messageRepo) AttachLinkPreview(ctx context.Context, messageID uuid.UUID, editCount int, preview model.LinkPreview) error {
 	query := `
-		INSERT INTO message (user_id, chat_id, body, message_type, sticker_path)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id
+		WITH saved AS (
+			INSERT INTO link_preview (url, title, description, image_url, site_name)
+			VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''))
+			ON CONFLICT (url) DO UPDATE
+			SET title = EXCLUDED.title, description = EXCLUDED.description,
+				image_url = EXCLUDED.image_url, site_name = EXCLUDED.site_name,
+				fetched_at = CURRENT_TIMESTAMP
+			RETURNING url
+		)
+		UPDATE message
+		SET link_preview_url = (SELECT url FROM saved)
+		WHERE id = $6 AND edit_count = $7 AND deleted_at IS NULL
 	`
 
-	log.Println("CreateMessage chatID:", message.ChatID)
-
-	err := r.db.QueryRowContext(ctx, query,
-		message.UserID,
-		message.ChatID,
-		message.Body,
-		messageType,
-		message.Sticker,
-	).Scan(&message.ID)
+	res, err := r.db.ExecContext(ctx, query,
+		preview.URL, preview.Title, preview.Description, preview.ImageURL, preview.SiteName,
+		messageID, editCount,
+	)
 	if err != nil {
-		log.Println("insert message:", err)
-		return nil, ErrDatabaseOperation
+		utils.GetLoggerFromCtx(ctx).Error("attach link preview failed", zap.Error(err))
+		return ErrDatabaseOperation
 	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return ErrMessagesNotFound
+	}
+	return nil
+}
 
-	if messageType == MessageWithPayloadType {
-		for _, file := range message.FilesDTO {
-			id := uuid.New()
-			_, err = r.db.ExecContext(ctx, `
-				INSERT INTO message_payload (id, message_id, file_path, file_name, content_type, file_size)
-				VALUES ($1, $2, $3, $4, $5, $6)
-			`, id, message.ID, file.URL, file.Filename, file.ContentType, file.Size)
-			if err != nil {
-				log.Println("insert file payload:", err)
-				return nil, ErrDatabaseOperation
-			}
-		}
+// VotePoll заменяет голос пользователя в опросе выбранными вариантами.
+// Закрытый к моменту записи опрос голос не принимает: возвращается ErrPollClosed.
+func (r *messageRepo) VotePoll(ctx context.Context, messageID, userID uuid.UUID, positions []int) error {
+	logger := utils.GetLoggerFromCtx(ctx)
 
-		for _, photo := range message.PhotosDTO {
-			id := uuid.New()
-			_, err = r.db.ExecContext(ctx, `
-				INSERT INTO message_payload (id, message_id, file_path, file_name, content_type, file_size)
-				VALUES ($1, $2, $3, $4, $5, $6)
-			`, id, message.ID, photo.URL, photo.Filename, photo.ContentType, photo.Size)
-			if err != nil {
-				log.Println("insert photo payload:", err)
-				return nil, ErrDatabaseOperation
-			}
-		}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return ErrDatabaseOperation
 	}
 
-	messageOut, err := r.GetMessage(ctx, message.ID)
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM poll_vote WHERE message_id = $1 AND user_id = $2
+	`, messageID, userID); err != nil {
+		logger.Error("clear poll vote failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return ErrDatabaseOperation
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO poll_vote (message_id, position, user_id)
+		SELECT $1, o.position, $2
+		FROM unnest($3::int[]) AS o(position)
+		WHERE EXISTS (
+			SELECT 1 FROM poll
+			WHERE message_id = $1 AND (closes_at IS NULL OR closes_at > CURRENT_TIMESTAMP)
+		)
+	`, messageID, userID, pq.Array(positions))
 	if err != nil {
-		log.Println("get message:", err)
-		return nil, err
+		logger.Error("insert poll vote failed", zap.Error(err))
+		rollbackTx(logger, tx)
+		return ErrDatabaseOperation
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		rollbackTx(logger, tx)
+		return ErrPollClosed
 	}
 
-	return messageOut, nil
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return ErrDatabaseOperation
+	}
+	return nil
 }
 
-func (r *messageRepo) UpdateMessage(ctx context.Context, messageID uuid.UUID, newBody string) (*model.Message, error) {
-	query := `
-		UPDATE message
-		SET body = $1, is_redacted = true
-		WHERE id = $2
-		RETURNING id
-	`
-
-	var updatedID uuid.UUID
-	err := r.db.QueryRowContext(ctx, query, newBody, messageID).Scan(&updatedID)
+func (r *messageRepo) RetractPollVote(ctx context.Context, messageID, userID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM poll_vote
+		WHERE message_id = $1 AND user_id = $2
+			AND EXISTS (
+				SELECT 1 FROM poll
+				WHERE message_id = $1 AND (closes_at IS NULL OR closes_at > CURRENT_TIMESTAMP)
+			)
+	`, messageID, userID)
 	if err != nil {
-		return nil, ErrUpdateFailed
+		return ErrDatabaseOperation
 	}
-
-	return r.GetMessage(ctx, updatedID)
+	return nil
 }
 
-func (r *messageRepo) DeleteMessage(ctx context.Context, messageID uuid.UUID) (*model.Message, error) {
-	query := `
-		DELETE FROM message
-		WHERE id = $1
-		RETURNING id
-	`
-
-	var deletedID uuid.UUID
-	err := r.db.QueryRowContext(ctx, query, messageID).Scan(&deletedID)
+// GetPollVotes возвращает позиции вариантов, за которые проголосовал пользователь
+func (r *messageRepo) GetPollVotes(ctx context.Context, messageID, userID uuid.UUID) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT position FROM poll_vote
+		WHERE message_id = $1 AND user_id = $2
+		ORDER BY position
+	`, messageID, userID)
 	if err != nil {
 		return nil, ErrDatabaseOperation
 	}
+	defer rows.Close()
 
-	return &model.Message{ID: deletedID}, nil
+	var positions []int
+	for rows.Next() {
+		var pos int
+		if err := rows.Scan(&pos); err != nil {
+			return nil, ErrDatabaseScan
+		}
+		positions = append(positions, pos)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, ErrDatabaseOperation
+	}
+	return positions, nil
 }
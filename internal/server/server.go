@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 	"os"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/usecase"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/linkpreview"
 	middleware "github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/middleware"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
 	generatedAuth "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/auth_service/proto"
@@ -82,20 +84,51 @@ func (s *Server) Run(address string) error {
 	chatRepo := repository.NewChatRepo(s.dbConn)
 	contactRepo := repository.NewContactRepo(s.dbConn)
 	messageRepo := repository.NewMessageRepo(s.dbConn)
+	draftRepo := repository.NewDraftRepo(s.dbConn)
+	bookmarkRepo := repository.NewBookmarkRepo(s.dbConn)
+	exportRepo := repository.NewExportRepo(s.dbConn)
+	importRepo := repository.NewImportRepo(s.dbConn)
 
 	// Usecase
 	filesUsecase := usecase.NewFilesUsecase(filesRepo)
-	messageUsecase := usecase.NewMessageUsecase(messageRepo, filesUsecase, chatRepo, s.nc)
+	linkPreviewUsecase := usecase.NewLinkPreviewUsecase(messageRepo, linkpreview.NewFetcher(linkpreview.Config{
+		Timeout:     config.LinkPreview.Timeout,
+		MaxBodySize: config.LinkPreview.MaxBodySize,
+		Allow:       config.LinkPreview.Allow,
+		Deny:        config.LinkPreview.Deny,
+		CacheTTL:    config.LinkPreview.CacheTTL,
+	}), s.nc, config.LinkPreview.Workers, 2*config.LinkPreview.Timeout)
+	draftUsecase := usecase.NewDraftUsecase(draftRepo, chatRepo, messageRepo, s.nc)
+	messageUsecase := usecase.NewMessageUsecase(messageRepo, filesUsecase, chatRepo, linkPreviewUsecase, draftUsecase, s.nc)
+	bookmarkUsecase := usecase.NewBookmarkUsecase(bookmarkRepo, chatRepo, s.nc)
+	exportUsecase := usecase.NewExportUsecase(exportRepo, chatRepo)
+	importUsecase := usecase.NewImportUsecase(importRepo, filesRepo, chatRepo, s.nc, usecase.ImportLimits{
+		MaxFileSize:   config.Import.MaxFileSize,
+		MaxResultSize: config.Import.MaxResultSize,
+		MaxTotalSize:  config.Import.MaxTotalSize,
+		MaxFiles:      config.Import.MaxFiles,
+	})
 	chatUsecase := usecase.NewChatUsecase(chatRepo, userRepo, messageRepo, s.nc)
 	userUsecase := usecase.NewUserUsecase(userRepo)
 	contactUsecase := usecase.NewContactUsecase(contactRepo)
 
+	// Фоновая отправка отложенных сообщений, удаление истёкших и выгрузка чатов
+	dispatcherCtx, stopDispatcher := context.WithCancel(utils.WithLogger(context.Background(), utils.Logger))
+	defer stopDispatcher()
+	go usecase.NewScheduledDispatcher(messageRepo, filesUsecase, linkPreviewUsecase, s.nc, 5*time.Second).Run(dispatcherCtx)
+	go usecase.NewExpiredMessagesSweeper(messageRepo, filesUsecase, s.nc, 10*time.Second).Run(dispatcherCtx)
+	go usecase.NewChatExporter(exportRepo, messageRepo, chatRepo, filesUsecase, s.nc, 5*time.Second).Run(dispatcherCtx)
+
 	// Controllers
 	httpDelivery.NewFilesController(apiRouter, sessionClient, filesUsecase)
 	httpDelivery.NewAuthController(apiRouter, authClient, sessionClient)
 	httpDelivery.NewChatController(apiRouter, chatUsecase, sessionClient)
 	httpDelivery.NewUserController(apiRouter, userUsecase, sessionClient)
 	httpDelivery.NewMessageController(apiRouter, messageUsecase, sessionClient)
+	httpDelivery.NewDraftController(apiRouter, draftUsecase, sessionClient)
+	httpDelivery.NewBookmarkController(apiRouter, bookmarkUsecase, sessionClient)
+	httpDelivery.NewExportController(apiRouter, exportUsecase, sessionClient)
+	httpDelivery.NewImportController(apiRouter, importUsecase, sessionClient)
 	httpDelivery.NewContactController(apiRouter, contactUsecase, sessionClient)
 	httpDelivery.NewSearchController(apiRouter, searchClient, sessionClient)
 
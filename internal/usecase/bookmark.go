@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+type IBookmarkUsecase interface {
+	GetSavedChat(ctx context.Context, userID uuid.UUID) (*model.Chat, error)
+	BookmarkMessage(ctx context.Context, userID, chatID, messageID uuid.UUID, input *model.BookmarkInput) (*model.Bookmark, error)
+	UpdateBookmarkTags(ctx context.Context, userID, messageID uuid.UUID, input *model.BookmarkInput) error
+	SearchBookmarks(ctx context.Context, userID uuid.UUID, search *model.BookmarkSearch) ([]model.Bookmark, error)
+}
+
+// BookmarkUsecase ведёт «Избранное»: личный чат пользователя с самим собой,
+// куда можно сохранить сообщение из любого чата и пометить его тегами
+type BookmarkUsecase struct {
+	bookmarkRepo repository.IBookmarkRepo
+	chatRepo     repository.IChatRepo
+	nc           *nats.Conn
+}
+
+func NewBookmarkUsecase(bookmarkRepo repository.IBookmarkRepo, chatRepo repository.IChatRepo, nc *nats.Conn) IBookmarkUsecase {
+	return &BookmarkUsecase{bookmarkRepo: bookmarkRepo, chatRepo: chatRepo, nc: nc}
+}
+
+func (uc *BookmarkUsecase) GetSavedChat(ctx context.Context, userID uuid.UUID) (*model.Chat, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("GetSavedChat start", zap.String("userID", userID.String()))
+
+	savedID, err := uc.chatRepo.GetOrCreateSavedChat(ctx, userID)
+	if err != nil {
+		logger.Error("GetOrCreateSavedChat failed", zap.Error(err))
+		return nil, err
+	}
+	return uc.chatRepo.GetChatByID(ctx, savedID)
+}
+
+// BookmarkMessage сохраняет сообщение в «Избранное» с тегами. Читать каналы
+// можно без членства, поэтому сохранять из них тоже разрешено всем.
+func (uc *BookmarkUsecase) BookmarkMessage(ctx context.Context, userID, chatID, messageID uuid.UUID, input *model.BookmarkInput) (*model.Bookmark, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("BookmarkMessage start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()), zap.String("messageID", messageID.String()))
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	chat, err := uc.chatRepo.GetChatByID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if chat.Type != string(model.ChatTypeChannel) {
+		role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+		if err != nil {
+			return nil, err
+		}
+		if !model.UserRoleInChat(role).IsMember() {
+			logger.Warn("Access denied при попытке сохранить сообщение")
+			return nil, ErrPermissionDenied
+		}
+	}
+
+	savedID, err := uc.chatRepo.GetOrCreateSavedChat(ctx, userID)
+	if err != nil {
+		logger.Error("GetOrCreateSavedChat failed", zap.Error(err))
+		return nil, err
+	}
+
+	bookmark, err := uc.bookmarkRepo.CreateBookmark(ctx, userID, savedID, chatID, messageID, input.Tags)
+	if err != nil {
+		logger.Error("CreateBookmark failed", zap.Error(err))
+		return nil, err
+	}
+
+	// Копия в «Избранном» — новое сообщение, его должны увидеть другие устройства.
+	// Закладка уже сохранена: при ошибке повтор запроса вернул бы ErrBookmarkExists.
+	if chatID != savedID {
+		e := model.MessageEvent{Action: utils.NewMessage, Message: bookmark.Message}
+		data, _ := json.Marshal(e)
+		subj := fmt.Sprintf("chat.%s.messages", savedID.String())
+		if err := uc.nc.Publish(subj, data); err != nil {
+			logger.Warn("NATS publish bookmark failed", zap.Error(err))
+		}
+	}
+
+	metrics.IncBusinessOp("bookmark_message")
+	return bookmark, nil
+}
+
+func (uc *BookmarkUsecase) UpdateBookmarkTags(ctx context.Context, userID, messageID uuid.UUID, input *model.BookmarkInput) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("UpdateBookmarkTags start", zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.bookmarkRepo.UpdateBookmarkTags(ctx, userID, messageID, input.Tags); err != nil {
+		logger.Error("UpdateBookmarkTags failed", zap.Error(err))
+		return err
+	}
+
+	metrics.IncBusinessOp("update_bookmark_tags")
+	return nil
+}
+
+func (uc *BookmarkUsecase) SearchBookmarks(ctx context.Context, userID uuid.UUID, search *model.BookmarkSearch) ([]model.Bookmark, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("SearchBookmarks start", zap.String("userID", userID.String()))
+
+	if err := search.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return nil, err
+	}
+
+	bookmarks, err := uc.bookmarkRepo.SearchBookmarks(ctx, userID, *search)
+	if err != nil {
+		logger.Error("SearchBookmarks failed", zap.Error(err))
+		return nil, err
+	}
+
+	metrics.IncBusinessOp("search_bookmarks")
+	return bookmarks, nil
+}
@@ -33,6 +33,9 @@ type IChatUsecase interface {
 	SubscribeToChannel(ctx context.Context, userID uuid.UUID, chatID uuid.UUID) error
 	DeleteUserFromChat(ctx context.Context, userID uuid.UUID, usernamesDelete []string, chatID uuid.UUID) (*model.DeletedUsersFromChat, error)
 	LeaveChat(ctx context.Context, userID, chatID uuid.UUID) error
+	PinMessage(ctx context.Context, userID, chatID, messageID uuid.UUID) error
+	UnpinMessage(ctx context.Context, userID, chatID, messageID uuid.UUID) error
+	SetMessageTTL(ctx context.Context, userID, chatID uuid.UUID, ttl *int) error
 }
 
 func NewChatUsecase(chatRepo repository.IChatRepo, userRepo repository.IUserRepo, messageRepo repository.IMessageRepo, nc *nats.Conn) IChatUsecase {
@@ -90,17 +93,24 @@ func (uc *ChatUsecase) GetChatInfo(ctx context.Context, userID, chatID uuid.UUID
 		uc.decorateDialogInfo(chat, userID, users)
 	}
 
-	messages, err := uc.messageRepo.GetMessages(ctx, chatID)
+	page, err := uc.messageRepo.GetMessagePage(ctx, chatID, userID, model.HistoryQuery{Limit: model.DefaultHistoryLimit})
 	if err != nil {
 		logger.Error("GetChatInfo: failed to get messages", zap.Error(err))
 		return nil, err
 	}
 
+	pins, err := uc.chatRepo.GetPinnedMessages(ctx, chatID)
+	if err != nil {
+		logger.Error("GetChatInfo: failed to get pinned messages", zap.Error(err))
+		return nil, err
+	}
+
 	metrics.IncBusinessOp("get_Chat")
 	return &model.ChatInfo{
 		Role:     role,
 		Users:    users,
-		Messages: messages,
+		Messages: page.Messages,
+		Pins:     pins,
 	}, nil
 }
 
@@ -140,6 +150,8 @@ func (uc *ChatUsecase) GetChat(ctx context.Context, userID, chatID uuid.UUID) (*
 		Title:             chat.Title,
 		CountUsers:        len(users),
 		SendNotifications: sendNotifications,
+		MessageTTL:        chat.MessageTTL,
+		IsSaved:           chat.IsSaved,
 	}, nil
 }
 
@@ -287,6 +299,15 @@ func (uc *ChatUsecase) AddUsersIntoChat(ctx context.Context, userID uuid.UUID, u
 
 	added, notAdded := uc.modifyMembers(ctx, chatID, usernames, true)
 
+	if len(added) > 0 {
+		data, _ := json.Marshal(model.ChatEvent{Action: utils.AddUsers, Chat: model.Chat{ID: chatID}})
+		subject := fmt.Sprintf("chat.%s.events", chatID.String())
+		if err := uc.nc.Publish(subject, data); err != nil {
+			logger.Error("failed to publish chat event", zap.String("subject", subject), zap.Error(err))
+			return nil, fmt.Errorf("%w: %v", ErrChatPublishFailed, err)
+		}
+	}
+
 	metrics.IncBusinessOp("add_user_into_chat")
 	return &model.AddedUsersIntoChat{AddedUsers: added, NotAddedUsers: notAdded}, nil
 }
@@ -302,6 +323,11 @@ func (uc *ChatUsecase) SubscribeToChannel(ctx context.Context, userID uuid.UUID,
 	if err := uc.chatRepo.AddUserToChatByID(ctx, userID, string(model.RoleMember), chatID); err != nil {
 		return err
 	}
+
+	data, _ := json.Marshal(model.ChatEvent{Action: utils.AddUsers, Chat: model.Chat{ID: chatID}})
+	if err := uc.nc.Publish(fmt.Sprintf("chat.%s.events", chatID.String()), data); err != nil {
+		return fmt.Errorf("%w: %v", ErrChatPublishFailed, err)
+	}
 	return nil
 }
 
@@ -318,6 +344,13 @@ func (uc *ChatUsecase) DeleteUserFromChat(ctx context.Context, userID uuid.UUID,
 	}
 	deleted, _ := uc.modifyMembers(ctx, chatID, usernames, false)
 
+	if len(deleted) > 0 {
+		data, _ := json.Marshal(model.ChatEvent{Action: utils.RemoveUsers, Chat: model.Chat{ID: chatID}})
+		if err := uc.nc.Publish(fmt.Sprintf("chat.%s.events", chatID.String()), data); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrChatPublishFailed, err)
+		}
+	}
+
 	metrics.IncBusinessOp("delete_user_from_chat")
 	return &model.DeletedUsersFromChat{DeletedUsers: deleted}, nil
 }
@@ -404,6 +437,132 @@ func (uc *ChatUsecase) decorateDialogInfo(chat *model.Chat, me uuid.UUID, users
 	}
 }
 
+func (uc *ChatUsecase) PinMessage(ctx context.Context, userID, chatID, messageID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("PinMessage", zap.String("chatID", chatID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureCanPin(ctx, userID, chatID, messageID); err != nil {
+		logger.Warn("PinMessage: access denied", zap.Error(err))
+		return err
+	}
+
+	if err := uc.chatRepo.PinMessage(ctx, chatID, messageID, userID); err != nil {
+		logger.Error("PinMessage failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.publishPins(ctx, chatID, utils.PinMessage); err != nil {
+		return err
+	}
+
+	metrics.IncBusinessOp("pin_message")
+	return nil
+}
+
+func (uc *ChatUsecase) UnpinMessage(ctx context.Context, userID, chatID, messageID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("UnpinMessage", zap.String("chatID", chatID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureCanPin(ctx, userID, chatID, messageID); err != nil {
+		logger.Warn("UnpinMessage: access denied", zap.Error(err))
+		return err
+	}
+
+	if err := uc.chatRepo.UnpinMessage(ctx, chatID, messageID); err != nil {
+		logger.Error("UnpinMessage failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.publishPins(ctx, chatID, utils.UnpinMessage); err != nil {
+		return err
+	}
+
+	metrics.IncBusinessOp("unpin_message")
+	return nil
+}
+
+// SetMessageTTL задаёт время жизни новых сообщений чата
+func (uc *ChatUsecase) SetMessageTTL(ctx context.Context, userID, chatID uuid.UUID, ttl *int) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("SetMessageTTL", zap.String("chatID", chatID.String()))
+
+	if ttl != nil {
+		if err := model.ValidateTTL(*ttl); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.ensureCanManage(ctx, userID, chatID); err != nil {
+		logger.Warn("SetMessageTTL: access denied", zap.Error(err))
+		return err
+	}
+
+	if err := uc.chatRepo.SetMessageTTL(ctx, chatID, ttl); err != nil {
+		logger.Error("SetMessageTTL failed", zap.Error(err))
+		return err
+	}
+
+	data, _ := json.Marshal(model.ChatEvent{Action: utils.UpdateChat, Chat: model.Chat{ID: chatID, MessageTTL: ttl}})
+	subject := fmt.Sprintf("chat.%s.events", chatID.String())
+	if err := uc.nc.Publish(subject, data); err != nil {
+		logger.Error("failed to publish chat event", zap.String("subject", subject), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrChatPublishFailed, err)
+	}
+
+	metrics.IncBusinessOp("set_message_ttl")
+	return nil
+}
+
+// ensureCanManage проверяет право менять настройки чата: в диалоге — любой
+// из участников, в группе и канале — только владелец
+func (uc *ChatUsecase) ensureCanManage(ctx context.Context, userID, chatID uuid.UUID) error {
+	chat, err := uc.chatRepo.GetChatByID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	if model.ChatType(chat.Type) == model.ChatTypeDialog {
+		return uc.ensureMember(ctx, userID, chatID)
+	}
+	return uc.ensureOwner(ctx, userID, chatID)
+}
+
+// ensureCanPin проверяет право закреплять сообщения (те же правила, что
+// и для настроек чата) и что сообщение принадлежит чату
+func (uc *ChatUsecase) ensureCanPin(ctx context.Context, userID, chatID, messageID uuid.UUID) error {
+	if err := uc.ensureCanManage(ctx, userID, chatID); err != nil {
+		return err
+	}
+
+	message, err := uc.messageRepo.GetMessage(ctx, messageID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+	if message.ChatID != chatID {
+		return ErrMessageNotFound
+	}
+	return nil
+}
+
+// publishPins рассылает участникам актуальный список закреплённых сообщений
+func (uc *ChatUsecase) publishPins(ctx context.Context, chatID uuid.UUID, action string) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	pins, err := uc.chatRepo.GetPinnedMessages(ctx, chatID)
+	if err != nil {
+		logger.Error("GetPinnedMessages failed", zap.Error(err))
+		return err
+	}
+
+	data, _ := json.Marshal(model.ChatEvent{Action: action, Chat: model.Chat{ID: chatID, Pins: pins}})
+	subject := fmt.Sprintf("chat.%s.events", chatID.String())
+	if err := uc.nc.Publish(subject, data); err != nil {
+		logger.Error("failed to publish chat event", zap.String("subject", subject), zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrChatPublishFailed, err)
+	}
+	return nil
+}
+
 func (uc *ChatUsecase) ensureMember(ctx context.Context, userID, chatID uuid.UUID) error {
 	role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
 	if err != nil {
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const scheduledBatchSize = 100
+
+// ScheduledDispatcher периодически отправляет наступившие отложенные сообщения.
+// Очередь хранится в БД, поэтому после перезапуска просроченные сообщения
+// уходят на первом же проходе.
+type ScheduledDispatcher struct {
+	messageRepo  repository.IMessageRepo
+	filesUsecase IFilesUsecase
+	linkPreviews ILinkPreviewUsecase
+	nc           *nats.Conn
+	interval     time.Duration
+}
+
+func NewScheduledDispatcher(messageRepo repository.IMessageRepo, filesUsecase IFilesUsecase, linkPreviews ILinkPreviewUsecase, nc *nats.Conn, interval time.Duration) *ScheduledDispatcher {
+	return &ScheduledDispatcher{messageRepo: messageRepo, filesUsecase: filesUsecase, linkPreviews: linkPreviews, nc: nc, interval: interval}
+}
+
+// Run блокируется до отмены контекста
+func (d *ScheduledDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *ScheduledDispatcher) dispatch(ctx context.Context) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	for {
+		sent, dropped, err := d.messageRepo.DispatchDueMessages(ctx, scheduledBatchSize)
+		if err != nil {
+			logger.Error("DispatchDueMessages failed", zap.Error(err))
+			return
+		}
+
+		// Отброшенные сообщения уже удалены из очереди, их вложения никто не увидит
+		if len(dropped) > 0 {
+			if err := d.filesUsecase.PurgeFiles(ctx, dropped); err != nil {
+				logger.Warn("Failed to purge dropped scheduled files", zap.Error(err))
+			}
+		}
+
+		for _, msg := range sent {
+			data, _ := json.Marshal(model.MessageEvent{Action: utils.NewMessage, Message: msg})
+			subj := fmt.Sprintf("chat.%s.messages", msg.ChatID.String())
+			if err := d.nc.Publish(subj, data); err != nil {
+				logger.Error("NATS publish failed", zap.String("messageID", msg.ID.String()), zap.Error(err))
+				continue
+			}
+			publishMentions(d.nc, logger, msg, model.MentionedUsers(msg.Entities))
+			d.linkPreviews.Enqueue(ctx, msg)
+			metrics.IncBusinessOp("send_scheduled_message")
+		}
+
+		if len(sent) < scheduledBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+type IDraftUsecase interface {
+	GetDraft(ctx context.Context, userID, chatID uuid.UUID) (*model.Draft, error)
+	SaveDraft(ctx context.Context, userID, chatID uuid.UUID, input *model.DraftInput) (*model.Draft, error)
+	DeleteDraft(ctx context.Context, userID, chatID uuid.UUID) error
+	// ClearAfterSend убирает черновик, когда пользователь отправил сообщение в чат
+	ClearAfterSend(ctx context.Context, userID, chatID uuid.UUID)
+}
+
+// DraftUsecase хранит неотправленные сообщения на сервере: каждое изменение
+// рассылается событием draftUpdated на все устройства пользователя.
+type DraftUsecase struct {
+	draftRepo   repository.IDraftRepo
+	chatRepo    repository.IChatRepo
+	messageRepo repository.IMessageRepo
+	nc          *nats.Conn
+}
+
+func NewDraftUsecase(draftRepo repository.IDraftRepo, chatRepo repository.IChatRepo, messageRepo repository.IMessageRepo, nc *nats.Conn) IDraftUsecase {
+	return &DraftUsecase{draftRepo: draftRepo, chatRepo: chatRepo, messageRepo: messageRepo, nc: nc}
+}
+
+func (uc *DraftUsecase) GetDraft(ctx context.Context, userID, chatID uuid.UUID) (*model.Draft, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("GetDraft start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке получить черновик", zap.Error(err))
+		return nil, err
+	}
+
+	return uc.draftRepo.GetDraft(ctx, userID, chatID)
+}
+
+// SaveDraft сохраняет черновик; пустой текст без ответа равносилен удалению
+func (uc *DraftUsecase) SaveDraft(ctx context.Context, userID, chatID uuid.UUID, input *model.DraftInput) (*model.Draft, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("SaveDraft start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке сохранить черновик", zap.Error(err))
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+	}
+
+	draft := &model.Draft{
+		ChatID:          chatID,
+		Body:            input.Body,
+		Entities:        input.Entities,
+		ParentMessageID: input.ParentMessageID,
+	}
+	if draft.IsEmpty() {
+		if _, err := uc.draftRepo.DeleteDraft(ctx, userID, chatID); err != nil {
+			logger.Error("DeleteDraft failed", zap.Error(err))
+			return nil, err
+		}
+		draft.Entities = nil
+		draft.UpdatedAt = time.Now()
+		uc.publish(ctx, userID, *draft)
+		return draft, nil
+	}
+
+	if draft.ParentMessageID != nil {
+		parent, err := uc.messageRepo.GetMessage(ctx, *draft.ParentMessageID)
+		if err != nil || parent.ChatID != chatID || parent.DeletedAt != nil {
+			logger.Warn("Родительское сообщение черновика не найдено в чате", zap.Error(err))
+			return nil, ErrInvalidParentMessage
+		}
+	}
+
+	saved, err := uc.draftRepo.SaveDraft(ctx, userID, draft)
+	if err != nil {
+		logger.Error("SaveDraft failed", zap.Error(err))
+		return nil, err
+	}
+	uc.publish(ctx, userID, *saved)
+
+	metrics.IncBusinessOp("save_draft")
+	return saved, nil
+}
+
+func (uc *DraftUsecase) DeleteDraft(ctx context.Context, userID, chatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("DeleteDraft start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке удалить черновик", zap.Error(err))
+		return err
+	}
+
+	deleted, err := uc.draftRepo.DeleteDraft(ctx, userID, chatID)
+	if err != nil {
+		logger.Error("DeleteDraft failed", zap.Error(err))
+		return err
+	}
+	if deleted {
+		uc.publish(ctx, userID, model.Draft{ChatID: chatID, UpdatedAt: time.Now()})
+	}
+	return nil
+}
+
+func (uc *DraftUsecase) ClearAfterSend(ctx context.Context, userID, chatID uuid.UUID) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	// Сообщение уже отправлено, поэтому сбой очистки не превращаем в ошибку отправки
+	deleted, err := uc.draftRepo.DeleteDraft(ctx, userID, chatID)
+	if err != nil {
+		logger.Warn("Не удалось очистить черновик после отправки", zap.Error(err))
+		return
+	}
+	if deleted {
+		uc.publish(ctx, userID, model.Draft{ChatID: chatID, UpdatedAt: time.Now()})
+	}
+}
+
+// publish отправляет черновик во все сессии пользователя. Черновик уже
+// сохранён, поэтому ошибка публикации только логируется.
+func (uc *DraftUsecase) publish(ctx context.Context, userID uuid.UUID, draft model.Draft) {
+	e := model.DraftEvent{Action: utils.DraftUpdated, Draft: draft}
+	data, _ := json.Marshal(model.UserEvent{TypeOfEvent: utils.DraftUpdated, Event: e})
+	subj := fmt.Sprintf("user.%s.events", userID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		utils.GetLoggerFromCtx(ctx).Warn("NATS publish draft failed", zap.Error(err))
+	}
+}
+
+func (uc *DraftUsecase) ensureMember(ctx context.Context, userID, chatID uuid.UUID) error {
+	role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !model.UserRoleInChat(role).IsMember() {
+		return ErrPermissionDenied
+	}
+	return nil
+}
@@ -29,6 +29,13 @@ var (
 	ErrMessageAccessDenied     = errors.New("user is not the author of the message")
 	ErrMessageUpdateFailed     = errors.New("failed to update message")
 	ErrMessageDeleteFailed     = errors.New("failed to delete message")
+	ErrInvalidParentMessage    = errors.New("parent message not found in this chat")
+	ErrMessageEditWindowClosed = errors.New("message can no longer be edited")
+
+	ErrNotAPoll        = errors.New("message is not a poll")
+	ErrPollNotEditable = errors.New("poll messages cannot be edited")
+	ErrInvalidPollVote = errors.New("invalid poll vote")
+	ErrScheduledPoll   = errors.New("polls cannot be scheduled")
 
 	ErrMessagePublishFailed = errors.New("failed to publish message event")
 	ErrChatPublishFailed    = errors.New("failed to publish chat event")
@@ -0,0 +1,515 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const (
+	exportBatchSize = 100
+	// exportStaleAfter — сколько выгрузка может не обновляться, прежде чем
+	// её подхватит другая реплика (прошлая упала посреди работы)
+	exportStaleAfter = 10 * time.Minute
+)
+
+type IExportUsecase interface {
+	RequestExport(ctx context.Context, userID, chatID uuid.UUID) (*model.ChatExport, error)
+	GetExport(ctx context.Context, userID, exportID uuid.UUID) (*model.ChatExport, error)
+}
+
+// ExportUsecase ставит выгрузки чатов в очередь; собирает архивы ChatExporter
+type ExportUsecase struct {
+	exportRepo repository.IExportRepo
+	chatRepo   repository.IChatRepo
+}
+
+func NewExportUsecase(exportRepo repository.IExportRepo, chatRepo repository.IChatRepo) IExportUsecase {
+	return &ExportUsecase{exportRepo: exportRepo, chatRepo: chatRepo}
+}
+
+func (uc *ExportUsecase) RequestExport(ctx context.Context, userID, chatID uuid.UUID) (*model.ChatExport, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("RequestExport start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if !model.UserRoleInChat(role).IsMember() {
+		logger.Warn("Access denied при попытке выгрузить чат")
+		return nil, ErrPermissionDenied
+	}
+
+	export, err := uc.exportRepo.CreateExport(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+	metrics.IncBusinessOp("request_export")
+	return export, nil
+}
+
+func (uc *ExportUsecase) GetExport(ctx context.Context, userID, exportID uuid.UUID) (*model.ChatExport, error) {
+	return uc.exportRepo.GetExport(ctx, exportID, userID)
+}
+
+// ChatExporter забирает выгрузки из очереди и собирает ZIP-архив прямо
+// в хранилище: transcript.json, index.html, вложения, стикеры и manifest.json.
+// Архив не держится в памяти целиком — он пишется в трубу, из которой
+// читает загрузка в MinIO. Прогресс рассылается событием exportUpdated.
+type ChatExporter struct {
+	exportRepo   repository.IExportRepo
+	messageRepo  repository.IMessageRepo
+	chatRepo     repository.IChatRepo
+	filesUsecase IFilesUsecase
+	nc           *nats.Conn
+	interval     time.Duration
+}
+
+func NewChatExporter(exportRepo repository.IExportRepo, messageRepo repository.IMessageRepo, chatRepo repository.IChatRepo, filesUsecase IFilesUsecase, nc *nats.Conn, interval time.Duration) *ChatExporter {
+	return &ChatExporter{
+		exportRepo:   exportRepo,
+		messageRepo:  messageRepo,
+		chatRepo:     chatRepo,
+		filesUsecase: filesUsecase,
+		nc:           nc,
+		interval:     interval,
+	}
+}
+
+// Run блокируется до отмены контекста
+func (e *ChatExporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		for e.exportNext(ctx) {
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// exportNext обрабатывает одну выгрузку; false — очередь пуста или сервер останавливается
+func (e *ChatExporter) exportNext(ctx context.Context) bool {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	job, err := e.exportRepo.ClaimExport(ctx, exportStaleAfter)
+	if err != nil || job == nil {
+		return false
+	}
+	logger = logger.With(zap.String("exportID", job.ID.String()))
+	logger.Info("Export started", zap.String("chatID", job.ChatID.String()))
+
+	err = e.export(ctx, job)
+	if ctx.Err() != nil {
+		// Остановка сервера: выгрузку доделает следующий запуск
+		return false
+	}
+	if err != nil {
+		logger.Error("Export failed", zap.Error(err))
+		job.Status = model.ExportFailed
+		job.FileURL = ""
+		job.Error = "export failed"
+		if errors.Is(err, ErrPermissionDenied) {
+			job.Error = "access to the chat was revoked"
+		}
+	} else {
+		job.Status = model.ExportDone
+		metrics.IncBusinessOp("export_chat")
+	}
+	job.UpdateProgress()
+
+	if err := e.exportRepo.FinishExport(ctx, job); err != nil {
+		logger.Error("FinishExport failed", zap.Error(err))
+		return true
+	}
+	e.publish(ctx, job)
+	return true
+}
+
+func (e *ChatExporter) export(ctx context.Context, job *model.ChatExport) error {
+	// Пока выгрузка ждала в очереди, пользователя могли исключить из чата
+	role, err := e.chatRepo.GetUserRoleInChat(ctx, job.UserID, job.ChatID)
+	if err != nil {
+		return err
+	}
+	if !model.UserRoleInChat(role).IsMember() {
+		return ErrPermissionDenied
+	}
+
+	chat, err := e.chatRepo.GetChatByID(ctx, job.ChatID)
+	if err != nil {
+		return err
+	}
+	job.TotalMessages, err = e.exportRepo.CountVisibleMessages(ctx, job.ChatID, job.UserID)
+	if err != nil {
+		return err
+	}
+	e.reportProgress(ctx, job)
+
+	pr, pw := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := e.writeArchive(ctx, pw, job, chat)
+		pw.CloseWithError(err)
+		written <- err
+	}()
+
+	filename := fmt.Sprintf("chat_export_%s.zip", time.Now().UTC().Format("2006-01-02"))
+	url, saveErr := e.filesUsecase.SaveArchive(ctx, pr, filename, job.UserID)
+	// Если загрузка оборвалась, писатель не должен навсегда повиснуть на трубе
+	pr.CloseWithError(saveErr)
+
+	if err := <-written; err != nil {
+		if url != "" {
+			_ = e.filesUsecase.PurgeFiles(ctx, []string{url})
+		}
+		return err
+	}
+	if saveErr != nil {
+		return saveErr
+	}
+	job.FileURL = url
+	return nil
+}
+
+// reportProgress сохраняет прогресс и рассылает его; ошибки только логируются,
+// чтобы сбой учёта не ронял саму выгрузку
+func (e *ChatExporter) reportProgress(ctx context.Context, job *model.ChatExport) {
+	job.UpdateProgress()
+	if err := e.exportRepo.UpdateExportProgress(ctx, job); err != nil {
+		utils.GetLoggerFromCtx(ctx).Warn("UpdateExportProgress failed", zap.Error(err))
+	}
+	e.publish(ctx, job)
+}
+
+func (e *ChatExporter) publish(ctx context.Context, job *model.ChatExport) {
+	ev := model.ExportEvent{Action: utils.ExportUpdated, Export: *job}
+	data, _ := json.Marshal(model.UserEvent{TypeOfEvent: utils.ExportUpdated, Event: ev})
+	subj := fmt.Sprintf("user.%s.events", job.UserID.String())
+	if err := e.nc.Publish(subj, data); err != nil {
+		utils.GetLoggerFromCtx(ctx).Warn("NATS publish export failed", zap.Error(err))
+	}
+}
+
+// exportFile — файл, который нужно положить в архив после transcript.json:
+// в ZIP одновременно открыта только одна запись
+type exportFile struct {
+	url  string
+	path string
+}
+
+// exportArchive собирает архив за один проход по истории
+type exportArchive struct {
+	zw       *zip.Writer
+	html     *bufio.Writer
+	authors  map[uuid.UUID]string
+	order    []uuid.UUID
+	files    []exportFile
+	stickers map[string]string
+	manifest model.ExportManifest
+}
+
+func (e *ChatExporter) writeArchive(ctx context.Context, w io.Writer, job *model.ChatExport, chat *model.Chat) error {
+	// HTML-представление копится во временном файле: его запись в ZIP
+	// можно открыть только после transcript.json
+	htmlFile, err := os.CreateTemp("", "chat-export-*.html")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(htmlFile.Name())
+	defer htmlFile.Close()
+
+	a := &exportArchive{
+		zw:       zip.NewWriter(w),
+		html:     bufio.NewWriter(htmlFile),
+		authors:  make(map[uuid.UUID]string),
+		stickers: make(map[string]string),
+		manifest: model.ExportManifest{
+			ChatID:     chat.ID,
+			ChatTitle:  chat.Title,
+			ChatType:   chat.Type,
+			ExportedBy: job.UserID,
+			ExportedAt: time.Now().UTC(),
+		},
+	}
+
+	if err := e.writeTranscript(ctx, a, job); err != nil {
+		return err
+	}
+	if err := e.writeFiles(ctx, a, job); err != nil {
+		return err
+	}
+
+	if err := exportHTMLTemplate.ExecuteTemplate(a.html, "footer", nil); err != nil {
+		return err
+	}
+	if err := a.html.Flush(); err != nil {
+		return err
+	}
+	if _, err := htmlFile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	index, err := a.zw.Create("index.html")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(index, htmlFile); err != nil {
+		return err
+	}
+
+	manifest, err := a.zw.Create("manifest.json")
+	if err != nil {
+		return err
+	}
+	data, err := a.manifest.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if _, err := manifest.Write(data); err != nil {
+		return err
+	}
+	return a.zw.Close()
+}
+
+// writeTranscript проходит историю от старых сообщений к новым и пишет
+// transcript.json и index.html параллельно, по одной странице за раз
+func (e *ChatExporter) writeTranscript(ctx context.Context, a *exportArchive, job *model.ChatExport) error {
+	transcript, err := a.zw.Create("transcript.json")
+	if err != nil {
+		return err
+	}
+	chatInfo, _ := json.Marshal(map[string]any{"id": a.manifest.ChatID, "title": a.manifest.ChatTitle, "type": a.manifest.ChatType})
+	if _, err := fmt.Fprintf(transcript, `{"chat":%s,"messages":[`, chatInfo); err != nil {
+		return err
+	}
+	if err := exportHTMLTemplate.ExecuteTemplate(a.html, "header", a.manifest); err != nil {
+		return err
+	}
+
+	cursor := &model.MessageCursor{}
+	for {
+		page, err := e.messageRepo.GetMessagePage(ctx, job.ChatID, job.UserID, model.HistoryQuery{After: cursor, Limit: exportBatchSize})
+		if err != nil {
+			return err
+		}
+		// Страница отдаётся от новых к старым
+		for i := len(page.Messages) - 1; i >= 0; i-- {
+			msg := a.exportMessage(page.Messages[i])
+			data, err := msg.MarshalJSON()
+			if err != nil {
+				return err
+			}
+			if a.manifest.Messages > 0 {
+				data = append([]byte{','}, data...)
+			}
+			if _, err := transcript.Write(data); err != nil {
+				return err
+			}
+			if err := exportHTMLTemplate.ExecuteTemplate(a.html, "message", msg); err != nil {
+				return err
+			}
+			a.manifest.Messages++
+		}
+		if len(page.Messages) > 0 {
+			cursor = model.NewMessageCursor(page.Messages[0])
+		}
+
+		// Сообщения, пришедшие во время выгрузки, тоже попадают в архив
+		job.ProcessedMessages = a.manifest.Messages
+		job.TotalMessages = max(job.TotalMessages, job.ProcessedMessages)
+		job.TotalFiles = len(a.files)
+		e.reportProgress(ctx, job)
+
+		if !page.HasNewer {
+			break
+		}
+	}
+
+	authors := make([]model.ExportedAuthor, 0, len(a.order))
+	for _, id := range a.order {
+		authors = append(authors, model.ExportedAuthor{ID: id, Username: a.authors[id]})
+	}
+	data, err := json.Marshal(authors)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(transcript, `],"authors":%s}`, data)
+	return err
+}
+
+// exportMessage переводит сообщение в формат архива и запоминает его файлы
+func (a *exportArchive) exportMessage(m model.Message) model.ExportedMessage {
+	if _, ok := a.authors[m.UserID]; !ok {
+		a.authors[m.UserID] = m.Username
+		a.order = append(a.order, m.UserID)
+	}
+
+	out := model.ExportedMessage{
+		ID:       m.ID,
+		AuthorID: m.UserID,
+		Author:   m.Username,
+		SentAt:   m.SentAt,
+		EditedAt: m.EditedAt,
+		ReplyTo:  m.ParentMessageID,
+		Forward:  m.Forward,
+	}
+	if m.DeletedAt != nil {
+		out.Deleted = true
+		return out
+	}
+	out.Body = m.Body
+	out.Entities = m.Entities
+	out.Poll = m.Poll
+
+	if m.Sticker != "" {
+		out.Sticker = a.addSticker(m.Sticker)
+	}
+	for n, p := range m.Payloads() {
+		filePath := fmt.Sprintf("attachments/%s/%d_%s", m.ID, n+1, sanitizeExportName(p.Filename))
+		a.files = append(a.files, exportFile{url: p.URL, path: filePath})
+		out.Attachments = append(out.Attachments, model.ExportedFile{
+			Kind:        p.ContentType,
+			Name:        p.Filename,
+			Path:        filePath,
+			ContentType: p.ContentType,
+			Size:        p.Size,
+			DurationMs:  p.DurationMs,
+		})
+	}
+	return out
+}
+
+// addSticker кладёт стикер в архив один раз, сколько бы раз он ни встречался.
+// Стикеры не из хранилища остаются ссылками.
+func (a *exportArchive) addSticker(url string) string {
+	if !strings.HasPrefix(url, fileURLPrefix) {
+		return url
+	}
+	if p, ok := a.stickers[url]; ok {
+		return p
+	}
+	p := "stickers/" + path.Base(url)
+	a.stickers[url] = p
+	a.files = append(a.files, exportFile{url: url, path: p})
+	return p
+}
+
+// writeFiles копирует вложения и стикеры из хранилища. Файлы, которые удалены
+// или недоступны запросившему, не прерывают выгрузку, а попадают в manifest.
+func (e *ChatExporter) writeFiles(ctx context.Context, a *exportArchive, job *model.ChatExport) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	for i, f := range a.files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := e.copyFile(ctx, a.zw, f, job.UserID); err != nil {
+			if errors.Is(err, errArchiveWrite) {
+				return err
+			}
+			logger.Warn("Export skipped file", zap.String("url", f.url), zap.Error(err))
+			a.manifest.MissingFiles = append(a.manifest.MissingFiles, f.path)
+		} else {
+			a.manifest.Files++
+		}
+
+		job.ProcessedFiles = i + 1
+		if job.ProcessedFiles%exportBatchSize == 0 {
+			e.reportProgress(ctx, job)
+		}
+	}
+	return nil
+}
+
+var errArchiveWrite = errors.New("failed to write archive")
+
+func (e *ChatExporter) copyFile(ctx context.Context, zw *zip.Writer, f exportFile, userID uuid.UUID) error {
+	src, _, err := e.filesUsecase.OpenFile(ctx, f.url, userID)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	// Медиа уже сжаты, повторное сжатие только тратит CPU
+	dst, err := zw.CreateHeader(&zip.FileHeader{Name: f.path, Method: zip.Store, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("%w: %v", errArchiveWrite, err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		// Запись в ZIP уже начата, пропустить файл нельзя
+		return fmt.Errorf("%w: %v", errArchiveWrite, err)
+	}
+	return nil
+}
+
+// sanitizeExportName делает имя файла безопасным для распаковки
+func sanitizeExportName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || strings.ContainsRune(`<>:"|?*`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+	return name
+}
+
+var exportHTMLTemplate = template.Must(template.New("export").Parse(`
+{{define "header"}}<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.ChatTitle}}</title>
+<style>
+body { font-family: sans-serif; max-width: 800px; margin: 0 auto; padding: 16px; }
+.message { border-bottom: 1px solid #eee; padding: 8px 0; }
+.meta { color: #888; font-size: 12px; }
+.body { white-space: pre-wrap; }
+.deleted { color: #aaa; font-style: italic; }
+img { max-width: 320px; display: block; margin-top: 4px; }
+</style>
+</head>
+<body>
+<h1>{{.ChatTitle}}</h1>
+{{end}}
+
+{{define "message"}}<div class="message" id="{{.ID}}">
+<div class="meta"><b>{{.Author}}</b> {{.SentAt.Format "2006-01-02 15:04:05"}}{{if .EditedAt}} (изменено){{end}}{{if .ReplyTo}} <a href="#{{.ReplyTo}}">в ответ</a>{{end}}</div>
+{{if .Deleted}}<div class="deleted">Сообщение удалено</div>{{else}}
+{{if .Forward}}<div class="meta">Переслано</div>{{end}}
+{{if .Body}}<div class="body">{{.Body}}</div>{{end}}
+{{if .Sticker}}<img src="{{.Sticker}}" alt="sticker">{{end}}
+{{range .Attachments}}{{if eq .Kind "photo"}}<a href="{{.Path}}"><img src="{{.Path}}" alt="{{.Name}}"></a>{{else if eq .Kind "voice"}}<div><audio controls preload="none" src="{{.Path}}"></audio></div>{{else}}<div><a href="{{.Path}}">{{.Name}}</a></div>{{end}}{{end}}
+{{if .Poll}}<div class="meta">Опрос: {{.Poll.Question}}</div>{{end}}
+{{end}}</div>
+{{end}}
+
+{{define "footer"}}</body>
+</html>
+{{end}}
+`))
@@ -3,11 +3,15 @@ package usecase
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
+	"strings"
 
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/audio"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -18,12 +22,16 @@ type filesUsecase struct {
 }
 
 type IFilesUsecase interface {
-	GetFile(ctx context.Context, fileIDStr uuid.UUID, userID uuid.UUID) (*bytes.Buffer, *model.FileMetaData, error)
+	GetFile(ctx context.Context, fileIDStr uuid.UUID, userID uuid.UUID) (io.ReadSeekCloser, *model.FileMetaData, error)
 	SaveFile(ctx context.Context, file multipart.File, header *multipart.FileHeader, users []string) (model.Payload, error)
 	GetStickerPack(ctx context.Context, packID string) (model.GetStickerPackResponse, error)
 	GetStickerPacks(ctx context.Context) (model.StickerPacks, error)
 	SaveSticker(ctx context.Context, file multipart.File, header *multipart.FileHeader, name string) error
 	SavePhoto(ctx context.Context, file multipart.File, header *multipart.FileHeader, users []string) (model.Payload, error)
+	SaveVoice(ctx context.Context, file multipart.File, header *multipart.FileHeader, users []string) (model.Payload, error)
+	PurgeFiles(ctx context.Context, urls []string) error
+	OpenFile(ctx context.Context, url string, userID uuid.UUID) (io.ReadCloser, *model.FileMetaData, error)
+	SaveArchive(ctx context.Context, r io.Reader, filename string, owner uuid.UUID) (string, error)
 	// SaveAvatar(ctx context.Context, file multipart.File, header *multipart.FileHeader) (string, error)
 	// RewritePhoto(ctx context.Context, file multipart.File, header multipart.FileHeader, fileIDStr string) error
 	// DeletePhoto(ctx context.Context, fileIDStr string) error
@@ -34,8 +42,9 @@ func NewFilesUsecase(fileRepo repository.IFilesRepo) IFilesUsecase {
 	return &filesUsecase{fileRepo: fileRepo}
 }
 
-func (u *filesUsecase) GetFile(ctx context.Context, fileIDStr uuid.UUID, userID uuid.UUID) (*bytes.Buffer, *model.FileMetaData, error) {
-	return u.fileRepo.GetFile(ctx, fileIDStr, userID)
+// GetFile открывает файл потоком с поддержкой Seek, чтобы его можно было отдавать по частям
+func (u *filesUsecase) GetFile(ctx context.Context, fileIDStr uuid.UUID, userID uuid.UUID) (io.ReadSeekCloser, *model.FileMetaData, error) {
+	return u.fileRepo.OpenFile(ctx, fileIDStr, userID)
 }
 
 func (u *filesUsecase) SaveFile(ctx context.Context, file multipart.File, header *multipart.FileHeader, users []string) (model.Payload, error) {
@@ -138,6 +147,85 @@ func (u *filesUsecase) SavePhoto(ctx context.Context, file multipart.File, heade
 	return out, nil
 }
 
+// SaveVoice проверяет аудио, достаёт длительность и огибающую и сохраняет
+// файл с MIME-типом, определённым по содержимому, а не по заголовку клиента
+func (u *filesUsecase) SaveVoice(ctx context.Context, file multipart.File, header *multipart.FileHeader, users []string) (model.Payload, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	logger.Info("Starting voice save operation",
+		zap.String("filename", header.Filename),
+		zap.Int64("size", header.Size),
+		zap.Int("users_count", len(users)),
+	)
+
+	fileBuffer, err := getFileBuffer(file)
+	if err != nil {
+		logger.Error("Failed to create voice buffer",
+			zap.String("filename", header.Filename),
+			zap.Error(err),
+		)
+		return model.Payload{}, fmt.Errorf("failed to create voice buffer: %w", err)
+	}
+
+	info, err := audio.Probe(fileBuffer.Bytes())
+	if err != nil {
+		logger.Warn("Rejected voice upload", zap.String("filename", header.Filename), zap.Error(err))
+		return model.Payload{}, errors.Join(model.ErrValidation, err)
+	}
+	if info.Duration > model.MaxVoiceDuration {
+		return model.Payload{}, errors.Join(model.ErrValidation,
+			fmt.Errorf("voice is longer than %s", model.MaxVoiceDuration))
+	}
+
+	size := int64(fileBuffer.Len())
+	fileID, err := u.fileRepo.SaveFile(ctx, fileBuffer, header.Filename, info.MimeType, size, users)
+	if err != nil {
+		logger.Error("Failed to save voice in repository",
+			zap.String("filename", header.Filename),
+			zap.Error(err),
+		)
+		return model.Payload{}, fmt.Errorf("failed to save voice in repository: %w", err)
+	}
+
+	out := model.Payload{
+		URL:         addFileURLPrefix(fileID),
+		Filename:    header.Filename,
+		ContentType: "voice",
+		Size:        size,
+		DurationMs:  int(info.Duration.Milliseconds()),
+		Waveform:    info.Waveform,
+	}
+
+	logger.Info("Voice successfully saved",
+		zap.String("file_id", fileID),
+		zap.String("format", info.Format),
+		zap.Duration("duration", info.Duration),
+	)
+
+	return out, nil
+}
+
+// PurgeFiles удаляет файлы без проверки доступа (системная очистка);
+// при ошибке продолжает с остальными и возвращает первую из них
+func (u *filesUsecase) PurgeFiles(ctx context.Context, urls []string) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	var firstErr error
+	for _, url := range urls {
+		fileID := strings.TrimPrefix(url, fileURLPrefix)
+		if err := u.fileRepo.RemoveFile(ctx, fileID); err != nil {
+			logger.Error("Failed to purge file",
+				zap.String("file_id", fileID),
+				zap.Error(err),
+			)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to purge file: %w", err)
+			}
+		}
+	}
+	return firstErr
+}
+
 func (u *filesUsecase) GetStickerPack(ctx context.Context, packID string) (model.GetStickerPackResponse, error) {
 	return u.fileRepo.GetStickerPack(ctx, packID)
 }
@@ -175,6 +263,27 @@ func getFileBuffer(file multipart.File) (*bytes.Buffer, error) {
 	return buf, nil
 }
 
+const fileURLPrefix = "/files/"
+
+// OpenFile открывает файл по его URL вида /files/{id} с проверкой доступа
+func (u *filesUsecase) OpenFile(ctx context.Context, url string, userID uuid.UUID) (io.ReadCloser, *model.FileMetaData, error) {
+	fileID, err := uuid.Parse(strings.TrimPrefix(url, fileURLPrefix))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid file url %q: %w", url, err)
+	}
+	return u.fileRepo.OpenFile(ctx, fileID, userID)
+}
+
+// SaveArchive сохраняет ZIP-архив из потока; скачать его сможет только владелец
+func (u *filesUsecase) SaveArchive(ctx context.Context, r io.Reader, filename string, owner uuid.UUID) (string, error) {
+	fileID, err := u.fileRepo.SaveStream(ctx, r, filename, "application/zip", []string{owner.String()})
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("Failed to save archive", zap.String("filename", filename), zap.Error(err))
+		return "", err
+	}
+	return fileURLPrefix + fileID, nil
+}
+
 func addFileURLPrefix(fileID string) string {
-	return "/files/" + fileID
+	return fileURLPrefix + fileID
 }
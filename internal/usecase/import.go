@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/audio"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const telegramResultFile = "result.json"
+
+type IImportUsecase interface {
+	// ImportTelegram создаёт группу из выгрузки Telegram Desktop: export —
+	// папка выгрузки (с result.json и медиа) или архив с ней
+	ImportTelegram(ctx context.Context, userID uuid.UUID, export fs.FS) (*model.ImportResult, error)
+}
+
+// ImportLimits ограничивает распакованные данные одной выгрузки
+type ImportLimits struct {
+	MaxFileSize   int64 // одно вложение
+	MaxResultSize int64 // result.json
+	MaxTotalSize  int64 // все вложения вместе
+	MaxFiles      int   // число вложений
+}
+
+// ImportUsecase переносит историю чатов из других мессенджеров. Импортированные
+// сообщения принадлежат импортировавшему, а исходные авторы показываются по имени.
+type ImportUsecase struct {
+	importRepo repository.IImportRepo
+	filesRepo  repository.IFilesRepo
+	chatRepo   repository.IChatRepo
+	nc         *nats.Conn
+	limits     ImportLimits
+}
+
+// NewImportUsecase — nc может быть nil (импорт из консоли без рассылки событий)
+func NewImportUsecase(importRepo repository.IImportRepo, filesRepo repository.IFilesRepo, chatRepo repository.IChatRepo, nc *nats.Conn, limits ImportLimits) IImportUsecase {
+	return &ImportUsecase{importRepo: importRepo, filesRepo: filesRepo, chatRepo: chatRepo, nc: nc, limits: limits}
+}
+
+// importBudget — сколько вложений и байт выгрузки уже распаковано
+type importBudget struct {
+	files int
+	bytes int64
+}
+
+func (uc *ImportUsecase) ImportTelegram(ctx context.Context, userID uuid.UUID, export fs.FS) (*model.ImportResult, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("ImportTelegram start", zap.String("userID", userID.String()))
+
+	root, err := telegramExportRoot(export)
+	if err != nil {
+		return nil, err
+	}
+	data, err := uc.readResult(root)
+	if err != nil {
+		return nil, errors.Join(model.ErrValidation, fmt.Errorf("failed to read %s: %w", telegramResultFile, err))
+	}
+	var tg model.TelegramExport
+	if err := easyjson.Unmarshal(data, &tg); err != nil {
+		return nil, errors.Join(model.ErrValidation, fmt.Errorf("invalid %s: %w", telegramResultFile, err))
+	}
+	if err := tg.Validate(); err != nil {
+		return nil, err
+	}
+
+	chat := &model.ImportedChat{Title: truncateRunes(strings.TrimSpace(tg.Name), 100), OwnerID: userID}
+	result := &model.ImportResult{Title: chat.Title}
+	var (
+		uploaded []string
+		budget   importBudget
+	)
+
+	ids := make(map[int64]uuid.UUID, len(tg.Messages))
+	var lastSentAt time.Time
+	now := time.Now()
+	for i := range tg.Messages {
+		tm := &tg.Messages[i]
+		// Служебные сообщения (вступления, закрепы, звонки) не переносятся
+		if tm.Type != "message" {
+			result.Skipped++
+			continue
+		}
+		sentAt, err := tm.SentAt()
+		if err != nil {
+			logger.Warn("Skipping message with invalid date", zap.Int64("telegramID", tm.ID), zap.Error(err))
+			result.Skipped++
+			continue
+		}
+		// Сообщение из будущего нарушило бы CHECK на sent_at и сорвало весь импорт
+		if sentAt.After(now) {
+			logger.Warn("Skipping message from the future", zap.Int64("telegramID", tm.ID), zap.Time("sentAt", sentAt))
+			result.Skipped++
+			continue
+		}
+		// Время в Telegram с точностью до секунды, а история сортируется по
+		// (sent_at, id); сдвиг на микросекунды сохраняет исходный порядок
+		if !sentAt.After(lastSentAt) {
+			sentAt = lastSentAt.Add(time.Microsecond)
+		}
+
+		body, entities := tm.Content()
+		payloads, missing, err := uc.uploadAttachments(ctx, root, tm, userID, &budget)
+		for _, p := range payloads {
+			uploaded = append(uploaded, p.URL)
+		}
+		if err != nil {
+			uc.removeUploaded(ctx, uploaded)
+			return nil, err
+		}
+		result.Files += len(payloads)
+		result.MissingFiles = append(result.MissingFiles, missing...)
+		if body == "" && len(payloads) == 0 {
+			result.Skipped++
+			continue
+		}
+
+		var parent *uuid.UUID
+		if id, ok := ids[tm.ReplyToMessageID]; ok {
+			parent = &id
+		}
+		for j, part := range model.SplitImportedText(body, entities, model.MaxImportedBodyLength) {
+			msg := model.ImportedMessage{
+				ID:              uuid.New(),
+				ParentMessageID: parent,
+				Author:          tm.Author(),
+				Body:            part.Body,
+				Entities:        part.Entities,
+				SentAt:          sentAt.Add(time.Duration(j) * time.Microsecond),
+				EditedAt:        tm.EditedAt(),
+			}
+			if j == 0 {
+				msg.Payloads = payloads
+				ids[tm.ID] = msg.ID
+			}
+			chat.Messages = append(chat.Messages, msg)
+			lastSentAt = msg.SentAt
+		}
+		result.Messages++
+	}
+
+	if len(chat.Messages) == 0 {
+		return nil, errors.Join(model.ErrValidation, errors.New("export has no messages that can be imported"))
+	}
+
+	if err := uc.importRepo.ImportChat(ctx, chat); err != nil {
+		uc.removeUploaded(ctx, uploaded)
+		return nil, err
+	}
+	result.ChatID = chat.ID
+
+	uc.publishNewChat(ctx, chat.ID)
+	metrics.IncBusinessOp("import_chat")
+	logger.Info("ImportTelegram done",
+		zap.String("chatID", chat.ID.String()),
+		zap.Int("messages", result.Messages),
+		zap.Int("files", result.Files),
+		zap.Int("skipped", result.Skipped),
+	)
+	return result, nil
+}
+
+// readResult читает result.json не больше MaxResultSize. Размер из архива
+// ничего не гарантирует, поэтому чтение дополнительно ограничено LimitReader.
+func (uc *ImportUsecase) readResult(root fs.FS) ([]byte, error) {
+	f, err := root.Open(telegramResultFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > uc.limits.MaxResultSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", uc.limits.MaxResultSize)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(f, uc.limits.MaxResultSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > uc.limits.MaxResultSize {
+		return nil, fmt.Errorf("file exceeds %d bytes", uc.limits.MaxResultSize)
+	}
+	return data, nil
+}
+
+// uploadAttachments загружает файлы сообщения в хранилище. Отсутствующие
+// и слишком большие файлы не прерывают импорт, а попадают в отчёт; превышение
+// общих лимитов выгрузки прерывает его с ошибкой валидации.
+func (uc *ImportUsecase) uploadAttachments(ctx context.Context, root fs.FS, tm *model.TelegramMessage, userID uuid.UUID, budget *importBudget) ([]model.Payload, []string, error) {
+	files, notIncluded := tm.Attachments()
+	var missing []string
+	for _, note := range notIncluded {
+		missing = append(missing, fmt.Sprintf("message %d: %s", tm.ID, note))
+	}
+
+	var payloads []model.Payload
+	for _, att := range files {
+		payload, err := uc.uploadAttachment(ctx, root, att, userID, budget)
+		if errors.Is(err, model.ErrValidation) {
+			return payloads, missing, err
+		}
+		if err != nil {
+			utils.GetLoggerFromCtx(ctx).Warn("Skipping attachment",
+				zap.Int64("telegramID", tm.ID), zap.String("path", att.Path), zap.Error(err))
+			missing = append(missing, fmt.Sprintf("message %d: %s", tm.ID, att.Path))
+			continue
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, missing, nil
+}
+
+func (uc *ImportUsecase) uploadAttachment(ctx context.Context, root fs.FS, att model.TelegramAttachment, userID uuid.UUID, budget *importBudget) (model.Payload, error) {
+	f, err := root.Open(path.Clean(att.Path))
+	if err != nil {
+		return model.Payload{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return model.Payload{}, err
+	}
+	if info.IsDir() || info.Size() > uc.limits.MaxFileSize {
+		return model.Payload{}, fmt.Errorf("file is a directory or exceeds %d bytes", uc.limits.MaxFileSize)
+	}
+	if budget.files >= uc.limits.MaxFiles {
+		return model.Payload{}, errors.Join(model.ErrValidation, fmt.Errorf("export has more than %d attachments", uc.limits.MaxFiles))
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, info.Size()))
+	if _, err := io.Copy(buf, io.LimitReader(f, uc.limits.MaxFileSize)); err != nil {
+		return model.Payload{}, err
+	}
+	// Считаем фактически распакованное: размер в заголовке архива может врать
+	budget.files++
+	budget.bytes += int64(buf.Len())
+	if budget.bytes > uc.limits.MaxTotalSize {
+		return model.Payload{}, errors.Join(model.ErrValidation, fmt.Errorf("export attachments exceed %d bytes", uc.limits.MaxTotalSize))
+	}
+
+	contentType := att.MimeType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(att.Path))
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(buf.Bytes())
+	}
+
+	// Голосовое, которое не удалось разобрать, переносится обычным файлом
+	var voice *audio.Info
+	if att.IsVoice {
+		if info, err := audio.Probe(buf.Bytes()); err == nil && info.Duration <= model.MaxVoiceDuration {
+			voice, contentType = info, info.MimeType
+		}
+	}
+
+	name := truncateRunes(att.Name, 255)
+	size := int64(buf.Len())
+	fileID, err := uc.filesRepo.SaveFile(ctx, buf, name, contentType, size, []string{userID.String()})
+	if err != nil {
+		return model.Payload{}, err
+	}
+
+	// В message_payload хранится вид вложения, а не MIME-тип
+	payload := model.Payload{URL: fileURLPrefix + fileID, Filename: name, ContentType: "file", Size: size}
+	switch {
+	case att.IsPhoto:
+		payload.ContentType = "photo"
+	case voice != nil:
+		payload.ContentType = "voice"
+		payload.DurationMs = int(voice.Duration.Milliseconds())
+		payload.Waveform = voice.Waveform
+	}
+	return payload, nil
+}
+
+// removeUploaded убирает файлы неудавшегося импорта, чтобы не копить мусор
+func (uc *ImportUsecase) removeUploaded(ctx context.Context, urls []string) {
+	for _, url := range urls {
+		if err := uc.filesRepo.RemoveFile(ctx, strings.TrimPrefix(url, fileURLPrefix)); err != nil {
+			utils.GetLoggerFromCtx(ctx).Warn("Failed to remove imported file", zap.String("url", url), zap.Error(err))
+		}
+	}
+}
+
+func (uc *ImportUsecase) publishNewChat(ctx context.Context, chatID uuid.UUID) {
+	if uc.nc == nil {
+		return
+	}
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	info, err := uc.chatRepo.GetChatByID(ctx, chatID)
+	if err != nil {
+		logger.Warn("Failed to load imported chat", zap.Error(err))
+		return
+	}
+	info.CountUsers = 1
+
+	data, _ := json.Marshal(model.ChatEvent{Action: utils.NewChat, Chat: *info})
+	subject := fmt.Sprintf("chat.%s.events", chatID.String())
+	if err := uc.nc.Publish(subject, data); err != nil {
+		logger.Error("failed to publish chat event", zap.String("subject", subject), zap.Error(err))
+	}
+}
+
+// telegramExportRoot находит папку с result.json. Telegram Desktop кладёт
+// выгрузку в папку ChatExport_<дата>, и обычно архивируют её целиком.
+func telegramExportRoot(export fs.FS) (fs.FS, error) {
+	if _, err := fs.Stat(export, telegramResultFile); err == nil {
+		return export, nil
+	}
+
+	entries, err := fs.ReadDir(export, ".")
+	if err != nil {
+		return nil, errors.Join(model.ErrValidation, fmt.Errorf("failed to read export: %w", err))
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), "__MACOSX") {
+			dirs = append(dirs, e.Name())
+		}
+	}
+	if len(dirs) == 1 {
+		sub, err := fs.Sub(export, dirs[0])
+		if err == nil {
+			if _, err := fs.Stat(sub, telegramResultFile); err == nil {
+				return sub, nil
+			}
+		}
+	}
+	return nil, errors.Join(model.ErrValidation, fmt.Errorf("%s not found in export", telegramResultFile))
+}
+
+func truncateRunes(s string, limit int) string {
+	if runes := []rune(s); len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return s
+}
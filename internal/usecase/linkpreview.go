@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/linkpreview"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+type ILinkPreviewUsecase interface {
+	// Enqueue запускает фоновую загрузку превью, если в сообщении есть новая ссылка
+	Enqueue(ctx context.Context, msg model.Message)
+}
+
+// LinkPreviewUsecase загружает превью ссылок вне запроса отправки:
+// сообщение уходит сразу, а карточка приходит позже событием updateMessage.
+// Число одновременных загрузок ограничено, лишние задачи отбрасываются.
+type LinkPreviewUsecase struct {
+	messageRepo repository.IMessageRepo
+	fetcher     linkpreview.IFetcher
+	nc          *nats.Conn
+	slots       chan struct{}
+	timeout     time.Duration
+}
+
+func NewLinkPreviewUsecase(messageRepo repository.IMessageRepo, fetcher linkpreview.IFetcher, nc *nats.Conn, workers int, timeout time.Duration) ILinkPreviewUsecase {
+	return &LinkPreviewUsecase{
+		messageRepo: messageRepo,
+		fetcher:     fetcher,
+		nc:          nc,
+		slots:       make(chan struct{}, workers),
+		timeout:     timeout,
+	}
+}
+
+func (uc *LinkPreviewUsecase) Enqueue(ctx context.Context, msg model.Message) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	link := model.PreviewURL(msg.Body, msg.Entities)
+	if link == "" || (msg.LinkPreview != nil && msg.LinkPreview.URL == link) {
+		return
+	}
+
+	select {
+	case uc.slots <- struct{}{}:
+	default:
+		logger.Warn("Link preview queue is full, skipping", zap.String("messageID", msg.ID.String()))
+		return
+	}
+
+	// Контекст запроса отменится после ответа клиенту, поэтому берём новый
+	bgCtx, cancel := context.WithTimeout(utils.WithLogger(context.Background(), logger), uc.timeout)
+	go func() {
+		defer func() { <-uc.slots }()
+		defer cancel()
+		uc.attach(bgCtx, msg, link)
+	}()
+}
+
+func (uc *LinkPreviewUsecase) attach(ctx context.Context, msg model.Message, link string) {
+	logger := utils.GetLoggerFromCtx(ctx).With(zap.String("messageID", msg.ID.String()))
+
+	preview, err := uc.fetcher.Fetch(ctx, link)
+	if err != nil {
+		logger.Info("Link preview unavailable", zap.String("url", link), zap.Error(err))
+		return
+	}
+
+	err = uc.messageRepo.AttachLinkPreview(ctx, msg.ID, msg.EditCount, model.LinkPreview{
+		URL:         link,
+		Title:       preview.Title,
+		Description: preview.Description,
+		ImageURL:    preview.ImageURL,
+		SiteName:    preview.SiteName,
+	})
+	if errors.Is(err, repository.ErrMessagesNotFound) {
+		// Сообщение изменили или удалили, пока грузилась страница
+		return
+	}
+	if err != nil {
+		logger.Error("AttachLinkPreview failed", zap.Error(err))
+		return
+	}
+
+	updated, err := uc.messageRepo.GetMessage(ctx, msg.ID)
+	if err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return
+	}
+
+	data, _ := json.Marshal(model.MessageEvent{Action: utils.UpdateMessage, Message: *updated})
+	subj := fmt.Sprintf("chat.%s.messages", updated.ChatID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		logger.Error("NATS publish failed", zap.Error(err))
+		return
+	}
+
+	metrics.IncBusinessOp("attach_link_preview")
+}
@@ -3,8 +3,11 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
@@ -16,97 +19,196 @@ import (
 )
 
 type IMessageUsecase interface {
-	GetChatMessages(ctx context.Context, userID uuid.UUID, chatID uuid.UUID) ([]model.Message, error)
-	GetMessagesBefore(ctx context.Context, userID, chatID, messageID uuid.UUID) ([]model.Message, error)
-	GetMessagesAfter(ctx context.Context, userID, chatID, messageID uuid.UUID) ([]model.Message, error)
-	SendMessage(ctx context.Context, input *model.Message, userID uuid.UUID, chatID uuid.UUID) error
+	GetMessageHistory(ctx context.Context, userID, chatID uuid.UUID, query *model.HistoryQuery) (*model.MessagePage, error)
+	GetMessageWindow(ctx context.Context, userID, chatID uuid.UUID, query *model.WindowQuery) (*model.MessagePage, error)
+	// SendMessage возвращает опубликованное сообщение либо, если задан send_at, запись очереди отложенных
+	SendMessage(ctx context.Context, input *model.Message, userID uuid.UUID, chatID uuid.UUID) (*model.Message, *model.ScheduledMessage, error)
 	UpdateMessage(ctx context.Context, messageID uuid.UUID, input *model.MessageInput, userID uuid.UUID, chatID uuid.UUID) error
-	DeleteMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID) error
+	DeleteMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID, mode model.DeleteMode) error
+	DeleteMessages(ctx context.Context, input *model.BulkDeleteInput, userID uuid.UUID, chatID uuid.UUID) error
+	ClearHistory(ctx context.Context, mode model.DeleteMode, userID uuid.UUID, chatID uuid.UUID) error
+	GetMessageRevisions(ctx context.Context, userID, chatID, messageID uuid.UUID) ([]model.MessageRevision, error)
+	SetReaction(ctx context.Context, messageID uuid.UUID, input *model.ReactionInput, userID uuid.UUID, chatID uuid.UUID) error
+	RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID) error
+	GetReplies(ctx context.Context, userID, chatID, messageID uuid.UUID, afterReplyID *uuid.UUID) ([]model.Message, error)
+	MarkRead(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID) error
+	ForwardMessages(ctx context.Context, input *model.ForwardInput, userID uuid.UUID, toChatID uuid.UUID) error
+	GetScheduledMessages(ctx context.Context, userID, chatID uuid.UUID) ([]model.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, userID, chatID, scheduledID uuid.UUID, input *model.ScheduledMessageUpdate) (*model.ScheduledMessage, error)
+	CancelScheduledMessage(ctx context.Context, userID, chatID, scheduledID uuid.UUID) error
+	GetNextMention(ctx context.Context, userID, chatID uuid.UUID, afterMessageID *uuid.UUID) (*model.Message, error)
+	GetPoll(ctx context.Context, userID, chatID, messageID uuid.UUID) (*model.Poll, error)
+	VotePoll(ctx context.Context, userID, chatID, messageID uuid.UUID, input *model.PollVoteInput) (*model.Poll, error)
+	RetractPollVote(ctx context.Context, userID, chatID, messageID uuid.UUID) (*model.Poll, error)
 }
 
 type MessageUsecase struct {
 	messageRepo  repository.IMessageRepo
 	filesUsecase IFilesUsecase
 	chatRepo     repository.IChatRepo
+	linkPreviews ILinkPreviewUsecase
+	drafts       IDraftUsecase
 	nc           *nats.Conn
 }
 
-func NewMessageUsecase(msgRepo repository.IMessageRepo, filesUsecase IFilesUsecase, chatRepo repository.IChatRepo, nc *nats.Conn) IMessageUsecase {
-	return &MessageUsecase{messageRepo: msgRepo, filesUsecase: filesUsecase, chatRepo: chatRepo, nc: nc}
+func NewMessageUsecase(msgRepo repository.IMessageRepo, filesUsecase IFilesUsecase, chatRepo repository.IChatRepo, linkPreviews ILinkPreviewUsecase, drafts IDraftUsecase, nc *nats.Conn) IMessageUsecase {
+	return &MessageUsecase{messageRepo: msgRepo, filesUsecase: filesUsecase, chatRepo: chatRepo, linkPreviews: linkPreviews, drafts: drafts, nc: nc}
 }
 
-func (uc *MessageUsecase) GetChatMessages(ctx context.Context, userID uuid.UUID, chatID uuid.UUID) ([]model.Message, error) {
+// GetMessageHistory отдаёт страницу истории чата по курсору
+func (uc *MessageUsecase) GetMessageHistory(ctx context.Context, userID, chatID uuid.UUID, query *model.HistoryQuery) (*model.MessagePage, error) {
 	logger := utils.GetLoggerFromCtx(ctx)
-	logger.Info("GetChatMessages start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+	logger.Info("GetMessageHistory start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := query.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return nil, err
+	}
 
 	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
 		logger.Warn("Access denied при попытке получить сообщения", zap.Error(err))
 		return nil, err
 	}
 
-	msgs, err := uc.messageRepo.GetMessages(ctx, chatID)
+	page, err := uc.messageRepo.GetMessagePage(ctx, chatID, userID, *query)
 	if err != nil {
-		logger.Error("GetMessages failed", zap.Error(err))
+		logger.Error("GetMessagePage failed", zap.Error(err))
 		return nil, err
 	}
 	metrics.IncBusinessOp("get_messages")
-	return msgs, nil
+	return page, nil
 }
 
-func (uc *MessageUsecase) GetMessagesBefore(ctx context.Context, userID, chatID, messageID uuid.UUID) ([]model.Message, error) {
+// GetMessageWindow отдаёт окно истории вокруг сообщения или даты — для
+// перехода к дате, результату поиска или цитируемому сообщению
+func (uc *MessageUsecase) GetMessageWindow(ctx context.Context, userID, chatID uuid.UUID, query *model.WindowQuery) (*model.MessagePage, error) {
 	logger := utils.GetLoggerFromCtx(ctx)
-	logger.Info("GetMessagesAfter start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()), zap.String("messageID", messageID.String()))
+	logger.Info("GetMessageWindow start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := query.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return nil, err
+	}
 
 	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
-		logger.Warn("Access denied при попытке получить сообщения до", zap.Error(err))
+		logger.Warn("Access denied при попытке получить сообщения", zap.Error(err))
 		return nil, err
 	}
 
-	messages, err := uc.messageRepo.GetMessagesBefore(ctx, chatID, messageID)
+	var anchor model.MessageCursor
+	if query.MessageID != nil {
+		msg, err := uc.messageRepo.GetMessage(ctx, *query.MessageID)
+		if err != nil {
+			logger.Error("GetMessage failed", zap.Error(err))
+			return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+		}
+		if msg.ChatID != chatID {
+			return nil, ErrMessageNotFound
+		}
+		anchor = *model.NewMessageCursor(*msg)
+	} else {
+		// sent_at хранится без часового пояса в UTC; нулевой ID ставит
+		// опорную точку перед всеми сообщениями этого момента
+		anchor = model.MessageCursor{SentAt: query.Date.UTC(), ID: uuid.Nil}
+	}
+
+	page, err := uc.messageRepo.GetMessageWindow(ctx, chatID, userID, anchor, query.Limit)
 	if err != nil {
-		logger.Error("GetMessagesAfterID failed", zap.Error(err))
+		logger.Error("GetMessageWindow failed", zap.Error(err))
 		return nil, err
 	}
-
-	metrics.IncBusinessOp("get_messages_before")
-	return messages, nil
+	metrics.IncBusinessOp("get_message_window")
+	return page, nil
 }
 
-func (uc *MessageUsecase) GetMessagesAfter(ctx context.Context, userID, chatID, messageID uuid.UUID) ([]model.Message, error) {
+func (uc *MessageUsecase) GetReplies(ctx context.Context, userID, chatID, messageID uuid.UUID, afterReplyID *uuid.UUID) ([]model.Message, error) {
 	logger := utils.GetLoggerFromCtx(ctx)
-	logger.Info("GetMessagesAfter start",
-		zap.String("userID", userID.String()),
-		zap.String("chatID", chatID.String()),
-		zap.String("messageID", messageID.String()),
-	)
+	logger.Info("GetReplies start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()), zap.String("messageID", messageID.String()))
 
 	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
-		logger.Warn("Access denied при попытке получить сообщения после", zap.Error(err))
+		logger.Warn("Access denied при попытке получить ответы", zap.Error(err))
 		return nil, err
 	}
 
-	messages, err := uc.messageRepo.GetMessagesAfter(ctx, chatID, messageID)
+	if err := uc.ensureMessageInChat(ctx, messageID, chatID); err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return nil, err
+	}
+
+	replies, err := uc.messageRepo.GetReplies(ctx, messageID, userID, afterReplyID)
 	if err != nil {
-		logger.Error("GetMessagesAfterID failed", zap.Error(err))
+		logger.Error("GetReplies failed", zap.Error(err))
 		return nil, err
 	}
 
-	metrics.IncBusinessOp("get_messages_after")
-	return messages, nil
+	metrics.IncBusinessOp("get_replies")
+	return replies, nil
 }
 
-func (uc *MessageUsecase) SendMessage(ctx context.Context, msg *model.Message, userID uuid.UUID, chatID uuid.UUID) error {
+func (uc *MessageUsecase) SendMessage(ctx context.Context, msg *model.Message, userID uuid.UUID, chatID uuid.UUID) (*model.Message, *model.ScheduledMessage, error) {
 	logger := utils.GetLoggerFromCtx(ctx)
 	logger.Info("SendMessage start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
 
 	if err := uc.ensureCanSend(ctx, userID, chatID); err != nil {
 		logger.Warn("Access denied при попытке отправить сообщение", zap.Error(err))
-		return err
+		return nil, nil, err
 	}
 
 	if err := msg.Validate(); err != nil {
 		logger.Error("Validation failed", zap.Error(err))
-		return fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+		return nil, nil, fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+	}
+
+	if msg.ParentMessageID != nil {
+		if err := uc.ensureMessageInChat(ctx, *msg.ParentMessageID, chatID); err != nil {
+			logger.Warn("Родительское сообщение не найдено в чате", zap.Error(err))
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidParentMessage, err)
+		}
+	}
+
+	if msg.SendAt != nil {
+		// Опрос в очереди отложенных не хранится: голосовать можно только в опубликованном
+		if msg.PollInput != nil {
+			return nil, nil, ErrScheduledPoll
+		}
+		if err := model.ValidateSendAt(*msg.SendAt); err != nil {
+			logger.Error("Invalid send_at", zap.Error(err))
+			return nil, nil, fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+		}
+	}
+
+	if msg.TTL != nil {
+		if err := model.ValidateTTL(*msg.TTL); err != nil {
+			logger.Error("Invalid ttl", zap.Error(err))
+			return nil, nil, fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+		}
+	}
+
+	mentions, err := uc.resolveMentions(ctx, chatID, msg.Body)
+	if err != nil {
+		logger.Error("Не удалось разобрать упоминания", zap.Error(err))
+		return nil, nil, err
+	}
+	msg.Entities = model.MergeEntities(msg.Entities, mentions)
+
+	// Повтор уже выполненной отправки: отдаём исходное сообщение, файлы не загружаем.
+	// Для отложенных сообщений ключ не учитывается.
+	if msg.ClientMessageID != "" {
+		if err := model.ValidateClientMessageID(msg.ClientMessageID); err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+		}
+		if msg.SendAt == nil {
+			existing, err := uc.messageRepo.FindMessageByClientID(ctx, userID, chatID, msg.ClientMessageID)
+			if err != nil {
+				logger.Error("FindMessageByClientID failed", zap.Error(err))
+				return nil, nil, fmt.Errorf("%w: %v", ErrMessageCreationFailed, err)
+			}
+			if existing != nil {
+				logger.Info("Повторная отправка, возвращаем исходное сообщение", zap.String("messageID", existing.ID.String()))
+				existing.ClientMessageID = msg.ClientMessageID
+				return existing, nil, nil
+			}
+		}
 	}
 	log.Println(len(msg.Photos), len(msg.Files))
 	// Если есть файлы/фото и сообщение не только стикер
@@ -115,14 +217,14 @@ func (uc *MessageUsecase) SendMessage(ctx context.Context, msg *model.Message, u
 		chat, err := uc.chatRepo.GetChatByID(ctx, chatID)
 		if err != nil {
 			logger.Error("Не удалось получить тип чата", zap.Error(err))
-			return err
+			return nil, nil, err
 		}
 
 		if model.ChatType(chat.Type) == model.ChatTypeDialog || model.ChatType(chat.Type) == model.ChatTypeGroup {
 			users, err := uc.chatRepo.GetUsersFromChat(ctx, chatID)
 			if err != nil {
 				logger.Error("Не удалось получить пользователей чата", zap.Error(err))
-				return err
+				return nil, nil, err
 			}
 			for _, u := range users {
 				userIDs = append(userIDs, u.ID.String())
@@ -135,7 +237,8 @@ func (uc *MessageUsecase) SendMessage(ctx context.Context, msg *model.Message, u
 			savedFile, err := uc.filesUsecase.SaveFile(ctx, msg.Files[i], msg.FilesHeaders[i], userIDs)
 			if err != nil {
 				logger.Error("Не удалось сохранить файл", zap.Error(err))
-				return err
+				uc.discardUploads(ctx, msg)
+				return nil, nil, err
 			}
 			log.Println("bebra123", savedFile.ContentType)
 			msg.FilesDTO = append(msg.FilesDTO, model.Payload{
@@ -151,7 +254,8 @@ func (uc *MessageUsecase) SendMessage(ctx context.Context, msg *model.Message, u
 			savedPhoto, err := uc.filesUsecase.SavePhoto(ctx, msg.Photos[i], msg.PhotosHeaders[i], userIDs)
 			if err != nil {
 				logger.Error("Не удалось сохранить фото", zap.Error(err))
-				return err
+				uc.discardUploads(ctx, msg)
+				return nil, nil, err
 			}
 			msg.PhotosDTO = append(msg.PhotosDTO, model.Payload{
 				URL:         savedPhoto.URL,
@@ -160,26 +264,142 @@ func (uc *MessageUsecase) SendMessage(ctx context.Context, msg *model.Message, u
 				Size:        savedPhoto.Size,
 			})
 		}
+
+		if msg.Voice != nil {
+			savedVoice, err := uc.filesUsecase.SaveVoice(ctx, msg.Voice, msg.VoiceHeader, userIDs)
+			if err != nil {
+				logger.Error("Не удалось сохранить голосовое сообщение", zap.Error(err))
+				uc.discardUploads(ctx, msg)
+				return nil, nil, err
+			}
+			msg.VoiceDTO = &savedVoice
+		}
 	}
+
+	// Отложенное сообщение сохраняем в очередь, его опубликует диспетчер
+	if msg.SendAt != nil {
+		scheduled, err := uc.messageRepo.CreateScheduledMessage(ctx, msg, *msg.SendAt)
+		if err != nil {
+			logger.Error("CreateScheduledMessage failed", zap.Error(err))
+			uc.discardUploads(ctx, msg)
+			return nil, nil, fmt.Errorf("%w: %v", ErrMessageCreationFailed, err)
+		}
+		metrics.IncBusinessOp("schedule_message")
+		return nil, scheduled, nil
+	}
+
 	savedMsg, err := uc.messageRepo.CreateMessage(ctx, msg)
+	if errors.Is(err, repository.ErrDuplicateClientMessageID) {
+		// Параллельный повтор успел раньше: убираем наши загрузки и отдаём его сообщение
+		existing, err := uc.resolveDuplicateSend(ctx, msg)
+		return existing, nil, err
+	}
 	if err != nil {
-		log.Println(savedMsg, err)
 		logger.Error("CreateMessage failed", zap.Error(err))
-		return fmt.Errorf("%w: %v", ErrMessageCreationFailed, err)
+		// Транзакция откатилась целиком, загруженные файлы больше никому не нужны
+		uc.discardUploads(ctx, msg)
+		return nil, nil, fmt.Errorf("%w: %v", ErrMessageCreationFailed, err)
 	}
 
 	log.Println(savedMsg)
 	// Отправка события в NATS
+	savedMsg.ClientMessageID = msg.ClientMessageID
 	event := model.MessageEvent{Action: utils.NewMessage, Message: *savedMsg}
 	data, _ := json.Marshal(event)
 	subj := fmt.Sprintf("chat.%s.messages", chatID.String())
 	if err := uc.nc.Publish(subj, data); err != nil {
 		logger.Error("NATS publish failed", zap.Error(err))
-		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+		return nil, nil, fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
 	}
+	publishMentions(uc.nc, logger, *savedMsg, model.MentionedUsers(savedMsg.Entities))
+	uc.linkPreviews.Enqueue(ctx, *savedMsg)
+	uc.drafts.ClearAfterSend(ctx, userID, chatID)
 
 	metrics.IncBusinessOp("send_message")
-	return nil
+	return savedMsg, nil, nil
+}
+
+// resolveDuplicateSend удаляет файлы, загруженные проигравшим повтором,
+// и возвращает сообщение, созданное первой попыткой
+func (uc *MessageUsecase) resolveDuplicateSend(ctx context.Context, msg *model.Message) (*model.Message, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	uc.discardUploads(ctx, msg)
+
+	existing, err := uc.messageRepo.FindMessageByClientID(ctx, msg.UserID, msg.ChatID, msg.ClientMessageID)
+	if err != nil || existing == nil {
+		logger.Error("FindMessageByClientID failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrMessageCreationFailed, err)
+	}
+	existing.ClientMessageID = msg.ClientMessageID
+	return existing, nil
+}
+
+// discardUploads удаляет из хранилища вложения, загруженные для сообщения,
+// которое так и не было сохранено. Ошибки только логируются: исходную
+// ошибку отправки они не отменяют.
+func (uc *MessageUsecase) discardUploads(ctx context.Context, msg *model.Message) {
+	urls := payloadURLs(msg)
+	if len(urls) == 0 {
+		return
+	}
+	if err := uc.filesUsecase.PurgeFiles(ctx, urls); err != nil {
+		utils.GetLoggerFromCtx(ctx).Warn("Failed to clean up uploads", zap.Error(err))
+		return
+	}
+	msg.FilesDTO, msg.PhotosDTO, msg.VoiceDTO = nil, nil, nil
+}
+
+// resolveMentions размечает в тексте упоминания участников чата
+func (uc *MessageUsecase) resolveMentions(ctx context.Context, chatID uuid.UUID, body string) ([]model.MessageEntity, error) {
+	if !strings.Contains(body, "@") {
+		return nil, nil
+	}
+
+	users, err := uc.chatRepo.GetUsersFromChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	byUsername := make(map[string]uuid.UUID, len(users))
+	for _, u := range users {
+		byUsername[strings.ToLower(u.Username)] = u.ID
+	}
+
+	return model.FindMentions(body, func(username string) (uuid.UUID, bool) {
+		id, ok := byUsername[strings.ToLower(username)]
+		return id, ok
+	}), nil
+}
+
+// publishMentions отправляет упомянутым пользователям событие mention в их
+// личный канал. Автор о своём упоминании не уведомляется. Сообщение уже
+// сохранено, поэтому ошибки публикации только логируются.
+func publishMentions(nc *nats.Conn, logger *zap.Logger, msg model.Message, userIDs []uuid.UUID) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	e := model.MessageEvent{Action: utils.Mention, Message: msg}
+	data, _ := json.Marshal(model.UserEvent{TypeOfEvent: utils.Mention, Event: e})
+	for _, id := range userIDs {
+		if id == msg.UserID {
+			continue
+		}
+		subj := fmt.Sprintf("user.%s.events", id.String())
+		if err := nc.Publish(subj, data); err != nil {
+			logger.Warn("NATS publish mention failed", zap.String("userID", id.String()), zap.Error(err))
+		}
+	}
+}
+
+// payloadURLs собирает адреса всех загруженных вложений сообщения
+func payloadURLs(msg *model.Message) []string {
+	payloads := msg.Payloads()
+	urls := make([]string, 0, len(payloads))
+	for _, p := range payloads {
+		urls = append(urls, p.URL)
+	}
+	return urls
 }
 
 func (uc *MessageUsecase) UpdateMessage(ctx context.Context, messageID uuid.UUID, input *model.MessageInput, userID uuid.UUID, chatID uuid.UUID) error {
@@ -202,12 +422,33 @@ func (uc *MessageUsecase) UpdateMessage(ctx context.Context, messageID uuid.UUID
 		return fmt.Errorf("%w: %v", ErrMessageNotFound, err)
 	}
 
+	if message.ChatID != chatID || message.DeletedAt != nil {
+		return ErrMessageNotFound
+	}
+
 	if message.UserID != userID {
 		logger.Warn("Access denied: user is not the author of the message", zap.String("messageID", messageID.String()))
 		return ErrMessageAccessDenied
 	}
 
-	updated, err := uc.messageRepo.UpdateMessage(ctx, messageID, input.Message)
+	// Вопрос опроса правкой текста не меняется: за варианты уже голосовали
+	if message.Poll != nil {
+		return ErrPollNotEditable
+	}
+
+	if time.Since(message.SentAt) > model.MessageEditWindow {
+		logger.Warn("Edit window closed", zap.String("messageID", messageID.String()))
+		return ErrMessageEditWindowClosed
+	}
+
+	mentions, err := uc.resolveMentions(ctx, chatID, input.Message)
+	if err != nil {
+		logger.Error("Не удалось разобрать упоминания", zap.Error(err))
+		return err
+	}
+	entities := model.MergeEntities(input.Entities, mentions)
+
+	updated, err := uc.messageRepo.UpdateMessage(ctx, messageID, input.Message, entities)
 	if err != nil {
 		logger.Error("UpdateMessage failed", zap.Error(err))
 		return fmt.Errorf("%w: %v", ErrMessageUpdateFailed, err)
@@ -222,13 +463,71 @@ func (uc *MessageUsecase) UpdateMessage(ctx context.Context, messageID uuid.UUID
 		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
 	}
 
+	// Уведомляем только тех, кого правка упомянула впервые
+	alreadyMentioned := make(map[uuid.UUID]struct{})
+	for _, id := range model.MentionedUsers(message.Entities) {
+		alreadyMentioned[id] = struct{}{}
+	}
+	var newlyMentioned []uuid.UUID
+	for _, id := range model.MentionedUsers(updated.Entities) {
+		if _, ok := alreadyMentioned[id]; !ok {
+			newlyMentioned = append(newlyMentioned, id)
+		}
+	}
+	publishMentions(uc.nc, logger, *updated, newlyMentioned)
+	uc.linkPreviews.Enqueue(ctx, *updated)
+
 	metrics.IncBusinessOp("update_message")
 	return nil
 }
 
-func (uc *MessageUsecase) DeleteMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID) error {
+// GetMessageRevisions возвращает историю правок сообщения; последней идёт текущая версия
+func (uc *MessageUsecase) GetMessageRevisions(ctx context.Context, userID, chatID, messageID uuid.UUID) ([]model.MessageRevision, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("GetMessageRevisions start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке получить историю правок", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := uc.messageRepo.GetMessage(ctx, messageID)
+	if err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+	if message.ChatID != chatID {
+		return nil, ErrMessageNotFound
+	}
+
+	revisions, err := uc.messageRepo.GetMessageRevisions(ctx, messageID)
+	if err != nil {
+		logger.Error("GetMessageRevisions failed", zap.Error(err))
+		return nil, err
+	}
+
+	current := model.MessageRevision{
+		Version:   message.EditCount + 1,
+		Body:      message.Body,
+		CreatedAt: message.SentAt,
+	}
+	if message.EditedAt != nil {
+		current.CreatedAt = *message.EditedAt
+	}
+
+	metrics.IncBusinessOp("get_message_revisions")
+	return append(revisions, current), nil
+}
+
+// DeleteMessage удаляет сообщение в одном из режимов: «для себя» скрывает его
+// только у вызывающего, «для всех» оставляет надгробие у всех участников
+func (uc *MessageUsecase) DeleteMessage(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID, mode model.DeleteMode) error {
 	logger := utils.GetLoggerFromCtx(ctx)
-	logger.Info("DeleteMessage start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+	logger.Info("DeleteMessage start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()), zap.String("mode", string(mode)))
+
+	if !mode.IsValid() {
+		return fmt.Errorf("%w: unknown delete mode %q", ErrMessageValidationFailed, mode)
+	}
 
 	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
 		logger.Warn("Access denied при попытке удалить сообщение", zap.Error(err))
@@ -240,20 +539,34 @@ func (uc *MessageUsecase) DeleteMessage(ctx context.Context, messageID uuid.UUID
 		logger.Error("GetMessage failed", zap.Error(err))
 		return fmt.Errorf("%w: %v", ErrMessageNotFound, err)
 	}
+	if message.ChatID != chatID {
+		return ErrMessageNotFound
+	}
+
+	if mode == model.DeleteForMe {
+		return uc.hideMessage(ctx, message, userID)
+	}
+
+	if message.DeletedAt != nil {
+		return ErrMessageNotFound
+	}
 
 	if message.UserID != userID {
-		logger.Warn("Access denied: user is not the author of the message", zap.String("messageID", messageID.String()))
-		return ErrMessageAccessDenied
+		if err := uc.ensureChatModerator(ctx, userID, chatID); err != nil {
+			logger.Warn("Access denied: user is neither the author nor the chat owner", zap.String("messageID", messageID.String()))
+			return err
+		}
 	}
 
-	deleted, err := uc.messageRepo.DeleteMessage(ctx, messageID)
+	deleted, files, err := uc.messageRepo.DeleteMessage(ctx, messageID)
 	if err != nil {
 		logger.Error("DeleteMessage failed", zap.Error(err))
 		return fmt.Errorf("%w: %v", ErrMessageDeleteFailed, err)
 	}
+	uc.purgeFiles(ctx, files)
 
 	// publish delete-message event
-	e := model.MessageEvent{Action: utils.DeleteMessage, Message: *deleted}
+	e := model.MessageEvent{Action: utils.DeleteMessage, Message: *deleted, Mode: model.DeleteForEveryone}
 	data, _ := json.Marshal(e)
 	subj := fmt.Sprintf("chat.%s.messages", chatID.String())
 	if err := uc.nc.Publish(subj, data); err != nil {
@@ -265,33 +578,708 @@ func (uc *MessageUsecase) DeleteMessage(ctx context.Context, messageID uuid.UUID
 	return nil
 }
 
-// ensureMember проверяет, что пользователь является участником чата
-func (uc *MessageUsecase) ensureMember(ctx context.Context, userID, chatID uuid.UUID) error {
-	role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+// hideMessage скрывает сообщение у пользователя и сообщает об этом
+// только его собственным соединениям
+func (uc *MessageUsecase) hideMessage(ctx context.Context, message *model.Message, userID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	if err := uc.messageRepo.HideMessage(ctx, message.ID, userID); err != nil {
+		logger.Error("HideMessage failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessageDeleteFailed, err)
+	}
+
+	e := model.MessageEvent{
+		Action:  utils.DeleteMessage,
+		Message: model.Message{ID: message.ID, ChatID: message.ChatID},
+		Mode:    model.DeleteForMe,
+	}
+	data, _ := json.Marshal(model.UserEvent{TypeOfEvent: utils.DeleteMessage, Event: e})
+	subj := fmt.Sprintf("user.%s.events", userID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		logger.Error("NATS publish failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+	}
+
+	metrics.IncBusinessOp("hide_message")
+	return nil
+}
+
+// DeleteMessages удаляет пачку сообщений одной операцией и рассылает одно
+// событие deleteMessages. Права те же, что при одиночном удалении: для всех
+// удаляет автор своих сообщений или владелец группы или канала.
+func (uc *MessageUsecase) DeleteMessages(ctx context.Context, input *model.BulkDeleteInput, userID uuid.UUID, chatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("DeleteMessages start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке удалить сообщения", zap.Error(err))
+		return err
+	}
+
+	deleted := model.MessagesDeleted{ChatID: chatID, MessageIDs: input.MessageIDs}
+
+	if input.Mode == model.DeleteForMe {
+		if err := uc.messageRepo.HideMessages(ctx, chatID, userID, input.MessageIDs); err != nil {
+			logger.Error("HideMessages failed", zap.Error(err))
+			return err
+		}
+		if err := uc.publishDeletedForMe(userID, utils.DeleteMessages, deleted); err != nil {
+			logger.Error("NATS publish failed", zap.Error(err))
+			return err
+		}
+		metrics.IncBusinessOp("hide_messages")
+		return nil
+	}
+
+	// Модератор удаляет любые сообщения, остальные — только свои
+	authorID := &userID
+	err := uc.ensureChatModerator(ctx, userID, chatID)
+	switch {
+	case err == nil:
+		authorID = nil
+	case !errors.Is(err, ErrMessageAccessDenied):
+		return err
+	}
+
+	files, err := uc.messageRepo.DeleteMessages(ctx, chatID, input.MessageIDs, authorID)
 	if err != nil {
+		logger.Error("DeleteMessages failed", zap.Error(err))
 		return err
 	}
-	if !model.UserRoleInChat(role).IsMember() {
-		return ErrPermissionDenied
+	uc.purgeFiles(ctx, files)
+
+	e := model.MessagesDeletedEvent{Action: utils.DeleteMessages, Deleted: deleted, Mode: model.DeleteForEveryone}
+	data, _ := json.Marshal(e)
+	subj := fmt.Sprintf("chat.%s.messages", chatID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		logger.Error("NATS publish failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
 	}
+
+	metrics.IncBusinessOp("delete_messages")
 	return nil
 }
 
-// ensureCanSend проверяет, что пользователь может отправлять сообщения
-func (uc *MessageUsecase) ensureCanSend(ctx context.Context, userID, chatID uuid.UUID) error {
-	chat, err := uc.chatRepo.GetChatByID(ctx, chatID)
-	if err != nil {
+// ClearHistory очищает историю чата. В режиме me история скрывается только
+// у пользователя и доступна любому участнику; в режиме everyone сообщения
+// удаляются у всех — это может только владелец группы или канала.
+func (uc *MessageUsecase) ClearHistory(ctx context.Context, mode model.DeleteMode, userID uuid.UUID, chatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("ClearHistory start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()), zap.String("mode", string(mode)))
+
+	if !mode.IsValid() {
+		return fmt.Errorf("%w: unknown delete mode %q", ErrMessageValidationFailed, mode)
+	}
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке очистить историю", zap.Error(err))
 		return err
 	}
-	if chat.Type == string(model.ChatTypeChannel) {
-		role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+
+	if mode == model.DeleteForMe {
+		before, err := uc.messageRepo.HideHistory(ctx, chatID, userID)
 		if err != nil {
+			logger.Error("HideHistory failed", zap.Error(err))
 			return err
 		}
-		if model.UserRoleInChat(role) != model.RoleOwner {
-			return ErrMessageAccessDenied
+		if before == nil {
+			return nil
+		}
+		deleted := model.MessagesDeleted{ChatID: chatID, Before: before}
+		if err := uc.publishDeletedForMe(userID, utils.ClearHistory, deleted); err != nil {
+			logger.Error("NATS publish failed", zap.Error(err))
+			return err
 		}
+		metrics.IncBusinessOp("hide_history")
 		return nil
 	}
-	return uc.ensureMember(ctx, userID, chatID)
+
+	if err := uc.ensureChatModerator(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied: only the chat owner can clear history for everyone")
+		return err
+	}
+
+	before, files, err := uc.messageRepo.ClearHistory(ctx, chatID)
+	if err != nil {
+		logger.Error("ClearHistory failed", zap.Error(err))
+		return err
+	}
+	if before == nil {
+		return nil
+	}
+	uc.purgeFiles(ctx, files)
+
+	e := model.MessagesDeletedEvent{
+		Action:  utils.ClearHistory,
+		Deleted: model.MessagesDeleted{ChatID: chatID, Before: before},
+		Mode:    model.DeleteForEveryone,
+	}
+	data, _ := json.Marshal(e)
+	subj := fmt.Sprintf("chat.%s.messages", chatID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		logger.Error("NATS publish failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+	}
+
+	metrics.IncBusinessOp("clear_history")
+	return nil
+}
+
+// publishDeletedForMe сообщает о пакетном скрытии только соединениям самого пользователя
+func (uc *MessageUsecase) publishDeletedForMe(userID uuid.UUID, action string, deleted model.MessagesDeleted) error {
+	e := model.MessagesDeletedEvent{Action: action, Deleted: deleted, Mode: model.DeleteForMe}
+	data, _ := json.Marshal(model.UserEvent{TypeOfEvent: action, Event: e})
+	subj := fmt.Sprintf("user.%s.events", userID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+	}
+	return nil
+}
+
+// purgeFiles убирает из хранилища файлы удалённых сообщений. Строки в БД
+// уже удалены, поэтому ошибка хранилища оставляет лишь мусорный объект.
+func (uc *MessageUsecase) purgeFiles(ctx context.Context, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	if err := uc.filesUsecase.PurgeFiles(ctx, files); err != nil {
+		utils.GetLoggerFromCtx(ctx).Warn("Failed to purge files of deleted messages", zap.Error(err))
+	}
+}
+
+func (uc *MessageUsecase) SetReaction(ctx context.Context, messageID uuid.UUID, input *model.ReactionInput, userID uuid.UUID, chatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("SetReaction start", zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке поставить реакцию", zap.Error(err))
+		return err
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.ensureMessageInChat(ctx, messageID, chatID); err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.messageRepo.SetReaction(ctx, messageID, userID, input.Reaction); err != nil {
+		logger.Error("SetReaction failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.publishReactions(ctx, messageID, chatID); err != nil {
+		return err
+	}
+
+	metrics.IncBusinessOp("set_reaction")
+	return nil
+}
+
+func (uc *MessageUsecase) RemoveReaction(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("RemoveReaction start", zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке убрать реакцию", zap.Error(err))
+		return err
+	}
+
+	if err := uc.ensureMessageInChat(ctx, messageID, chatID); err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.messageRepo.DeleteReaction(ctx, messageID, userID); err != nil {
+		logger.Error("DeleteReaction failed", zap.Error(err))
+		return err
+	}
+
+	if err := uc.publishReactions(ctx, messageID, chatID); err != nil {
+		return err
+	}
+
+	metrics.IncBusinessOp("remove_reaction")
+	return nil
+}
+
+// GetPoll возвращает опрос с итогами и голосами пользователя
+func (uc *MessageUsecase) GetPoll(ctx context.Context, userID, chatID, messageID uuid.UUID) (*model.Poll, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("GetPoll start", zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке получить опрос", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := uc.getPollMessage(ctx, messageID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.withMyVotes(ctx, message.Poll, messageID, userID)
+}
+
+// VotePoll заменяет голос пользователя и рассылает участникам новые итоги.
+// Голосовать может тот, кто может писать в чат (ensureCanSend).
+func (uc *MessageUsecase) VotePoll(ctx context.Context, userID, chatID, messageID uuid.UUID, input *model.PollVoteInput) (*model.Poll, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("VotePoll start", zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureCanSend(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке проголосовать", zap.Error(err))
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPollVote, err)
+	}
+
+	message, err := uc.getPollMessage(ctx, messageID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if message.Poll.Closed {
+		return nil, repository.ErrPollClosed
+	}
+	if !message.Poll.MultipleChoice && len(input.Options) > 1 {
+		return nil, fmt.Errorf("%w: poll allows a single option", ErrInvalidPollVote)
+	}
+	for _, pos := range input.Options {
+		if pos >= len(message.Poll.Options) {
+			return nil, fmt.Errorf("%w: option %d does not exist", ErrInvalidPollVote, pos)
+		}
+	}
+
+	if err := uc.messageRepo.VotePoll(ctx, messageID, userID, input.Options); err != nil {
+		logger.Error("VotePoll failed", zap.Error(err))
+		return nil, err
+	}
+
+	poll, err := uc.publishPoll(ctx, messageID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.IncBusinessOp("vote_poll")
+	return uc.withMyVotes(ctx, poll, messageID, userID)
+}
+
+// RetractPollVote отзывает голос пользователя, пока опрос открыт
+func (uc *MessageUsecase) RetractPollVote(ctx context.Context, userID, chatID, messageID uuid.UUID) (*model.Poll, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("RetractPollVote start", zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureCanSend(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке отозвать голос", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := uc.getPollMessage(ctx, messageID, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if message.Poll.Closed {
+		return nil, repository.ErrPollClosed
+	}
+
+	if err := uc.messageRepo.RetractPollVote(ctx, messageID, userID); err != nil {
+		logger.Error("RetractPollVote failed", zap.Error(err))
+		return nil, err
+	}
+
+	poll, err := uc.publishPoll(ctx, messageID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.IncBusinessOp("retract_poll_vote")
+	return uc.withMyVotes(ctx, poll, messageID, userID)
+}
+
+// getPollMessage возвращает неудалённое сообщение чата, содержащее опрос
+func (uc *MessageUsecase) getPollMessage(ctx context.Context, messageID, chatID uuid.UUID) (*model.Message, error) {
+	message, err := uc.messageRepo.GetMessage(ctx, messageID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+	if message.ChatID != chatID || message.DeletedAt != nil {
+		return nil, ErrMessageNotFound
+	}
+	if message.Poll == nil {
+		return nil, ErrNotAPoll
+	}
+	return message, nil
+}
+
+// withMyVotes дополняет опрос голосами пользователя; в общую рассылку они не попадают
+func (uc *MessageUsecase) withMyVotes(ctx context.Context, poll *model.Poll, messageID, userID uuid.UUID) (*model.Poll, error) {
+	votes, err := uc.messageRepo.GetPollVotes(ctx, messageID, userID)
+	if err != nil {
+		utils.GetLoggerFromCtx(ctx).Error("GetPollVotes failed", zap.Error(err))
+		return nil, err
+	}
+	poll.MyVotes = votes
+	return poll, nil
+}
+
+// publishPoll отправляет в чат актуальные итоги опроса
+func (uc *MessageUsecase) publishPoll(ctx context.Context, messageID, chatID uuid.UUID) (*model.Poll, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	updated, err := uc.messageRepo.GetMessage(ctx, messageID)
+	if err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+	if updated.Poll == nil {
+		return nil, ErrNotAPoll
+	}
+
+	e := model.MessageEvent{
+		Action:  utils.UpdatePoll,
+		Message: model.Message{ID: updated.ID, ChatID: updated.ChatID, Poll: updated.Poll},
+	}
+	data, _ := json.Marshal(e)
+	subj := fmt.Sprintf("chat.%s.messages", chatID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		logger.Error("NATS publish failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+	}
+	return updated.Poll, nil
+}
+
+func (uc *MessageUsecase) MarkRead(ctx context.Context, messageID uuid.UUID, userID uuid.UUID, chatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("MarkRead start", zap.String("userID", userID.String()), zap.String("messageID", messageID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке отметить сообщения прочитанными", zap.Error(err))
+		return err
+	}
+
+	if err := uc.ensureMessageInChat(ctx, messageID, chatID); err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return err
+	}
+
+	marked, err := uc.messageRepo.MarkRead(ctx, chatID, userID, messageID)
+	if err != nil {
+		logger.Error("MarkRead failed", zap.Error(err))
+		return err
+	}
+
+	// Повторная отметка ничего не меняет — событие не нужно
+	if marked == 0 {
+		return nil
+	}
+
+	e := model.ReadEvent{
+		Action: utils.ReadMessages,
+		Read: model.ReadState{
+			ChatID:            chatID,
+			UserID:            userID,
+			LastReadMessageID: messageID,
+			ReadAt:            time.Now(),
+		},
+	}
+	data, _ := json.Marshal(e)
+	subj := fmt.Sprintf("chat.%s.reads", chatID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		logger.Error("NATS publish failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+	}
+
+	metrics.IncBusinessOp("mark_read")
+	return nil
+}
+
+func (uc *MessageUsecase) ForwardMessages(ctx context.Context, input *model.ForwardInput, userID uuid.UUID, toChatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("ForwardMessages start", zap.String("userID", userID.String()), zap.String("toChatID", toChatID.String()))
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+	}
+
+	fromChatID := uuid.MustParse(input.FromChatID)
+	messageIDs := make([]uuid.UUID, 0, len(input.MessageIDs))
+	seen := make(map[uuid.UUID]struct{}, len(input.MessageIDs))
+	for _, raw := range input.MessageIDs {
+		id := uuid.MustParse(raw)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		messageIDs = append(messageIDs, id)
+	}
+
+	if err := uc.ensureMember(ctx, userID, fromChatID); err != nil {
+		logger.Warn("Access denied к исходному чату при пересылке", zap.Error(err))
+		return err
+	}
+
+	if err := uc.ensureCanSend(ctx, userID, toChatID); err != nil {
+		logger.Warn("Access denied при попытке переслать сообщения", zap.Error(err))
+		return err
+	}
+
+	forwarded, err := uc.messageRepo.ForwardMessages(ctx, userID, fromChatID, toChatID, messageIDs)
+	if err != nil {
+		logger.Error("ForwardMessages failed", zap.Error(err))
+		return err
+	}
+
+	subj := fmt.Sprintf("chat.%s.messages", toChatID.String())
+	for _, msg := range forwarded {
+		data, _ := json.Marshal(model.MessageEvent{Action: utils.NewMessage, Message: msg})
+		if err := uc.nc.Publish(subj, data); err != nil {
+			logger.Error("NATS publish failed", zap.Error(err))
+			return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+		}
+	}
+
+	metrics.IncBusinessOp("forward_messages")
+	return nil
+}
+
+func (uc *MessageUsecase) GetScheduledMessages(ctx context.Context, userID, chatID uuid.UUID) ([]model.ScheduledMessage, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("GetScheduledMessages start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке получить отложенные сообщения", zap.Error(err))
+		return nil, err
+	}
+
+	messages, err := uc.messageRepo.GetScheduledMessages(ctx, chatID, userID)
+	if err != nil {
+		logger.Error("GetScheduledMessages failed", zap.Error(err))
+		return nil, err
+	}
+
+	metrics.IncBusinessOp("get_scheduled_messages")
+	return messages, nil
+}
+
+func (uc *MessageUsecase) UpdateScheduledMessage(ctx context.Context, userID, chatID, scheduledID uuid.UUID, input *model.ScheduledMessageUpdate) (*model.ScheduledMessage, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("UpdateScheduledMessage start", zap.String("userID", userID.String()), zap.String("scheduledID", scheduledID.String()))
+
+	if err := input.Validate(); err != nil {
+		logger.Error("Validation failed", zap.Error(err))
+		return nil, fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+	}
+
+	scheduled, err := uc.getOwnScheduledMessage(ctx, userID, chatID, scheduledID)
+	if err != nil {
+		logger.Warn("Access denied при попытке изменить отложенное сообщение", zap.Error(err))
+		return nil, err
+	}
+
+	var entities []model.MessageEntity
+	if input.Message != nil {
+		// Текст хранится как есть, как и при отправке и правке обычного сообщения:
+		// экранирование изменило бы его и сдвинуло смещения разметки
+		body := *input.Message
+		empty := strings.TrimSpace(body) == "" && scheduled.Sticker == "" &&
+			len(scheduled.Payloads()) == 0
+		if empty {
+			return nil, fmt.Errorf("%w: message would be empty", ErrMessageValidationFailed)
+		}
+
+		if err := model.ValidateEntities(body, input.Entities); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMessageValidationFailed, err)
+		}
+		mentions, err := uc.resolveMentions(ctx, chatID, body)
+		if err != nil {
+			logger.Error("Не удалось разобрать упоминания", zap.Error(err))
+			return nil, err
+		}
+		entities = model.MergeEntities(input.Entities, mentions)
+	}
+
+	updated, err := uc.messageRepo.UpdateScheduledMessage(ctx, scheduledID, input.Message, entities, input.SendAt)
+	if err != nil {
+		logger.Error("UpdateScheduledMessage failed", zap.Error(err))
+		return nil, err
+	}
+
+	metrics.IncBusinessOp("update_scheduled_message")
+	return updated, nil
+}
+
+func (uc *MessageUsecase) CancelScheduledMessage(ctx context.Context, userID, chatID, scheduledID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("CancelScheduledMessage start", zap.String("userID", userID.String()), zap.String("scheduledID", scheduledID.String()))
+
+	scheduled, err := uc.getOwnScheduledMessage(ctx, userID, chatID, scheduledID)
+	if err != nil {
+		logger.Warn("Access denied при попытке отменить отложенное сообщение", zap.Error(err))
+		return err
+	}
+
+	if err := uc.messageRepo.DeleteScheduledMessage(ctx, scheduledID); err != nil {
+		logger.Error("DeleteScheduledMessage failed", zap.Error(err))
+		return err
+	}
+
+	// Вложения уже лежат в хранилище и больше никому не нужны
+	var urls []string
+	for _, p := range scheduled.Payloads() {
+		urls = append(urls, p.URL)
+	}
+	if len(urls) > 0 {
+		if err := uc.filesUsecase.PurgeFiles(ctx, urls); err != nil {
+			logger.Warn("Не удалось удалить вложения отменённого сообщения", zap.Error(err))
+		}
+	}
+
+	metrics.IncBusinessOp("cancel_scheduled_message")
+	return nil
+}
+
+// getOwnScheduledMessage возвращает отложенное сообщение, если оно принадлежит
+// пользователю и относится к указанному чату
+func (uc *MessageUsecase) getOwnScheduledMessage(ctx context.Context, userID, chatID, scheduledID uuid.UUID) (*model.ScheduledMessage, error) {
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+
+	scheduled, err := uc.messageRepo.GetScheduledMessage(ctx, scheduledID)
+	if err != nil {
+		return nil, err
+	}
+	if scheduled.ChatID != chatID {
+		return nil, repository.ErrScheduledMessageNotFound
+	}
+	if scheduled.UserID != userID {
+		return nil, ErrMessageAccessDenied
+	}
+	return scheduled, nil
+}
+
+// publishReactions отправляет в чат сообщение с актуальными счётчиками реакций
+func (uc *MessageUsecase) publishReactions(ctx context.Context, messageID, chatID uuid.UUID) error {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	updated, err := uc.messageRepo.GetMessage(ctx, messageID)
+	if err != nil {
+		logger.Error("GetMessage failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+
+	e := model.MessageEvent{Action: utils.UpdateReactions, Message: *updated}
+	data, _ := json.Marshal(e)
+	subj := fmt.Sprintf("chat.%s.messages", chatID.String())
+	if err := uc.nc.Publish(subj, data); err != nil {
+		logger.Error("NATS publish failed", zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrMessagePublishFailed, err)
+	}
+	return nil
+}
+
+// ensureMessageInChat проверяет, что сообщение существует и принадлежит чату
+func (uc *MessageUsecase) ensureMessageInChat(ctx context.Context, messageID, chatID uuid.UUID) error {
+	message, err := uc.messageRepo.GetMessage(ctx, messageID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+	// Удалённое для всех сообщение осталось лишь надгробием в ленте
+	if message.ChatID != chatID || message.DeletedAt != nil {
+		return ErrMessageNotFound
+	}
+	return nil
+}
+
+// ensureMember проверяет, что пользователь является участником чата
+func (uc *MessageUsecase) ensureMember(ctx context.Context, userID, chatID uuid.UUID) error {
+	role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+	if !model.UserRoleInChat(role).IsMember() {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// ensureChatModerator проверяет, что пользователь — владелец группы или канала;
+// в диалогах чужие сообщения удалять нельзя
+func (uc *MessageUsecase) ensureChatModerator(ctx context.Context, userID, chatID uuid.UUID) error {
+	chat, err := uc.chatRepo.GetChatByID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if model.ChatType(chat.Type) == model.ChatTypeDialog {
+		return ErrMessageAccessDenied
+	}
+	role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
+	if model.UserRoleInChat(role) != model.RoleOwner {
+		return ErrMessageAccessDenied
+	}
+	return nil
+}
+
+// ensureCanSend проверяет, что пользователь может отправлять сообщения
+func (uc *MessageUsecase) ensureCanSend(ctx context.Context, userID, chatID uuid.UUID) error {
+	chat, err := uc.chatRepo.GetChatByID(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if chat.Type == string(model.ChatTypeChannel) {
+		role, err := uc.chatRepo.GetUserRoleInChat(ctx, userID, chatID)
+		if err != nil {
+			return err
+		}
+		if model.UserRoleInChat(role) != model.RoleOwner {
+			return ErrMessageAccessDenied
+		}
+		return nil
+	}
+	return uc.ensureMember(ctx, userID, chatID)
+}
+
+// GetNextMention возвращает ближайшее непрочитанное сообщение с упоминанием
+// пользователя; afterMessageID позволяет перейти к следующему
+func (uc *MessageUsecase) GetNextMention(ctx context.Context, userID, chatID uuid.UUID, afterMessageID *uuid.UUID) (*model.Message, error) {
+	logger := utils.GetLoggerFromCtx(ctx)
+	logger.Info("GetNextMention start", zap.String("userID", userID.String()), zap.String("chatID", chatID.String()))
+
+	if err := uc.ensureMember(ctx, userID, chatID); err != nil {
+		logger.Warn("Access denied при попытке перейти к упоминанию", zap.Error(err))
+		return nil, err
+	}
+
+	if afterMessageID != nil {
+		if err := uc.ensureMessageInChat(ctx, *afterMessageID, chatID); err != nil {
+			return nil, err
+		}
+	}
+
+	msg, err := uc.messageRepo.GetNextUnreadMention(ctx, chatID, userID, afterMessageID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMessagesNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		logger.Error("GetNextUnreadMention failed", zap.Error(err))
+		return nil, err
+	}
+
+	metrics.IncBusinessOp("get_next_mention")
+	return msg, nil
 }
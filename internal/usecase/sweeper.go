@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/config/metrics"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const expiredBatchSize = 500
+
+// ExpiredMessagesSweeper периодически удаляет сообщения с истёкшим TTL
+// вместе с их файлами и рассылает deleteMessage, как при ручном удалении.
+// Заодно чистит устаревшие ключи идемпотентности отправки.
+type ExpiredMessagesSweeper struct {
+	messageRepo  repository.IMessageRepo
+	filesUsecase IFilesUsecase
+	nc           *nats.Conn
+	interval     time.Duration
+}
+
+func NewExpiredMessagesSweeper(messageRepo repository.IMessageRepo, filesUsecase IFilesUsecase, nc *nats.Conn, interval time.Duration) *ExpiredMessagesSweeper {
+	return &ExpiredMessagesSweeper{messageRepo: messageRepo, filesUsecase: filesUsecase, nc: nc, interval: interval}
+}
+
+// Run блокируется до отмены контекста
+func (s *ExpiredMessagesSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ExpiredMessagesSweeper) sweep(ctx context.Context) {
+	logger := utils.GetLoggerFromCtx(ctx)
+
+	if err := s.messageRepo.PurgeClientMessageIDs(ctx); err != nil {
+		logger.Error("PurgeClientMessageIDs failed", zap.Error(err))
+	}
+
+	for {
+		deleted, files, err := s.messageRepo.DeleteExpiredMessages(ctx, expiredBatchSize)
+		if err != nil {
+			logger.Error("DeleteExpiredMessages failed", zap.Error(err))
+			return
+		}
+
+		for _, msg := range deleted {
+			data, _ := json.Marshal(model.MessageEvent{Action: utils.DeleteMessage, Message: model.Message{ID: msg.ID}})
+			subj := fmt.Sprintf("chat.%s.messages", msg.ChatID.String())
+			if err := s.nc.Publish(subj, data); err != nil {
+				logger.Error("NATS publish failed", zap.String("messageID", msg.ID.String()), zap.Error(err))
+				continue
+			}
+			metrics.IncBusinessOp("expire_message")
+		}
+
+		// Строки в БД уже удалены, поэтому ошибка хранилища оставляет лишь мусорный объект
+		if len(files) > 0 {
+			if err := s.filesUsecase.PurgeFiles(ctx, files); err != nil {
+				logger.Warn("Failed to purge expired files", zap.Error(err))
+			}
+		}
+
+		if len(deleted) < expiredBatchSize {
+			return
+		}
+	}
+}
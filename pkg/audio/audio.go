@@ -0,0 +1,148 @@
+// Package audio проверяет контейнеры голосовых и аудиосообщений и достаёт
+// из них длительность и огибающую громкости для плеера. Звук не декодируется:
+// огибающая строится по размерам пакетов (в VBR-кодеках они растут с громкостью),
+// по global_gain кадров MP3 и по самим отсчётам в WAV.
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported audio format")
+	ErrInvalidAudio      = errors.New("invalid or truncated audio file")
+)
+
+// WaveformSamples — число столбиков огибающей, каждый от 0 до 255
+const WaveformSamples = 100
+
+type Info struct {
+	Format   string
+	MimeType string
+	Duration time.Duration
+	Waveform []byte
+}
+
+// Probe определяет контейнер по сигнатуре и разбирает его. Поддерживаются
+// Ogg (Opus, Vorbis), WebM/Matroska (Opus, Vorbis), MP4/M4A, MP3 и WAV (PCM).
+func Probe(data []byte) (*Info, error) {
+	var (
+		info *Info
+		err  error
+	)
+	switch {
+	case bytes.HasPrefix(data, []byte("OggS")):
+		info, err = probeOgg(data)
+	case len(data) >= 12 && bytes.Equal(data[:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WAVE")):
+		info, err = probeWAV(data)
+	case bytes.HasPrefix(data, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		info, err = probeWebM(data)
+	case len(data) >= 8 && bytes.Equal(data[4:8], []byte("ftyp")):
+		info, err = probeMP4(data)
+	case bytes.HasPrefix(data, []byte("ID3")) || len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		info, err = probeMP3(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if info.Duration <= 0 {
+		return nil, ErrInvalidAudio
+	}
+	return info, nil
+}
+
+// waveform копит значения по WaveformSamples равным отрезкам записи
+type waveform struct {
+	sum   [WaveformSamples]float64
+	count [WaveformSamples]int
+	total float64
+}
+
+func newWaveform(total float64) *waveform {
+	return &waveform{total: total}
+}
+
+// add учитывает значение в момент at (в тех же единицах, что и total)
+func (w *waveform) add(at, value float64) {
+	if w.total <= 0 {
+		return
+	}
+	i := int(at / w.total * WaveformSamples)
+	i = min(max(i, 0), WaveformSamples-1)
+	w.sum[i] += value
+	w.count[i]++
+}
+
+// bytes усредняет отрезки и растягивает их от тишины до пика на 0..255.
+// Пустые отрезки (пакет длиннее отрезка) повторяют предыдущий.
+func (w *waveform) bytes() []byte {
+	var levels [WaveformSamples]float64
+	prev := 0.0
+	for i := range levels {
+		if w.count[i] > 0 {
+			prev = w.sum[i] / float64(w.count[i])
+		}
+		levels[i] = prev
+	}
+
+	lo, hi := levels[0], levels[0]
+	for _, v := range levels {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	out := make([]byte, WaveformSamples)
+	if hi <= lo {
+		return out
+	}
+	for i, v := range levels {
+		out[i] = byte((v - lo) / (hi - lo) * 255)
+	}
+	return out
+}
+
+func secondsToDuration(s float64) time.Duration {
+	// Испорченные заголовки могут обещать что угодно; не переполняем Duration
+	if s >= math.MaxInt64/float64(time.Second) {
+		return math.MaxInt64
+	}
+	return time.Duration(s * float64(time.Second))
+}
+
+// opusPacketSamples возвращает длительность пакета Opus в отсчётах 48 кГц по его TOC (RFC 6716, 3.1)
+func opusPacketSamples(packet []byte) int {
+	if len(packet) == 0 {
+		return 0
+	}
+	toc := packet[0]
+	config := int(toc >> 3)
+
+	var frame int // в отсчётах 48 кГц
+	switch {
+	case config < 12:
+		frame = []int{480, 960, 1920, 2880}[config%4]
+	case config < 16:
+		frame = []int{480, 960}[config%2]
+	default:
+		frame = []int{120, 240, 480, 960}[config%4]
+	}
+
+	switch toc & 0x03 {
+	case 0:
+		return frame
+	case 1, 2:
+		return 2 * frame
+	default:
+		if len(packet) < 2 {
+			return 0
+		}
+		return int(packet[1]&0x3F) * frame
+	}
+}
+
+var le = binary.LittleEndian
+var be = binary.BigEndian
@@ -0,0 +1,208 @@
+package audio
+
+import (
+	"bytes"
+)
+
+var (
+	mp3BitratesV1 = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mp3BitratesV2 = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+	mp3Rates      = [3]int{44100, 48000, 32000}
+)
+
+// mp3Frame — разобранный заголовок кадра MPEG Audio Layer III
+type mp3Frame struct {
+	mpeg1     bool
+	mono      bool
+	protected bool
+	rate      int
+	samples   int
+	length    int
+}
+
+func parseMP3Header(h []byte) (mp3Frame, bool) {
+	if len(h) < 4 || h[0] != 0xFF || h[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+	version := (h[1] >> 3) & 0x03 // 3 — MPEG-1, 2 — MPEG-2, 0 — MPEG-2.5
+	layer := (h[1] >> 1) & 0x03   // 1 — Layer III
+	bitrateIdx := h[2] >> 4
+	rateIdx := (h[2] >> 2) & 0x03
+	if version == 1 || layer != 1 || rateIdx == 3 {
+		return mp3Frame{}, false
+	}
+
+	f := mp3Frame{
+		mpeg1:     version == 3,
+		mono:      h[3]>>6 == 3,
+		protected: h[1]&0x01 == 0,
+		rate:      mp3Rates[rateIdx],
+	}
+	bitrate := mp3BitratesV1[bitrateIdx]
+	f.samples = 1152
+	if !f.mpeg1 {
+		bitrate = mp3BitratesV2[bitrateIdx]
+		f.samples = 576
+		f.rate /= 2
+		if version == 0 {
+			f.rate /= 2
+		}
+	}
+	// Свободный битрейт (индекс 0) не поддерживается: длину кадра не вычислить
+	if bitrate == 0 {
+		return mp3Frame{}, false
+	}
+	padding := int(h[2]>>1) & 0x01
+	f.length = f.samples/8*bitrate*1000/f.rate + padding
+	return f, true
+}
+
+// sideInfoLength — размер side info, после которого в первом кадре VBR-файлов лежит заголовок Xing/Info
+func (f mp3Frame) sideInfoLength() int {
+	switch {
+	case f.mpeg1 && f.mono:
+		return 17
+	case f.mpeg1:
+		return 32
+	case f.mono:
+		return 9
+	}
+	return 17
+}
+
+// globalGain читает global_gain первой гранулы первого канала — грубую
+// громкость кадра, по которой строится огибающая
+func (f mp3Frame) globalGain(frame []byte) (int, bool) {
+	off := 4
+	if f.protected {
+		off += 2
+	}
+	if len(frame) < off+f.sideInfoLength() {
+		return 0, false
+	}
+	r := bitReader{data: frame[off:]}
+	if f.mpeg1 {
+		r.skip(9) // main_data_begin
+		if f.mono {
+			r.skip(5)
+			r.skip(4) // scfsi
+		} else {
+			r.skip(3)
+			r.skip(8)
+		}
+	} else {
+		r.skip(8)
+		if f.mono {
+			r.skip(1)
+		} else {
+			r.skip(2)
+		}
+	}
+	r.skip(12) // part2_3_length
+	r.skip(9)  // big_values
+	return r.read(8), true
+}
+
+func probeMP3(data []byte) (*Info, error) {
+	pos := 0
+	// ID3v2: 10 байт заголовка, размер в syncsafe-формате и, возможно, футер
+	if len(data) >= 10 && bytes.HasPrefix(data, []byte("ID3")) {
+		size := int(data[6]&0x7F)<<21 | int(data[7]&0x7F)<<14 | int(data[8]&0x7F)<<7 | int(data[9]&0x7F)
+		pos = 10 + size
+		if data[5]&0x10 != 0 {
+			pos += 10
+		}
+	}
+
+	// Ищем первый кадр, за которым сразу идёт следующий, чтобы не принять мусор за синхрослово
+	for ; pos+4 <= len(data); pos++ {
+		f, ok := parseMP3Header(data[pos:])
+		if !ok {
+			continue
+		}
+		next := pos + f.length
+		if next+4 > len(data) {
+			break
+		}
+		if _, ok := parseMP3Header(data[next:]); ok {
+			break
+		}
+	}
+
+	type gainAt struct {
+		at   int
+		gain int
+	}
+	var (
+		gains   []gainAt
+		samples int
+		rate    int
+		first   = true
+	)
+	for pos+4 <= len(data) {
+		f, ok := parseMP3Header(data[pos:])
+		if !ok || pos+f.length > len(data) {
+			break
+		}
+		frame := data[pos : pos+f.length]
+		pos += f.length
+
+		// Кадр с заголовком Xing/Info не содержит звука
+		if first {
+			first = false
+			tagAt := 4 + f.sideInfoLength()
+			if f.protected {
+				tagAt += 2
+			}
+			if len(frame) >= tagAt+4 {
+				tag := frame[tagAt : tagAt+4]
+				if bytes.Equal(tag, []byte("Xing")) || bytes.Equal(tag, []byte("Info")) {
+					continue
+				}
+			}
+		}
+
+		if gain, ok := f.globalGain(frame); ok {
+			gains = append(gains, gainAt{at: samples, gain: gain})
+		}
+		samples += f.samples
+		rate = f.rate
+	}
+	if samples == 0 {
+		return nil, ErrInvalidAudio
+	}
+
+	wf := newWaveform(float64(samples))
+	for _, g := range gains {
+		wf.add(float64(g.at), float64(g.gain))
+	}
+	return &Info{
+		Format:   "mp3",
+		MimeType: "audio/mpeg",
+		Duration: secondsToDuration(float64(samples) / float64(rate)),
+		Waveform: wf.bytes(),
+	}, nil
+}
+
+// bitReader читает биты от старшего к младшему
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) skip(n int) {
+	r.pos += n
+}
+
+func (r *bitReader) read(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		b := 0
+		if idx := r.pos / 8; idx < len(r.data) {
+			b = int(r.data[idx]>>(7-r.pos%8)) & 1
+		}
+		v = v<<1 | b
+		r.pos++
+	}
+	return v
+}
@@ -0,0 +1,323 @@
+package audio
+
+// mp4MaxSamples ограничивает число отсчётов, которое обещают таблицы, чтобы
+// испорченный файл не заставил выделить гигабайты
+const mp4MaxSamples = 1 << 22
+
+// В эти боксы заходим, остальные пропускаем целиком
+var mp4Containers = map[string]bool{
+	"moov": true, "trak": true, "mdia": true, "minf": true, "stbl": true,
+	"mvex": true, "moof": true, "traf": true,
+}
+
+type mp4Sample struct {
+	at   int64 // в единицах timescale дорожки
+	size int
+}
+
+// mp4TimeEntry — строка stts: count отсчётов подряд длятся по delta
+type mp4TimeEntry struct {
+	count uint32
+	delta int64
+}
+
+type mp4Track struct {
+	id        uint32
+	handler   string
+	timescale uint32
+	duration  uint64
+	sizes     []int
+	times     []mp4TimeEntry
+	// Значения по умолчанию для фрагментов из trex
+	defaultDuration uint32
+	defaultSize     uint32
+	samples         []mp4Sample
+	end             int64
+}
+
+type mp4Parser struct {
+	tracks []*mp4Track
+	cur    *mp4Track
+	// Состояние текущего фрагмента (traf)
+	frag         *mp4Track
+	fragDuration uint32
+	fragSize     uint32
+	fragTime     int64
+	fragTimeSet  bool
+}
+
+func probeMP4(data []byte) (*Info, error) {
+	p := &mp4Parser{}
+	if err := p.walk(data); err != nil {
+		return nil, err
+	}
+
+	var audio *mp4Track
+	for _, t := range p.tracks {
+		switch t.handler {
+		case "vide":
+			return nil, ErrUnsupportedFormat
+		case "soun":
+			if audio == nil {
+				audio = t
+			}
+		}
+	}
+	if audio == nil || audio.timescale == 0 {
+		return nil, ErrInvalidAudio
+	}
+	audio.addTableSamples()
+
+	// Во фрагментированных файлах длительность в mdhd обычно нулевая
+	total := max(int64(audio.duration), audio.end)
+	wf := newWaveform(float64(total))
+	for _, s := range audio.samples {
+		wf.add(float64(s.at), float64(s.size))
+	}
+	return &Info{
+		Format:   "mp4",
+		MimeType: "audio/mp4",
+		Duration: secondsToDuration(float64(total) / float64(audio.timescale)),
+		Waveform: wf.bytes(),
+	}, nil
+}
+
+func (p *mp4Parser) walk(data []byte) error {
+	for len(data) >= 8 {
+		size := uint64(be.Uint32(data[0:4]))
+		typ := string(data[4:8])
+		header := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return ErrInvalidAudio
+			}
+			size, header = be.Uint64(data[8:16]), 16
+		}
+		if size < header {
+			return ErrInvalidAudio
+		}
+		// Обрезанный в конце mdat не мешает: всё нужное лежит в moov
+		if size > uint64(len(data)) {
+			if typ == "mdat" {
+				return nil
+			}
+			return ErrInvalidAudio
+		}
+		body := data[header:size]
+		data = data[size:]
+
+		if err := p.box(typ, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *mp4Parser) box(typ string, body []byte) error {
+	switch typ {
+	case "trak":
+		p.cur = &mp4Track{}
+		p.tracks = append(p.tracks, p.cur)
+		err := p.walk(body)
+		p.cur = nil
+		return err
+	case "traf":
+		p.frag, p.fragDuration, p.fragSize, p.fragTimeSet = nil, 0, 0, false
+		err := p.walk(body)
+		p.frag = nil
+		return err
+	}
+	if mp4Containers[typ] {
+		return p.walk(body)
+	}
+
+	// У остальных нужных боксов первые 4 байта — версия и флаги
+	if len(body) < 4 {
+		return nil
+	}
+	version, flags := body[0], be.Uint32(body[0:4])&0xFFFFFF
+	r := &byteReader{data: body[4:]}
+
+	switch typ {
+	case "tkhd":
+		if p.cur != nil {
+			if version == 1 {
+				r.skip(16)
+			} else {
+				r.skip(8)
+			}
+			p.cur.id = r.u32()
+		}
+	case "mdhd":
+		if p.cur != nil {
+			if version == 1 {
+				r.skip(16)
+				p.cur.timescale = r.u32()
+				p.cur.duration = r.u64()
+			} else {
+				r.skip(8)
+				p.cur.timescale = r.u32()
+				p.cur.duration = uint64(r.u32())
+			}
+		}
+	case "hdlr":
+		// В QuickTime hdlr есть и в minf (обработчик данных), нужен первый — из mdia
+		if p.cur != nil && p.cur.handler == "" {
+			r.skip(4)
+			p.cur.handler = string(r.bytes(4))
+		}
+	case "stsz":
+		if p.cur != nil {
+			fixed, count := r.u32(), r.u32()
+			if count > mp4MaxSamples {
+				return ErrInvalidAudio
+			}
+			for i := uint32(0); i < count && !r.failed; i++ {
+				size := fixed
+				if fixed == 0 {
+					size = r.u32()
+				}
+				p.cur.sizes = append(p.cur.sizes, int(size))
+			}
+		}
+	case "stts":
+		if p.cur != nil {
+			entries := r.u32()
+			for i := uint32(0); i < entries && !r.failed; i++ {
+				count, delta := r.u32(), r.u32()
+				p.cur.times = append(p.cur.times, mp4TimeEntry{count: count, delta: int64(delta)})
+			}
+		}
+	case "trex":
+		id := r.u32()
+		r.skip(4)
+		if t := p.track(id); t != nil {
+			t.defaultDuration, t.defaultSize = r.u32(), r.u32()
+		}
+	case "tfhd":
+		p.frag = p.track(r.u32())
+		if p.frag == nil {
+			return nil
+		}
+		p.fragDuration, p.fragSize = p.frag.defaultDuration, p.frag.defaultSize
+		if flags&0x01 != 0 {
+			r.skip(8)
+		}
+		if flags&0x02 != 0 {
+			r.skip(4)
+		}
+		if flags&0x08 != 0 {
+			p.fragDuration = r.u32()
+		}
+		if flags&0x10 != 0 {
+			p.fragSize = r.u32()
+		}
+	case "tfdt":
+		if version == 1 {
+			p.fragTime = int64(r.u64())
+		} else {
+			p.fragTime = int64(r.u32())
+		}
+		p.fragTimeSet = true
+	case "trun":
+		if p.frag == nil {
+			return nil
+		}
+		if !p.fragTimeSet {
+			p.fragTime, p.fragTimeSet = p.frag.end, true
+		}
+		count := r.u32()
+		if count > mp4MaxSamples || len(p.frag.samples)+int(count) > mp4MaxSamples {
+			return ErrInvalidAudio
+		}
+		if flags&0x01 != 0 {
+			r.skip(4)
+		}
+		if flags&0x04 != 0 {
+			r.skip(4)
+		}
+		for i := uint32(0); i < count && !r.failed; i++ {
+			duration, size := p.fragDuration, p.fragSize
+			if flags&0x100 != 0 {
+				duration = r.u32()
+			}
+			if flags&0x200 != 0 {
+				size = r.u32()
+			}
+			if flags&0x400 != 0 {
+				r.skip(4)
+			}
+			if flags&0x800 != 0 {
+				r.skip(4)
+			}
+			if r.failed {
+				break
+			}
+			p.frag.samples = append(p.frag.samples, mp4Sample{at: p.fragTime, size: int(size)})
+			p.fragTime += int64(duration)
+			p.frag.end = max(p.frag.end, p.fragTime)
+		}
+	}
+	if r.failed {
+		return ErrInvalidAudio
+	}
+	return nil
+}
+
+func (p *mp4Parser) track(id uint32) *mp4Track {
+	for _, t := range p.tracks {
+		if t.id == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// addTableSamples переводит таблицы stsz/stts обычного (не фрагментированного) файла в отсчёты
+func (t *mp4Track) addTableSamples() {
+	var at int64
+	entry, used := 0, uint32(0)
+	for _, size := range t.sizes {
+		t.samples = append(t.samples, mp4Sample{at: at, size: size})
+		for entry < len(t.times) && used == t.times[entry].count {
+			entry, used = entry+1, 0
+		}
+		if entry < len(t.times) {
+			at += t.times[entry].delta
+			used++
+		}
+	}
+	t.end = max(t.end, at)
+}
+
+// byteReader читает big-endian числа и запоминает выход за границу вместо паники
+type byteReader struct {
+	data   []byte
+	pos    int
+	failed bool
+}
+
+func (r *byteReader) bytes(n int) []byte {
+	if r.failed || r.pos+n > len(r.data) {
+		r.failed = true
+		return make([]byte, n)
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *byteReader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *byteReader) u32() uint32 {
+	return be.Uint32(r.bytes(4))
+}
+
+func (r *byteReader) u64() uint64 {
+	return be.Uint64(r.bytes(8))
+}
@@ -0,0 +1,152 @@
+package audio
+
+import (
+	"bytes"
+)
+
+// oggPacket — собранный из сегментов пакет логического потока Ogg
+type oggPacket struct {
+	head    []byte // первые байты пакета: заголовки кодека и TOC Opus
+	size    int
+	granule int64 // позиция страницы, на которой пакет закончился
+	page    int
+}
+
+const oggHeadBytes = 32
+
+func probeOgg(data []byte) (*Info, error) {
+	packets, lastGranule, err := readOggPackets(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(packets) == 0 {
+		return nil, ErrInvalidAudio
+	}
+
+	head := packets[0].head
+	switch {
+	case bytes.HasPrefix(head, []byte("OpusHead")):
+		if len(head) < 12 || len(packets) < 2 {
+			return nil, ErrInvalidAudio
+		}
+		preSkip := int64(le.Uint16(head[10:12]))
+		return opusInOgg(packets[2:], lastGranule-preSkip), nil
+	case bytes.HasPrefix(head, []byte("\x01vorbis")):
+		if len(head) < 16 || len(packets) < 3 {
+			return nil, ErrInvalidAudio
+		}
+		rate := int64(le.Uint32(head[12:16]))
+		if rate == 0 {
+			return nil, ErrInvalidAudio
+		}
+		return vorbisInOgg(packets[3:], lastGranule, rate), nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// readOggPackets собирает пакеты первого логического потока и возвращает
+// последнюю известную позицию (granule position) в отсчётах
+func readOggPackets(data []byte) ([]oggPacket, int64, error) {
+	var (
+		packets     []oggPacket
+		cur         oggPacket
+		lastGranule int64
+		serial      []byte
+		page        int
+	)
+	for pos := 0; pos < len(data); {
+		if len(data)-pos < 27 || !bytes.Equal(data[pos:pos+4], []byte("OggS")) {
+			return nil, 0, ErrInvalidAudio
+		}
+		segments := int(data[pos+26])
+		bodyStart := pos + 27 + segments
+		if bodyStart > len(data) {
+			return nil, 0, ErrInvalidAudio
+		}
+		lacing := data[pos+27 : bodyStart]
+		bodyEnd := bodyStart
+		for _, l := range lacing {
+			bodyEnd += int(l)
+		}
+		if bodyEnd > len(data) {
+			return nil, 0, ErrInvalidAudio
+		}
+
+		pageSerial := data[pos+14 : pos+18]
+		if serial == nil {
+			serial = pageSerial
+		}
+		if !bytes.Equal(serial, pageSerial) {
+			pos = bodyEnd
+			continue
+		}
+
+		granule := int64(le.Uint64(data[pos+6 : pos+14]))
+		off := bodyStart
+		for _, l := range lacing {
+			segment := data[off : off+int(l)]
+			if room := oggHeadBytes - len(cur.head); room > 0 {
+				cur.head = append(cur.head, segment[:min(room, len(segment))]...)
+			}
+			cur.size += int(l)
+			off += int(l)
+			if l < 255 {
+				cur.granule, cur.page = granule, page
+				packets = append(packets, cur)
+				cur = oggPacket{}
+			}
+		}
+		// -1 означает, что на странице не закончился ни один пакет
+		if granule >= 0 {
+			lastGranule = granule
+		}
+		page++
+		pos = bodyEnd
+	}
+	return packets, lastGranule, nil
+}
+
+func opusInOgg(packets []oggPacket, samples int64) *Info {
+	var total int64
+	for _, p := range packets {
+		total += int64(opusPacketSamples(p.head))
+	}
+	wf := newWaveform(float64(total))
+	var at int64
+	for _, p := range packets {
+		wf.add(float64(at), float64(p.size))
+		at += int64(opusPacketSamples(p.head))
+	}
+	return &Info{
+		Format:   "ogg/opus",
+		MimeType: "audio/ogg",
+		Duration: secondsToDuration(float64(samples) / 48000),
+		Waveform: wf.bytes(),
+	}
+}
+
+// vorbisInOgg раскладывает пакеты страницы равномерно между её позицией
+// и позицией предыдущей: длительность пакета Vorbis без декодирования не узнать
+func vorbisInOgg(packets []oggPacket, samples, rate int64) *Info {
+	wf := newWaveform(float64(samples))
+	var prevGranule int64
+	for i := 0; i < len(packets); {
+		j := i
+		for j < len(packets) && packets[j].page == packets[i].page {
+			j++
+		}
+		granule := packets[i].granule
+		for k := i; k < j; k++ {
+			at := prevGranule + (granule-prevGranule)*int64(k-i)/int64(j-i)
+			wf.add(float64(at), float64(packets[k].size))
+		}
+		prevGranule = granule
+		i = j
+	}
+	return &Info{
+		Format:   "ogg/vorbis",
+		MimeType: "audio/ogg",
+		Duration: secondsToDuration(float64(samples) / float64(rate)),
+		Waveform: wf.bytes(),
+	}
+}
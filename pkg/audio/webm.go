@@ -0,0 +1,232 @@
+package audio
+
+import (
+	"math"
+)
+
+// Идентификаторы элементов Matroska, нужные для разбора
+const (
+	ebmlSegment       = 0x18538067
+	ebmlInfo          = 0x1549A966
+	ebmlTimecodeScale = 0x2AD7B1
+	ebmlDuration      = 0x4489
+	ebmlTracks        = 0x1654AE6B
+	ebmlTrackEntry    = 0xAE
+	ebmlTrackNumber   = 0xD7
+	ebmlTrackType     = 0x83
+	ebmlCodecID       = 0x86
+	ebmlCluster       = 0x1F43B675
+	ebmlTimecode      = 0xE7
+	ebmlBlockGroup    = 0xA0
+	ebmlBlock         = 0xA1
+	ebmlSimpleBlock   = 0xA3
+)
+
+const (
+	matroskaTrackVideo = 1
+	matroskaTrackAudio = 2
+)
+
+// Разбор идёт плоско: в перечисленные контейнеры заходим, остальное пропускаем.
+// Так проще обработать элементы неизвестного размера, которые пишет MediaRecorder.
+var ebmlMasters = map[uint64]bool{
+	ebmlSegment: true, ebmlInfo: true, ebmlTracks: true, ebmlTrackEntry: true,
+	ebmlCluster: true, ebmlBlockGroup: true,
+}
+
+type webmTrack struct {
+	number  uint64
+	typ     uint64
+	codecID string
+}
+
+type webmBlock struct {
+	track uint64
+	at    int64 // в единицах TimecodeScale
+	size  int
+	head  []byte
+}
+
+func probeWebM(data []byte) (*Info, error) {
+	var (
+		scale    int64 = 1000000
+		duration float64
+		tracks   []*webmTrack
+		blocks   []webmBlock
+		cluster  int64
+	)
+
+	for pos := 0; pos < len(data); {
+		id, n := readEBMLID(data[pos:])
+		if n == 0 {
+			break
+		}
+		size, m := readEBMLSize(data[pos+n:])
+		if m == 0 {
+			break
+		}
+		pos += n + m
+
+		if ebmlMasters[id] {
+			if id == ebmlTrackEntry {
+				tracks = append(tracks, &webmTrack{})
+			}
+			continue
+		}
+		if size < 0 || int64(len(data)-pos) < size {
+			break
+		}
+		body := data[pos : pos+int(size)]
+		pos += int(size)
+
+		switch id {
+		case ebmlTimecodeScale:
+			scale = int64(readEBMLUint(body))
+		case ebmlDuration:
+			if d := readEBMLFloat(body); d > 0 && !math.IsInf(d, 0) {
+				duration = d
+			}
+		case ebmlTrackNumber, ebmlTrackType, ebmlCodecID:
+			if len(tracks) == 0 {
+				return nil, ErrInvalidAudio
+			}
+			t := tracks[len(tracks)-1]
+			switch id {
+			case ebmlTrackNumber:
+				t.number = readEBMLUint(body)
+			case ebmlTrackType:
+				t.typ = readEBMLUint(body)
+			default:
+				t.codecID = string(body)
+			}
+		case ebmlTimecode:
+			cluster = int64(readEBMLUint(body))
+		case ebmlSimpleBlock, ebmlBlock:
+			track, k := readEBMLSize(body)
+			if k == 0 || len(body) < k+3 {
+				return nil, ErrInvalidAudio
+			}
+			rel := int64(int16(be.Uint16(body[k : k+2])))
+			frame := body[k+3:]
+			blocks = append(blocks, webmBlock{
+				track: uint64(track),
+				at:    cluster + rel,
+				size:  len(frame),
+				head:  frame[:min(len(frame), 2)],
+			})
+		}
+	}
+
+	var audio *webmTrack
+	for _, t := range tracks {
+		switch t.typ {
+		case matroskaTrackVideo:
+			return nil, ErrUnsupportedFormat
+		case matroskaTrackAudio:
+			if audio == nil {
+				audio = t
+			}
+		}
+	}
+	if audio == nil || scale <= 0 {
+		return nil, ErrInvalidAudio
+	}
+	opus := audio.codecID == "A_OPUS"
+	if !opus && audio.codecID != "A_VORBIS" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// MediaRecorder не пишет Duration, тогда конец записи — конец последнего блока
+	var end int64
+	var own []webmBlock
+	for _, b := range blocks {
+		if b.track != audio.number {
+			continue
+		}
+		own = append(own, b)
+		blockEnd := b.at
+		if opus {
+			blockEnd += int64(opusPacketSamples(b.head)) * 1e9 / 48000 / scale
+		}
+		end = max(end, blockEnd)
+	}
+	total := math.Max(duration, float64(end))
+
+	wf := newWaveform(total)
+	for _, b := range own {
+		wf.add(float64(b.at), float64(b.size))
+	}
+
+	format, mimeType := "webm/vorbis", "audio/webm"
+	if opus {
+		format = "webm/opus"
+	}
+	return &Info{
+		Format:   format,
+		MimeType: mimeType,
+		Duration: secondsToDuration(total * float64(scale) / 1e9),
+		Waveform: wf.bytes(),
+	}, nil
+}
+
+// readEBMLID читает идентификатор элемента вместе с маркером длины
+func readEBMLID(b []byte) (uint64, int) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0
+	}
+	n := 1
+	for mask := byte(0x80); b[0]&mask == 0; mask >>= 1 {
+		n++
+	}
+	if n > 4 || len(b) < n {
+		return 0, 0
+	}
+	var id uint64
+	for _, c := range b[:n] {
+		id = id<<8 | uint64(c)
+	}
+	return id, n
+}
+
+// readEBMLSize читает размер без маркера; -1 — неизвестный размер
+func readEBMLSize(b []byte) (int64, int) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0
+	}
+	n := 1
+	mask := byte(0x80)
+	for ; b[0]&mask == 0; mask >>= 1 {
+		n++
+	}
+	if len(b) < n {
+		return 0, 0
+	}
+	v := uint64(b[0] & (mask - 1))
+	unknown := v == uint64(mask-1)
+	for _, c := range b[1:n] {
+		v = v<<8 | uint64(c)
+		unknown = unknown && c == 0xFF
+	}
+	if unknown {
+		return -1, n
+	}
+	return int64(v), n
+}
+
+func readEBMLUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func readEBMLFloat(b []byte) float64 {
+	switch len(b) {
+	case 4:
+		return float64(math.Float32frombits(be.Uint32(b)))
+	case 8:
+		return math.Float64frombits(be.Uint64(b))
+	}
+	return 0
+}
@@ -0,0 +1,271 @@
+// pkg/linkpreview/fetcher.go
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrHostNotAllowed   = errors.New("host is not allowed")
+	ErrUnsupportedURL   = errors.New("only absolute http(s) urls are supported")
+	ErrNotHTML          = errors.New("response is not html")
+	ErrBadStatus        = errors.New("unexpected response status")
+	ErrNothingToShow    = errors.New("page has no preview metadata")
+	ErrTooManyRedirects = errors.New("too many redirects")
+)
+
+// Preview — метаданные страницы для карточки ссылки
+type Preview struct {
+	URL         string
+	Title       string
+	Description string
+	ImageURL    string
+	SiteName    string
+}
+
+type Config struct {
+	// Client выполняет запросы; если не задан, создаётся клиент,
+	// который не ходит в приватные сети (см. AllowPrivateNetworks)
+	Client *http.Client
+	// Timeout ограничивает весь запрос вместе с чтением тела
+	Timeout time.Duration
+	// MaxBodySize — сколько байт страницы читать в поисках метаданных
+	MaxBodySize int64
+	// Allow — если не пуст, разрешены только эти домены и их поддомены
+	Allow []string
+	// Deny — запрещённые домены и их поддомены, проверяются раньше Allow
+	Deny []string
+	// AllowPrivateNetworks разрешает loopback и приватные адреса
+	AllowPrivateNetworks bool
+	// CacheTTL и CacheSize задают кэш результатов по URL, включая неудачи
+	CacheTTL  time.Duration
+	CacheSize int
+}
+
+type IFetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*Preview, error)
+}
+
+type cacheEntry struct {
+	preview   *Preview
+	err       error
+	expiresAt time.Time
+}
+
+type fetcher struct {
+	cfg    Config
+	client *http.Client
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+const maxRedirects = 3
+
+func NewFetcher(cfg Config) IFetcher {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = 512 << 10
+	}
+	if cfg.CacheSize <= 0 {
+		cfg.CacheSize = 1000
+	}
+
+	f := &fetcher{cfg: cfg, cache: make(map[string]cacheEntry)}
+
+	client := cfg.Client
+	if client == nil {
+		dialer := &net.Dialer{Timeout: cfg.Timeout}
+		if !cfg.AllowPrivateNetworks {
+			dialer.Control = denyPrivateAddress
+		}
+		// Прокси не используется: иначе denyPrivateAddress проверил бы адрес
+		// прокси, а внутренние URL загрузились бы через него
+		client = &http.Client{Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   cfg.Timeout,
+			ResponseHeaderTimeout: cfg.Timeout,
+		}}
+	}
+	// Копия, чтобы не менять переданный клиент: редиректы тоже проходят allow/deny
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return ErrTooManyRedirects
+		}
+		return f.checkURL(req.URL)
+	}
+	f.client = &c
+
+	return f
+}
+
+// Fetch возвращает превью страницы; результат, в том числе ошибка,
+// кэшируется по URL на CacheTTL
+func (f *fetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	if entry, ok := f.cached(rawURL); ok {
+		if entry.err != nil {
+			return nil, entry.err
+		}
+		preview := *entry.preview
+		return &preview, nil
+	}
+
+	preview, err := f.fetch(ctx, rawURL)
+	// Отмену вызывающим не запоминаем: страница тут ни при чём
+	if ctx.Err() == nil {
+		f.store(rawURL, preview, err)
+	}
+	return preview, err
+}
+
+func (f *fetcher) fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, ErrUnsupportedURL
+	}
+	if err := f.checkURL(u); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, ErrUnsupportedURL
+	}
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", "VelvetPulls-LinkPreview/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", ErrBadStatus, resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	preview := parseHTML(io.LimitReader(resp.Body, f.cfg.MaxBodySize), resp.Request.URL)
+	if preview.Title == "" && preview.Description == "" && preview.ImageURL == "" {
+		return nil, ErrNothingToShow
+	}
+	preview.URL = rawURL
+	return preview, nil
+}
+
+// checkURL пропускает только http(s) и домены, разрешённые списками
+func (f *fetcher) checkURL(u *url.URL) error {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrUnsupportedURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+
+	for _, d := range f.cfg.Deny {
+		if matchDomain(host, d) {
+			return ErrHostNotAllowed
+		}
+	}
+	if len(f.cfg.Allow) == 0 {
+		return nil
+	}
+	for _, a := range f.cfg.Allow {
+		if matchDomain(host, a) {
+			return nil
+		}
+	}
+	return ErrHostNotAllowed
+}
+
+func matchDomain(host, domain string) bool {
+	domain = strings.ToLower(strings.Trim(strings.TrimSpace(domain), "."))
+	if domain == "" {
+		return false
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// denyPrivateAddress не даёт подключиться к внутренним адресам,
+// даже если публичное имя разрешилось в них
+func denyPrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+		return ErrHostNotAllowed
+	}
+	return nil
+}
+
+func (f *fetcher) cached(rawURL string) (cacheEntry, bool) {
+	if f.cfg.CacheTTL <= 0 {
+		return cacheEntry{}, false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entry, ok := f.cache[rawURL]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(f.cache, rawURL)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (f *fetcher) store(rawURL string, preview *Preview, err error) {
+	if f.cfg.CacheTTL <= 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	if len(f.cache) >= f.cfg.CacheSize {
+		// Сначала выбрасываем устаревшие записи, а если их нет — любую
+		for key, entry := range f.cache {
+			if now.After(entry.expiresAt) {
+				delete(f.cache, key)
+			}
+		}
+		for key := range f.cache {
+			if len(f.cache) < f.cfg.CacheSize {
+				break
+			}
+			delete(f.cache, key)
+		}
+	}
+	entry := cacheEntry{err: err, expiresAt: now.Add(f.cfg.CacheTTL)}
+	if preview != nil {
+		p := *preview
+		entry.preview = &p
+	}
+	f.cache[rawURL] = entry
+}
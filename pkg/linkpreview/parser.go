@@ -0,0 +1,133 @@
+// pkg/linkpreview/parser.go
+package linkpreview
+
+import (
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxTitleLength       = 300
+	maxDescriptionLength = 1000
+)
+
+// parseHTML читает <head> страницы и собирает Open Graph метаданные,
+// откатываясь к <title> и meta description. Разбор останавливается
+// на <body> — всё нужное находится раньше.
+func parseHTML(r io.Reader, base *url.URL) *Preview {
+	var og, fallback Preview
+	z := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return merge(og, fallback, base)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tok := z.Token()
+			switch tok.DataAtom {
+			case atom.Body:
+				return merge(og, fallback, base)
+			case atom.Title:
+				inTitle = fallback.Title == ""
+			case atom.Meta:
+				readMeta(tok, &og, &fallback)
+			}
+
+		case html.EndTagToken:
+			if z.Token().DataAtom == atom.Head {
+				return merge(og, fallback, base)
+			}
+			inTitle = false
+
+		case html.TextToken:
+			if inTitle {
+				fallback.Title += string(z.Text())
+			}
+		}
+	}
+}
+
+func readMeta(tok html.Token, og, fallback *Preview) {
+	var key, content string
+	for _, a := range tok.Attr {
+		switch strings.ToLower(a.Key) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(a.Val)
+			}
+		case "content":
+			content = a.Val
+		}
+	}
+
+	switch key {
+	case "og:title":
+		og.Title = content
+	case "og:description":
+		og.Description = content
+	case "og:image", "og:image:url":
+		if og.ImageURL == "" {
+			og.ImageURL = content
+		}
+	case "og:site_name":
+		og.SiteName = content
+	case "description":
+		fallback.Description = content
+	case "twitter:image":
+		fallback.ImageURL = content
+	}
+}
+
+func merge(og, fallback Preview, base *url.URL) *Preview {
+	p := &Preview{
+		Title:       firstNonEmpty(og.Title, fallback.Title),
+		Description: firstNonEmpty(og.Description, fallback.Description),
+		SiteName:    og.SiteName,
+	}
+	p.Title = truncate(p.Title, maxTitleLength)
+	p.Description = truncate(p.Description, maxDescriptionLength)
+	p.SiteName = truncate(p.SiteName, maxTitleLength)
+	p.ImageURL = resolveImage(firstNonEmpty(og.ImageURL, fallback.ImageURL), base)
+	return p
+}
+
+// resolveImage приводит адрес картинки к абсолютному http(s)
+func resolveImage(raw string, base *url.URL) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u := base.ResolveReference(ref)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	return u.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncate(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
@@ -12,10 +12,32 @@ const (
 	AddUsers    = "addUsers"
 	RemoveUsers = "removeUsers"
 	LeaveChat   = "leaveChat"
+
+	PinMessage   = "pinMessage"
+	UnpinMessage = "unpinMessage"
+
+	DraftUpdated = "draftUpdated"
+
+	ExportUpdated = "exportUpdated"
 )
 
 const (
 	NewMessage    = "newMessage"
 	UpdateMessage = "updateMessage"
 	DeleteMessage = "deleteMessage"
+	// DeleteMessages и ClearHistory — пакетные удаления одним событием
+	DeleteMessages = "deleteMessages"
+	ClearHistory   = "clearHistory"
+
+	UpdateReactions = "updateReactions"
+	ReadMessages    = "readMessages"
+	Mention         = "mention"
+	UpdatePoll      = "updatePoll"
+)
+
+const (
+	// Typing и StopTyping присылает клиент по вебсокету, сервис раздаёт их
+	// остальным участникам чата через NATS; в базу они не попадают
+	Typing     = "typing"
+	StopTyping = "stopTyping"
 )
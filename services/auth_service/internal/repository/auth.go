@@ -41,13 +41,20 @@ func (r *authRepo) CreateUser(ctx context.Context, user *model.User) (uuid.UUID,
 		return uuid.Nil, ErrEmptyField
 	}
 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("BeginTx failed", zap.Error(err))
+		return uuid.Nil, ErrDatabaseOperation
+	}
+
 	// Убираем поле birth_date из запроса
 	query := `INSERT INTO public.user (username, password, name) 
               VALUES ($1, $2, $3) RETURNING id`
 	var userID uuid.UUID
 
-	err := r.db.QueryRowContext(ctx, query, user.Username, user.Password, user.Name).Scan(&userID)
+	err = tx.QueryRowContext(ctx, query, user.Username, user.Password, user.Name).Scan(&userID)
 	if err != nil {
+		rollbackTx(logger, tx)
 		if strings.Contains(err.Error(), "duplicate key value") {
 			logger.Warn("User already exists", zap.Error(err))
 			return uuid.Nil, ErrRecordAlreadyExists
@@ -56,6 +63,27 @@ func (r *authRepo) CreateUser(ctx context.Context, user *model.User) (uuid.UUID,
 		return uuid.Nil, ErrDatabaseOperation
 	}
 
+	// Вместе с пользователем создаём его «Избранное» — диалог с самим собой
+	_, err = tx.ExecContext(ctx, `
+		WITH saved AS (
+			INSERT INTO chat (type, title, saved_by)
+			VALUES ('dialog', $2, $1)
+			RETURNING id
+		)
+		INSERT INTO user_chat (user_id, chat_id, user_role)
+		SELECT $1, id, 'owner' FROM saved
+	`, userID, user.Username)
+	if err != nil {
+		rollbackTx(logger, tx)
+		logger.Error("Create saved messages chat failed", zap.Error(err))
+		return uuid.Nil, ErrDatabaseOperation
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Error("Commit failed", zap.Error(err))
+		return uuid.Nil, ErrDatabaseOperation
+	}
+
 	logger.Info("User created", zap.String("user_id", userID.String()))
 	return userID, nil
 }
@@ -103,3 +131,9 @@ func (r *authRepo) GetUserByPhone(ctx context.Context, phone string) (*model.Use
 func (r *authRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
 	return r.getUserByField(ctx, "id", id.String())
 }
+
+func rollbackTx(logger *zap.Logger, tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil {
+		logger.Error("Rollback failed", zap.Error(err))
+	}
+}
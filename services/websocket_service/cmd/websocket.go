@@ -1,6 +1,7 @@
 package main
 
 import (
+	"database/sql"
 	"log"
 	"time"
 
@@ -27,6 +28,21 @@ func main() {
 	}
 	defer nc.Close()
 
+	// Подключение к БД для индекса членства в чатах
+	dbConn, err := sql.Open("postgres", config.GetPostgresDSN())
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	dbConn.SetMaxOpenConns(5)
+	dbConn.SetMaxIdleConns(2)
+	dbConn.SetConnMaxLifetime(30 * time.Minute)
+
+	if err := dbConn.Ping(); err != nil {
+		log.Fatal("Failed to ping database:", err)
+	}
+	defer dbConn.Close()
+
 	authConn, errAuth := grpc.NewClient("auth:8081", grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if errAuth != nil {
 		log.Fatalf("Failed to connect to AuthService: %v", errAuth)
@@ -34,7 +50,7 @@ func main() {
 	defer authConn.Close()
 
 	log.Printf("Starting server on :8082")
-	s := server.NewServer(nc, authConn)
+	s := server.NewServer(nc, authConn, dbConn)
 	if err := s.Run(":8082"); err != nil {
 		log.Fatal("Failed to run server:", err)
 	}
@@ -2,9 +2,9 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/middleware"
@@ -12,12 +12,16 @@ import (
 	authpb "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/auth_service/proto"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/model"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/usecase"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/nats-io/nats.go"
 	"go.uber.org/zap"
 )
 
+// maxClientFrameSize — клиент присылает только короткие служебные кадры
+const maxClientFrameSize = 4 << 10
+
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
@@ -53,7 +57,8 @@ func (c *WebsocketController) WebsocketConnection(w http.ResponseWriter, r *http
 	userID := utils.GetUserIDFromCtx(r.Context())
 	eventChan := make(chan model.AnyEvent, 100)
 
-	if err := c.websocketUsecase.RegisterUserChannel(userID, eventChan); err != nil {
+	// События чатов раздаёт usecase по индексу членства, здесь достаточно зарегистрировать канал
+	if err := c.websocketUsecase.RegisterUserChannel(r.Context(), userID, eventChan); err != nil {
 		logger.Error("RegisterUserChannel failed", zap.Error(err))
 		conn.Close()
 		return
@@ -81,49 +86,16 @@ func (c *WebsocketController) WebsocketConnection(w http.ResponseWriter, r *http
 		}
 	}()
 
-	// Подписка на все события чата через wildcard
-	subChat, err := c.nc.Subscribe("chat.*.*", func(msg *nats.Msg) {
-		parts := strings.Split(msg.Subject, ".")
-		if len(parts) != 3 {
-			return
-		}
-		kind := parts[2] // "messages" или "events"
-		switch kind {
-		case "messages":
-			var me model.MessageEvent
-			if err := json.Unmarshal(msg.Data, &me); err != nil {
-				logger.Error("unmarshal message event", zap.Error(err))
-				return
-			}
-			c.handleMessageEvent(me, eventChan)
-
-		case "events":
-			var ce model.ChatEvent
-			if err := json.Unmarshal(msg.Data, &ce); err != nil {
-				logger.Error("unmarshal chat event", zap.Error(err))
-				return
-			}
-			c.handleChatEvent(ce, eventChan)
-		}
-	})
-	if err != nil {
-		logger.Error("Subscribe chat.*.* failed", zap.Error(err))
-		conn.Close()
-		return
-	}
-	defer func() {
-		if err := subChat.Unsubscribe(); err != nil {
-			logger.Error("Failed to unsubscribe chat subscription", zap.Error(err))
-		}
-	}()
-
+	conn.SetReadLimit(maxClientFrameSize)
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
 		for {
-			if _, _, err := conn.ReadMessage(); err != nil {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
 				return
 			}
+			c.handleClientFrame(logger, userID, data)
 		}
 	}()
 
@@ -155,14 +127,26 @@ func (c *WebsocketController) WebsocketConnection(w http.ResponseWriter, r *http
 	}
 }
 
-func (c *WebsocketController) handleUserEvent(event model.AnyEvent, eventChan chan model.AnyEvent) {
-	eventChan <- event
-}
+// handleClientFrame разбирает кадр от клиента. Неизвестные, чужие и
+// слишком частые кадры отбрасываются, соединение при этом не рвётся.
+func (c *WebsocketController) handleClientFrame(logger *zap.Logger, userID uuid.UUID, data []byte) {
+	var frame model.ClientFrame
+	if err := json.Unmarshal(data, &frame); err != nil {
+		logger.Debug("invalid client frame", zap.Error(err))
+		return
+	}
 
-func (c *WebsocketController) handleMessageEvent(event model.MessageEvent, eventChan chan model.AnyEvent) {
-	eventChan <- model.AnyEvent{TypeOfEvent: event.Action, Event: event}
+	switch frame.Action {
+	case utils.Typing, utils.StopTyping:
+		err := c.websocketUsecase.HandleTyping(userID, frame.ChatID, frame.Action == utils.Typing)
+		if err != nil && !errors.Is(err, model.ErrTypingThrottled) {
+			logger.Warn("typing frame rejected", zap.String("chatID", frame.ChatID.String()), zap.Error(err))
+		}
+	default:
+		logger.Debug("unknown client frame", zap.String("action", frame.Action))
+	}
 }
 
-func (c *WebsocketController) handleChatEvent(event model.ChatEvent, eventChan chan model.AnyEvent) {
-	eventChan <- model.AnyEvent{TypeOfEvent: event.Action, Event: event}
+func (c *WebsocketController) handleUserEvent(event model.AnyEvent, eventChan chan model.AnyEvent) {
+	eventChan <- event
 }
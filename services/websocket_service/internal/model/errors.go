@@ -3,5 +3,8 @@ package model
 import "errors"
 
 var (
-	ErrValidation = errors.New("validation error")
+	ErrValidation      = errors.New("validation error")
+	ErrNotChatMember   = errors.New("user is not a member of the chat")
+	ErrCannotSend      = errors.New("user cannot send messages to the chat")
+	ErrTypingThrottled = errors.New("typing event throttled")
 )
@@ -8,19 +8,66 @@ import (
 )
 
 type Message struct {
-	ID              uuid.UUID  `json:"id,omitempty"`
-	ParentMessageID *uuid.UUID `json:"parent_message_id,omitempty"`
-	ChatID          uuid.UUID  `json:"chat_id,omitempty"`
-	UserID          uuid.UUID  `json:"user_id,omitempty"`
-	Body            string     `json:"body,omitempty"`
-	SentAt          time.Time  `json:"sent_at,omitempty"`
-	IsRedacted      bool       `json:"is_redacted,omitempty"`
-	AvatarPath      *string    `json:"avatar_path,omitempty"`
-	Username        string     `json:"user,omitempty"`
-	FilesDTO        []Payload  `json:"files,omitempty" valid:"-"`
-	PhotosDTO       []Payload  `json:"photos,omitempty" valid:"-"`
+	ID              uuid.UUID             `json:"id,omitempty"`
+	ParentMessageID *uuid.UUID            `json:"parent_message_id,omitempty"`
+	ChatID          uuid.UUID             `json:"chat_id,omitempty"`
+	UserID          uuid.UUID             `json:"user_id,omitempty"`
+	Body            string                `json:"body,omitempty"`
+	Entities        []model.MessageEntity `json:"entities,omitempty"`
+	SentAt          time.Time             `json:"sent_at,omitempty"`
+	IsRedacted      bool                  `json:"is_redacted,omitempty"`
+	EditCount       int                   `json:"edit_count,omitempty"`
+	EditedAt        *time.Time            `json:"edited_at,omitempty"`
+	AvatarPath      *string               `json:"avatar_path,omitempty"`
+	Username        string                `json:"user,omitempty"`
+	FilesDTO        []Payload             `json:"files,omitempty" valid:"-"`
+	PhotosDTO       []Payload             `json:"photos,omitempty" valid:"-"`
+	VoiceDTO        *Payload              `json:"voice,omitempty" valid:"-"`
 
 	Sticker string `json:"sticker" valid:"optional,length(0|255)"`
+
+	Reactions []ReactionCount `json:"reactions,omitempty" valid:"-"`
+
+	Parent     *ParentPreview `json:"parent,omitempty" valid:"-"`
+	ReplyCount int            `json:"reply_count,omitempty" valid:"-"`
+
+	Forward *ForwardInfo `json:"forward,omitempty" valid:"-"`
+
+	ExpiresAt *time.Time `json:"expires_at,omitempty" valid:"-"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" valid:"-"`
+
+	ClientMessageID string `json:"client_message_id,omitempty" valid:"-"`
+
+	LinkPreview *model.LinkPreview `json:"link_preview,omitempty" valid:"-"`
+
+	Poll *model.Poll `json:"poll,omitempty" valid:"-"`
+}
+
+type ForwardInfo struct {
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	Username string     `json:"user,omitempty"`
+	ChatID   *uuid.UUID `json:"chat_id,omitempty"`
+	SentAt   time.Time  `json:"sent_at"`
+}
+
+type ParentPreview struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"user,omitempty"`
+	Body        string    `json:"body,omitempty"`
+	MessageType string    `json:"message_type,omitempty"`
+}
+
+type ReactionCount struct {
+	Reaction string `json:"reaction"`
+	Count    int    `json:"count"`
+}
+
+type ReadState struct {
+	ChatID            uuid.UUID `json:"chat_id"`
+	UserID            uuid.UUID `json:"user_id"`
+	LastReadMessageID uuid.UUID `json:"last_read_message_id"`
+	ReadAt            time.Time `json:"read_at"`
 }
 
 type Payload struct {
@@ -28,6 +75,8 @@ type Payload struct {
 	Filename    string
 	ContentType string
 	Size        int64
+	DurationMs  int    `json:",omitempty"`
+	Waveform    []byte `json:",omitempty"`
 }
 
 type Chat struct {
@@ -37,6 +86,18 @@ type Chat struct {
 	Title       string             `json:"title" valid:"required~Title is required,length(1|100)"`
 	LastMessage *model.LastMessage `json:"last_message,omitempty"`
 	CountUsers  int                `json:"count_users" valid:"range(0|5000)"`
+	Pins        []PinnedMessage    `json:"pins,omitempty"`
+	MessageTTL  *int               `json:"message_ttl,omitempty"`
+}
+
+type PinnedMessage struct {
+	MessageID   uuid.UUID  `json:"message_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Username    string     `json:"user,omitempty"`
+	Body        string     `json:"body,omitempty"`
+	MessageType string     `json:"message_type,omitempty"`
+	PinnedBy    *uuid.UUID `json:"pinned_by,omitempty"`
+	PinnedAt    time.Time  `json:"pinned_at"`
 }
 
 type UserInChat struct {
@@ -1,10 +1,31 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/google/uuid"
+)
+
+// ChatMember — участие пользователя в чате. CanSend ложно у подписчиков
+// каналов: писать в канал, а значит и печатать в нём, может только владелец.
+type ChatMember struct {
+	ChatID  uuid.UUID
+	UserID  uuid.UUID
+	CanSend bool
+}
 
 type MessageEvent struct {
 	Action  string  `json:"action"`
 	Message Message `json:"payload"`
+	Mode    string  `json:"mode,omitempty"`
+}
+
+// MessagesDeletedEvent — пакетное удаление сообщений или очистка истории
+type MessagesDeletedEvent struct {
+	Action  string                `json:"action"`
+	Deleted model.MessagesDeleted `json:"payload"`
+	Mode    string                `json:"mode,omitempty"`
 }
 
 type ChatEvent struct {
@@ -12,6 +33,31 @@ type ChatEvent struct {
 	Chat   Chat   `json:"payload"`
 }
 
+type ReadEvent struct {
+	Action string    `json:"action"`
+	Read   ReadState `json:"payload"`
+}
+
+// TypingEvent — пользователь начал или перестал печатать в чате
+type TypingEvent struct {
+	Action string      `json:"action"`
+	Typing TypingState `json:"payload"`
+}
+
+// TypingState — ExpiresAt подсказывает клиенту, когда скрыть индикатор,
+// если не придёт ни повторный typing, ни stopTyping
+type TypingState struct {
+	ChatID    uuid.UUID `json:"chat_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ClientFrame — кадр, который клиент присылает по вебсокету
+type ClientFrame struct {
+	Action string    `json:"action"`
+	ChatID uuid.UUID `json:"chat_id"`
+}
+
 type AnyEvent struct {
 	TypeOfEvent string
 	Event       interface{}
@@ -22,8 +68,3 @@ type Event struct {
 	ChatId uuid.UUID   `json:"chatId"`
 	Users  []uuid.UUID `json:"users"`
 }
-
-type ChatInfoWS struct {
-	Events chan Event
-	Users  map[uuid.UUID]struct{}
-}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/model"
+	"github.com/google/uuid"
+)
+
+type IChatRepo interface {
+	GetUserChats(ctx context.Context, userID uuid.UUID) ([]model.ChatMember, error)
+	GetChatMembers(ctx context.Context, chatID uuid.UUID) ([]model.ChatMember, error)
+}
+
+type chatRepo struct {
+	db *sql.DB
+}
+
+func NewChatRepo(db *sql.DB) IChatRepo {
+	return &chatRepo{db: db}
+}
+
+// canSendColumn повторяет правило ensureCanSend основного сервиса
+const canSendColumn = `(c.type <> 'channel' OR uc.user_role = 'owner')`
+
+// GetUserChats возвращает все чаты, в которых состоит пользователь
+func (r *chatRepo) GetUserChats(ctx context.Context, userID uuid.UUID) ([]model.ChatMember, error) {
+	query := `
+		SELECT uc.chat_id, uc.user_id, ` + canSendColumn + `
+		FROM user_chat uc
+		JOIN chat c ON c.id = uc.chat_id
+		WHERE uc.user_id = $1
+	`
+	return r.queryMembers(ctx, query, userID, ErrGetUserChats)
+}
+
+// GetChatMembers возвращает всех участников чата
+func (r *chatRepo) GetChatMembers(ctx context.Context, chatID uuid.UUID) ([]model.ChatMember, error) {
+	query := `
+		SELECT uc.chat_id, uc.user_id, ` + canSendColumn + `
+		FROM user_chat uc
+		JOIN chat c ON c.id = uc.chat_id
+		WHERE uc.chat_id = $1
+	`
+	return r.queryMembers(ctx, query, chatID, ErrGetChatUsers)
+}
+
+func (r *chatRepo) queryMembers(ctx context.Context, query string, arg uuid.UUID, queryErr error) ([]model.ChatMember, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, queryErr
+	}
+	defer rows.Close()
+
+	var members []model.ChatMember
+	for rows.Next() {
+		var m model.ChatMember
+		if err := rows.Scan(&m.ChatID, &m.UserID, &m.CanSend); err != nil {
+			return nil, queryErr
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, queryErr
+	}
+	return members, nil
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+var (
+	ErrGetUserChats = errors.New("get user chats query failed")
+	ErrGetChatUsers = errors.New("get chat users query failed")
+)
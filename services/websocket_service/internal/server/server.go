@@ -1,6 +1,7 @@
 package server
 
 import (
+	"database/sql"
 	"net/http"
 	"os"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
 	generatedAuth "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/auth_service/proto"
 	websocketDelivery "github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/delivery/websocket"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/repository"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/usecase"
 	"github.com/gorilla/mux"
 	"github.com/nats-io/nats.go"
@@ -34,10 +36,11 @@ type IServer interface {
 type Server struct {
 	nc       *nats.Conn
 	authConn *grpc.ClientConn
+	dbConn   *sql.DB
 }
 
-func NewServer(nc *nats.Conn, authConn *grpc.ClientConn) IServer {
-	return &Server{nc: nc, authConn: authConn}
+func NewServer(nc *nats.Conn, authConn *grpc.ClientConn, dbConn *sql.DB) IServer {
+	return &Server{nc: nc, authConn: authConn, dbConn: dbConn}
 }
 
 func (s *Server) Run(address string) error {
@@ -57,8 +60,14 @@ func (s *Server) Run(address string) error {
 	mainRouter.Use(middleware.RequestIDMiddleware)
 	mainRouter.Use(middleware.AccessLogMiddleware)
 
+	// Repository
+	chatRepo := repository.NewChatRepo(s.dbConn)
+
 	// Usecases
-	websocketUsecase := usecase.NewWebsocketUsecase(s.nc)
+	websocketUsecase := usecase.NewWebsocketUsecase(s.nc, chatRepo)
+	if err := websocketUsecase.SubscribeChatEvents(); err != nil {
+		return err
+	}
 
 	// ===== WebSocket =====
 	websocketDelivery.NewWebsocketController(mainRouter, sessionClient, websocketUsecase, s.nc)
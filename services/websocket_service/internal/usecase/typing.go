@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/model"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const (
+	// typingTTL — сколько клиент показывает индикатор без повторного typing
+	typingTTL = 6 * time.Second
+	// typingInterval — повторный typing в тот же чат рассылается не чаще;
+	// клиенты шлют кадр на каждое нажатие клавиши
+	typingInterval = 3 * time.Second
+	// typingWindow и typingMaxEvents ограничивают все индикаторы пользователя,
+	// чтобы чередование typing и stopTyping не превращалось в поток событий
+	typingWindow    = 10 * time.Second
+	typingMaxEvents = 20
+)
+
+// typingLimiter — состояние индикаторов одного пользователя на этом экземпляре сервиса
+type typingLimiter struct {
+	active      map[uuid.UUID]time.Time // chatID -> когда разослан последний typing
+	windowStart time.Time
+	sent        int
+}
+
+// HandleTyping рассылает участникам чата, что пользователь печатает или
+// перестал. Членство и право писать проверяются по индексу онлайн-пользователей:
+// он загружен при подключении и обновляется событиями чатов, поэтому база не нужна.
+// Подписчик канала писать не может, и его индикатор выдал бы список подписчиков.
+func (w *WebsocketUsecase) HandleTyping(userID, chatID uuid.UUID, typing bool) error {
+	w.mu.RLock()
+	canSend, member := w.userChats[userID][chatID]
+	w.mu.RUnlock()
+	if !member {
+		return model.ErrNotChatMember
+	}
+	if !canSend {
+		return model.ErrCannotSend
+	}
+
+	now := time.Now()
+	if !w.allowTyping(userID, chatID, typing, now) {
+		return model.ErrTypingThrottled
+	}
+
+	action := utils.StopTyping
+	if typing {
+		action = utils.Typing
+	}
+	return w.publishTyping(action, userID, chatID, now)
+}
+
+// allowTyping пропускает typing не чаще typingInterval на чат, а stopTyping —
+// только если индикатор этого пользователя в чате ещё виден
+func (w *WebsocketUsecase) allowTyping(userID, chatID uuid.UUID, typing bool, now time.Time) bool {
+	w.typingMu.Lock()
+	defer w.typingMu.Unlock()
+
+	l := w.typing[userID]
+	if l == nil {
+		l = &typingLimiter{active: make(map[uuid.UUID]time.Time)}
+		w.typing[userID] = l
+	}
+
+	last, active := l.active[chatID]
+	active = active && now.Sub(last) < typingTTL
+	if typing && active && now.Sub(last) < typingInterval {
+		return false
+	}
+	if !typing && !active {
+		delete(l.active, chatID)
+		return false
+	}
+
+	if now.Sub(l.windowStart) >= typingWindow {
+		l.windowStart, l.sent = now, 0
+	}
+	if l.sent >= typingMaxEvents {
+		return false
+	}
+	l.sent++
+
+	if typing {
+		l.active[chatID] = now
+	} else {
+		delete(l.active, chatID)
+	}
+	return true
+}
+
+// stopAllTyping снимает индикаторы пользователя, у которого закрылось
+// последнее соединение, чтобы собеседники не ждали истечения TTL
+func (w *WebsocketUsecase) stopAllTyping(userID uuid.UUID) {
+	now := time.Now()
+	for _, chatID := range w.takeActiveTyping(userID, now) {
+		if err := w.publishTyping(utils.StopTyping, userID, chatID, now); err != nil {
+			utils.Logger.Warn("publish stopTyping failed", zap.String("chatID", chatID.String()), zap.Error(err))
+		}
+	}
+}
+
+// takeActiveTyping забывает состояние пользователя и возвращает чаты,
+// где его индикатор ещё виден
+func (w *WebsocketUsecase) takeActiveTyping(userID uuid.UUID, now time.Time) []uuid.UUID {
+	w.typingMu.Lock()
+	l := w.typing[userID]
+	delete(w.typing, userID)
+	w.typingMu.Unlock()
+	if l == nil {
+		return nil
+	}
+
+	var chats []uuid.UUID
+	for chatID, last := range l.active {
+		if now.Sub(last) < typingTTL {
+			chats = append(chats, chatID)
+		}
+	}
+	return chats
+}
+
+func (w *WebsocketUsecase) publishTyping(action string, userID, chatID uuid.UUID, now time.Time) error {
+	data, err := json.Marshal(model.TypingEvent{
+		Action: action,
+		Typing: model.TypingState{ChatID: chatID, UserID: userID, ExpiresAt: now.Add(typingTTL)},
+	})
+	if err != nil {
+		return err
+	}
+	return w.nc.Publish(fmt.Sprintf("chat.%s.typing", chatID.String()), data)
+}
+
+// handleTypingEvent раздаёт индикатор всем участникам чата, кроме самого
+// печатающего. Запоздавшие события (например, после переподключения к NATS) отбрасываются.
+func (w *WebsocketUsecase) handleTypingEvent(msg *nats.Msg) {
+	chatID, ok := chatIDFromSubject(msg.Subject)
+	if !ok {
+		return
+	}
+
+	var te model.TypingEvent
+	if err := json.Unmarshal(msg.Data, &te); err != nil {
+		utils.Logger.Error("unmarshal typing event", zap.Error(err))
+		return
+	}
+	if te.Typing.ChatID != chatID || time.Now().After(te.Typing.ExpiresAt) {
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	recipients := make(map[uuid.UUID]struct{}, len(w.chatMembers[chatID]))
+	for userID := range w.chatMembers[chatID] {
+		if userID != te.Typing.UserID {
+			recipients[userID] = struct{}{}
+		}
+	}
+	w.deliver(recipients, model.AnyEvent{TypeOfEvent: te.Action, Event: te})
+}
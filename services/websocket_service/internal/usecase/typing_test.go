@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typingStep — кадр от клиента через at после начала сценария
+type typingStep struct {
+	at     time.Duration
+	chat   int
+	typing bool
+	want   bool
+}
+
+func TestAllowTyping(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []typingStep
+	}{
+		{
+			name: "повтор typing реже интервала",
+			steps: []typingStep{
+				{at: 0, typing: true, want: true},
+				{at: time.Second, typing: true, want: false},
+				{at: typingInterval - time.Millisecond, typing: true, want: false},
+				{at: typingInterval, typing: true, want: true},
+			},
+		},
+		{
+			name: "интервал считается для каждого чата отдельно",
+			steps: []typingStep{
+				{at: 0, chat: 0, typing: true, want: true},
+				{at: time.Second, chat: 1, typing: true, want: true},
+				{at: time.Second, chat: 0, typing: true, want: false},
+			},
+		},
+		{
+			name: "stopTyping только для видимого индикатора",
+			steps: []typingStep{
+				{at: 0, typing: false, want: false},
+				{at: time.Second, typing: true, want: true},
+				{at: 2 * time.Second, typing: false, want: true},
+				{at: 2 * time.Second, typing: false, want: false},
+				// После stopTyping новый typing не ждёт интервала
+				{at: 2 * time.Second, typing: true, want: true},
+			},
+		},
+		{
+			name: "после TTL индикатор уже погас",
+			steps: []typingStep{
+				{at: 0, typing: true, want: true},
+				{at: typingTTL, typing: false, want: false},
+				{at: typingTTL, typing: true, want: true},
+			},
+		},
+		{
+			name: "окно ограничивает все чаты пользователя",
+			steps: func() []typingStep {
+				var steps []typingStep
+				for i := 0; i < typingMaxEvents; i++ {
+					steps = append(steps, typingStep{at: time.Duration(i) * time.Millisecond, chat: i, typing: true, want: true})
+				}
+				return append(steps,
+					typingStep{at: time.Second, chat: typingMaxEvents, typing: true, want: false},
+					// Отклонённый кадр не считается разосланным
+					typingStep{at: typingWindow, chat: typingMaxEvents, typing: true, want: true},
+				)
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTestUsecase(&fakeChatRepo{})
+			userID := uuid.New()
+			chats := make(map[int]uuid.UUID)
+			start := time.Now()
+
+			for i, step := range tt.steps {
+				if _, ok := chats[step.chat]; !ok {
+					chats[step.chat] = uuid.New()
+				}
+				got := w.allowTyping(userID, chats[step.chat], step.typing, start.Add(step.at))
+				assert.Equal(t, step.want, got, "шаг %d", i)
+			}
+		})
+	}
+}
+
+func TestTakeActiveTyping(t *testing.T) {
+	w := newTestUsecase(&fakeChatRepo{})
+	userID := uuid.New()
+	expired, visible, stopped := uuid.New(), uuid.New(), uuid.New()
+	start := time.Now()
+
+	require.True(t, w.allowTyping(userID, expired, true, start))
+	require.True(t, w.allowTyping(userID, stopped, true, start))
+	require.True(t, w.allowTyping(userID, stopped, false, start.Add(time.Second)))
+	require.True(t, w.allowTyping(userID, visible, true, start.Add(5*time.Second)))
+
+	assert.Equal(t, []uuid.UUID{visible}, w.takeActiveTyping(userID, start.Add(typingTTL)))
+	assert.Empty(t, w.takeActiveTyping(userID, start.Add(typingTTL)), "состояние забыто")
+	assert.NotContains(t, w.typing, userID)
+}
+
+func TestHandleTyping_RequiresMembership(t *testing.T) {
+	w := newTestUsecase(&fakeChatRepo{members: map[uuid.UUID][]uuid.UUID{}})
+	userID := uuid.New()
+	register(t, w, userID)
+
+	err := w.HandleTyping(userID, uuid.New(), true)
+	assert.ErrorIs(t, err, model.ErrNotChatMember)
+	assert.Empty(t, w.typing, "чужой чат не расходует лимит")
+}
+
+func TestHandleTyping_ChannelOwnerOnly(t *testing.T) {
+	owner, subscriber := uuid.New(), uuid.New()
+	channel := uuid.New()
+	repo := &fakeChatRepo{
+		members:  map[uuid.UUID][]uuid.UUID{channel: {owner, subscriber}},
+		channels: map[uuid.UUID]uuid.UUID{channel: owner},
+	}
+	w := newTestUsecase(repo)
+	register(t, w, owner)
+	register(t, w, subscriber)
+
+	err := w.HandleTyping(subscriber, channel, true)
+	assert.ErrorIs(t, err, model.ErrCannotSend)
+	assert.Empty(t, w.typing, "подписчик не расходует лимит")
+
+	// Право писать обновляется вместе с составом канала
+	repo.setMembers(channel, owner)
+	w.handleChatEvent(membershipEvent(t, channel, utils.RemoveUsers))
+	assert.Equal(t, map[uuid.UUID]bool{channel: true}, w.userChats[owner])
+	assert.NotContains(t, w.userChats, subscriber)
+}
+
+func TestHandleTypingEvent(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	chat := uuid.New()
+	w := newTestUsecase(&fakeChatRepo{members: map[uuid.UUID][]uuid.UUID{chat: {alice, bob}}})
+	aliceCh := register(t, w, alice)
+	bobCh := register(t, w, bob)
+
+	typing := func(chatID uuid.UUID, expiresAt time.Time) model.TypingEvent {
+		return model.TypingEvent{
+			Action: utils.Typing,
+			Typing: model.TypingState{ChatID: chatID, UserID: alice, ExpiresAt: expiresAt},
+		}
+	}
+
+	// Печатающий свой индикатор не получает
+	w.handleTypingEvent(chatMsg(t, chat, "typing", typing(chat, time.Now().Add(typingTTL))))
+	assert.Empty(t, drain(aliceCh))
+	assert.Equal(t, []string{utils.Typing}, drain(bobCh))
+
+	// Запоздавшее событие и событие с чужим чатом в теме отбрасываются
+	w.handleTypingEvent(chatMsg(t, chat, "typing", typing(chat, time.Now().Add(-time.Second))))
+	w.handleTypingEvent(chatMsg(t, chat, "typing", typing(uuid.New(), time.Now().Add(typingTTL))))
+	assert.Empty(t, drain(bobCh))
+}
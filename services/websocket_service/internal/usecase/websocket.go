@@ -1,40 +1,109 @@
 package usecase
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
 	"sync"
 
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/repository"
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
 )
 
 type IWebsocketUsecase interface {
-	RegisterUserChannel(userID uuid.UUID, eventChan chan model.AnyEvent) error
+	RegisterUserChannel(ctx context.Context, userID uuid.UUID, eventChan chan model.AnyEvent) error
 	UnregisterUserChannel(userID uuid.UUID, eventChan chan model.AnyEvent)
 	GetUserChannels(userID uuid.UUID) []chan model.AnyEvent
+	SubscribeChatEvents() error
+	// HandleTyping обрабатывает кадры typing/stopTyping от клиента
+	HandleTyping(userID, chatID uuid.UUID, typing bool) error
 }
 
 type WebsocketUsecase struct {
 	nc            *nats.Conn
-	onlineChats   map[uuid.UUID]model.ChatInfoWS      // chatID -> ChatInfoWS{Events, Users}
-	onlineUsers   map[uuid.UUID][]chan model.AnyEvent // userID -> slice of event channels
-	subscriptions map[uuid.UUID][]*nats.Subscription  // chatID -> NATS subscriptions
+	chatRepo      repository.IChatRepo
+	chatMembers   map[uuid.UUID]map[uuid.UUID]struct{} // chatID -> online members
+	userChats     map[uuid.UUID]map[uuid.UUID]bool     // userID -> chats of online user -> can send
+	onlineUsers   map[uuid.UUID][]chan model.AnyEvent  // userID -> slice of event channels
+	subscriptions []*nats.Subscription
 	mu            sync.RWMutex
+	// loading — изменения членства, пришедшие, пока индекс пользователя
+	// читается из базы; применяются при регистрации соединения
+	loading map[uuid.UUID]*pendingMembership
+
+	typing   map[uuid.UUID]*typingLimiter // userID -> индикаторы набора текста
+	typingMu sync.Mutex
 }
 
-func NewWebsocketUsecase(nc *nats.Conn) IWebsocketUsecase {
+func NewWebsocketUsecase(nc *nats.Conn, chatRepo repository.IChatRepo) IWebsocketUsecase {
 	return &WebsocketUsecase{
-		nc:            nc,
-		onlineChats:   make(map[uuid.UUID]model.ChatInfoWS),
-		onlineUsers:   make(map[uuid.UUID][]chan model.AnyEvent),
-		subscriptions: make(map[uuid.UUID][]*nats.Subscription),
+		nc:          nc,
+		chatRepo:    chatRepo,
+		chatMembers: make(map[uuid.UUID]map[uuid.UUID]struct{}),
+		userChats:   make(map[uuid.UUID]map[uuid.UUID]bool),
+		onlineUsers: make(map[uuid.UUID][]chan model.AnyEvent),
+		loading:     make(map[uuid.UUID]*pendingMembership),
+		typing:      make(map[uuid.UUID]*typingLimiter),
 	}
 }
 
-func (w *WebsocketUsecase) RegisterUserChannel(userID uuid.UUID, eventChan chan model.AnyEvent) error {
+// pendingMembership копит изменения членства для пользователя, чей индекс
+// загружается; loaders — число параллельных загрузок его соединений
+type pendingMembership struct {
+	loaders int
+	chats   map[uuid.UUID]*model.ChatMember // chatID -> членство после изменения, nil — исключён
+}
+
+func (w *WebsocketUsecase) RegisterUserChannel(ctx context.Context, userID uuid.UUID, eventChan chan model.AnyEvent) error {
+	// Проверка и добавление канала под одной блокировкой: иначе последнее
+	// соединение могло закрыться между ними и унести индекс членства
 	w.mu.Lock()
-	w.onlineUsers[userID] = append(w.onlineUsers[userID], eventChan)
+	if _, online := w.onlineUsers[userID]; online {
+		w.onlineUsers[userID] = append(w.onlineUsers[userID], eventChan)
+		w.mu.Unlock()
+		return nil
+	}
+	pending := w.loading[userID]
+	if pending == nil {
+		pending = &pendingMembership{chats: make(map[uuid.UUID]*model.ChatMember)}
+		w.loading[userID] = pending
+	}
+	pending.loaders++
 	w.mu.Unlock()
+
+	// Индекс членства загружаем только для первого соединения пользователя
+	chats, err := w.chatRepo.GetUserChats(ctx, userID)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	pending.loaders--
+	if pending.loaders == 0 {
+		delete(w.loading, userID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Если параллельно подключилось другое устройство, его индекс уже
+	// актуален и поддерживается событиями чатов
+	if _, online := w.onlineUsers[userID]; !online {
+		// Изменения, пришедшие во время запроса, новее прочитанного списка
+		for _, m := range chats {
+			if _, changed := pending.chats[m.ChatID]; !changed {
+				w.addMember(m)
+			}
+		}
+		for _, m := range pending.chats {
+			if m != nil {
+				w.addMember(*m)
+			}
+		}
+	}
+	w.onlineUsers[userID] = append(w.onlineUsers[userID], eventChan)
 	return nil
 }
 
@@ -47,11 +116,20 @@ func (w *WebsocketUsecase) UnregisterUserChannel(userID uuid.UUID, eventChan cha
 			break
 		}
 	}
-	if len(w.onlineUsers[userID]) == 0 {
+	offline := len(w.onlineUsers[userID]) == 0
+	if offline {
 		delete(w.onlineUsers, userID)
+		for chatID := range w.userChats[userID] {
+			w.removeMember(chatID, userID)
+		}
+		delete(w.userChats, userID)
 	}
 	w.mu.Unlock()
 	close(eventChan)
+
+	if offline {
+		w.stopAllTyping(userID)
+	}
 }
 
 func (w *WebsocketUsecase) GetUserChannels(userID uuid.UUID) []chan model.AnyEvent {
@@ -62,3 +140,198 @@ func (w *WebsocketUsecase) GetUserChannels(userID uuid.UUID) []chan model.AnyEve
 	copy = append(copy[:0], orig...)
 	return copy
 }
+
+// SubscribeChatEvents подписывает сервис на события всех чатов и
+// раздаёт их только тем соединениям, чьи пользователи состоят в чате
+func (w *WebsocketUsecase) SubscribeChatEvents() error {
+	handlers := []struct {
+		subject string
+		handler nats.MsgHandler
+	}{
+		{"chat.*.messages", w.handleMessageEvent},
+		{"chat.*.events", w.handleChatEvent},
+		{"chat.*.reads", w.handleReadEvent},
+		{"chat.*.typing", w.handleTypingEvent},
+	}
+
+	subs := make([]*nats.Subscription, 0, len(handlers))
+	for _, h := range handlers {
+		sub, err := w.nc.Subscribe(h.subject, h.handler)
+		if err != nil {
+			for _, s := range subs {
+				_ = s.Unsubscribe()
+			}
+			return err
+		}
+		subs = append(subs, sub)
+	}
+	w.subscriptions = append(w.subscriptions, subs...)
+	return nil
+}
+
+func (w *WebsocketUsecase) handleMessageEvent(msg *nats.Msg) {
+	chatID, ok := chatIDFromSubject(msg.Subject)
+	if !ok {
+		return
+	}
+
+	var me model.MessageEvent
+	if err := json.Unmarshal(msg.Data, &me); err != nil {
+		utils.Logger.Error("unmarshal message event", zap.Error(err))
+		return
+	}
+
+	// У пакетных удалений в payload список ID, а не сообщение
+	var event interface{} = me
+	if me.Action == utils.DeleteMessages || me.Action == utils.ClearHistory {
+		var de model.MessagesDeletedEvent
+		if err := json.Unmarshal(msg.Data, &de); err != nil {
+			utils.Logger.Error("unmarshal messages deleted event", zap.Error(err))
+			return
+		}
+		event = de
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	w.deliver(w.chatMembers[chatID], model.AnyEvent{TypeOfEvent: me.Action, Event: event})
+}
+
+// handleReadEvent раздаёт отметки о прочтении участникам чата,
+// включая другие устройства прочитавшего пользователя
+func (w *WebsocketUsecase) handleReadEvent(msg *nats.Msg) {
+	chatID, ok := chatIDFromSubject(msg.Subject)
+	if !ok {
+		return
+	}
+
+	var re model.ReadEvent
+	if err := json.Unmarshal(msg.Data, &re); err != nil {
+		utils.Logger.Error("unmarshal read event", zap.Error(err))
+		return
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	w.deliver(w.chatMembers[chatID], model.AnyEvent{TypeOfEvent: re.Action, Event: re})
+}
+
+func (w *WebsocketUsecase) handleChatEvent(msg *nats.Msg) {
+	chatID, ok := chatIDFromSubject(msg.Subject)
+	if !ok {
+		return
+	}
+
+	var ce model.ChatEvent
+	if err := json.Unmarshal(msg.Data, &ce); err != nil {
+		utils.Logger.Error("unmarshal chat event", zap.Error(err))
+		return
+	}
+	event := model.AnyEvent{TypeOfEvent: ce.Action, Event: ce}
+
+	if !changesMembership(ce.Action) {
+		w.mu.RLock()
+		defer w.mu.RUnlock()
+		w.deliver(w.chatMembers[chatID], event)
+		return
+	}
+
+	var members []model.ChatMember
+	if ce.Action != utils.DeleteChat {
+		var err error
+		members, err = w.chatRepo.GetChatMembers(context.Background(), chatID)
+		if err != nil {
+			utils.Logger.Error("refresh chat members", zap.String("chatID", chatID.String()), zap.Error(err))
+			return
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Событие получают и прежние, и новые участники: так удалённый
+	// пользователь узнаёт о выходе, а добавленный — о новом чате
+	recipients := make(map[uuid.UUID]struct{}, len(w.chatMembers[chatID])+len(members))
+	for userID := range w.chatMembers[chatID] {
+		recipients[userID] = struct{}{}
+		w.removeMember(chatID, userID)
+	}
+	for _, m := range members {
+		if _, online := w.onlineUsers[m.UserID]; online {
+			recipients[m.UserID] = struct{}{}
+			w.addMember(m)
+		}
+	}
+	// Загружаемый сейчас список чатов мог быть прочитан до этого изменения
+	for _, pending := range w.loading {
+		pending.chats[chatID] = nil
+	}
+	for i, m := range members {
+		if pending := w.loading[m.UserID]; pending != nil {
+			pending.chats[chatID] = &members[i]
+		}
+	}
+	w.deliver(recipients, event)
+}
+
+// deliver отправляет событие во все соединения получателей.
+// Вызывается под мьютексом, чтобы канал не был закрыт во время отправки.
+func (w *WebsocketUsecase) deliver(recipients map[uuid.UUID]struct{}, event model.AnyEvent) {
+	for userID := range recipients {
+		for _, ch := range w.onlineUsers[userID] {
+			select {
+			case ch <- event:
+			default:
+				utils.Logger.Warn("event channel is full, dropping event",
+					zap.String("userID", userID.String()),
+					zap.String("action", event.TypeOfEvent))
+			}
+		}
+	}
+}
+
+func (w *WebsocketUsecase) addMember(m model.ChatMember) {
+	if w.chatMembers[m.ChatID] == nil {
+		w.chatMembers[m.ChatID] = make(map[uuid.UUID]struct{})
+	}
+	w.chatMembers[m.ChatID][m.UserID] = struct{}{}
+
+	if w.userChats[m.UserID] == nil {
+		w.userChats[m.UserID] = make(map[uuid.UUID]bool)
+	}
+	w.userChats[m.UserID][m.ChatID] = m.CanSend
+}
+
+func (w *WebsocketUsecase) removeMember(chatID, userID uuid.UUID) {
+	delete(w.chatMembers[chatID], userID)
+	if len(w.chatMembers[chatID]) == 0 {
+		delete(w.chatMembers, chatID)
+	}
+
+	delete(w.userChats[userID], chatID)
+	if len(w.userChats[userID]) == 0 {
+		delete(w.userChats, userID)
+	}
+}
+
+func changesMembership(action string) bool {
+	switch action {
+	case utils.NewChat, utils.DeleteChat, utils.AddUsers, utils.RemoveUsers, utils.LeaveChat:
+		return true
+	default:
+		return false
+	}
+}
+
+// chatIDFromSubject извлекает ID чата из темы вида chat.<id>.<kind>
+func chatIDFromSubject(subject string) (uuid.UUID, bool) {
+	parts := strings.Split(subject, ".")
+	if len(parts) != 3 {
+		return uuid.Nil, false
+	}
+	chatID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return chatID, true
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/services/websocket_service/internal/model"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChatRepo хранит членство в памяти; onLoad вызывается внутри
+// GetUserChats, чтобы воспроизвести событие во время загрузки индекса
+type fakeChatRepo struct {
+	mu       sync.Mutex
+	members  map[uuid.UUID][]uuid.UUID // chatID -> userIDs
+	channels map[uuid.UUID]uuid.UUID   // channelID -> ownerID
+	loads    int
+	onLoad   func()
+}
+
+func (r *fakeChatRepo) member(chatID, userID uuid.UUID) model.ChatMember {
+	owner, channel := r.channels[chatID]
+	return model.ChatMember{ChatID: chatID, UserID: userID, CanSend: !channel || owner == userID}
+}
+
+func (r *fakeChatRepo) GetUserChats(ctx context.Context, userID uuid.UUID) ([]model.ChatMember, error) {
+	r.mu.Lock()
+	r.loads++
+	var chats []model.ChatMember
+	for chatID, users := range r.members {
+		for _, id := range users {
+			if id == userID {
+				chats = append(chats, r.member(chatID, userID))
+			}
+		}
+	}
+	onLoad := r.onLoad
+	r.onLoad = nil
+	r.mu.Unlock()
+
+	if onLoad != nil {
+		onLoad()
+	}
+	return chats, nil
+}
+
+func (r *fakeChatRepo) GetChatMembers(ctx context.Context, chatID uuid.UUID) ([]model.ChatMember, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var members []model.ChatMember
+	for _, userID := range r.members[chatID] {
+		members = append(members, r.member(chatID, userID))
+	}
+	return members, nil
+}
+
+func (r *fakeChatRepo) setMembers(chatID uuid.UUID, users ...uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.members[chatID] = users
+}
+
+func newTestUsecase(repo *fakeChatRepo) *WebsocketUsecase {
+	return NewWebsocketUsecase(nil, repo).(*WebsocketUsecase)
+}
+
+func register(t *testing.T, w *WebsocketUsecase, userID uuid.UUID) chan model.AnyEvent {
+	t.Helper()
+	ch := make(chan model.AnyEvent, 10)
+	require.NoError(t, w.RegisterUserChannel(context.Background(), userID, ch))
+	return ch
+}
+
+func chatMsg(t *testing.T, chatID uuid.UUID, kind string, event any) *nats.Msg {
+	t.Helper()
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	return &nats.Msg{Subject: fmt.Sprintf("chat.%s.%s", chatID, kind), Data: data}
+}
+
+func newMessage(t *testing.T, chatID uuid.UUID) *nats.Msg {
+	return chatMsg(t, chatID, "messages", model.MessageEvent{
+		Action:  utils.NewMessage,
+		Message: model.Message{ID: uuid.New(), ChatID: chatID},
+	})
+}
+
+func membershipEvent(t *testing.T, chatID uuid.UUID, action string) *nats.Msg {
+	return chatMsg(t, chatID, "events", model.ChatEvent{Action: action, Chat: model.Chat{ID: chatID}})
+}
+
+func drain(ch chan model.AnyEvent) []string {
+	var actions []string
+	for {
+		select {
+		case ev := <-ch:
+			actions = append(actions, ev.TypeOfEvent)
+		default:
+			return actions
+		}
+	}
+}
+
+func TestHandleMessageEvent_DeliversOnlyToChatMembers(t *testing.T) {
+	alice, bob, carol := uuid.New(), uuid.New(), uuid.New()
+	chat, other := uuid.New(), uuid.New()
+	repo := &fakeChatRepo{members: map[uuid.UUID][]uuid.UUID{
+		chat:  {alice, bob},
+		other: {carol},
+	}}
+	w := newTestUsecase(repo)
+
+	aliceCh := register(t, w, alice)
+	aliceCh2 := register(t, w, alice)
+	bobCh := register(t, w, bob)
+	carolCh := register(t, w, carol)
+	assert.Equal(t, 3, repo.loads, "второе устройство не перечитывает членство")
+
+	w.handleMessageEvent(newMessage(t, chat))
+
+	assert.Equal(t, []string{utils.NewMessage}, drain(aliceCh))
+	assert.Equal(t, []string{utils.NewMessage}, drain(aliceCh2))
+	assert.Equal(t, []string{utils.NewMessage}, drain(bobCh))
+	assert.Empty(t, drain(carolCh))
+}
+
+func TestHandleChatEvent_UpdatesMembership(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	chat := uuid.New()
+	repo := &fakeChatRepo{members: map[uuid.UUID][]uuid.UUID{chat: {alice}}}
+	w := newTestUsecase(repo)
+
+	aliceCh := register(t, w, alice)
+	bobCh := register(t, w, bob)
+
+	repo.setMembers(chat, alice, bob)
+	w.handleChatEvent(membershipEvent(t, chat, utils.AddUsers))
+	assert.Equal(t, []string{utils.AddUsers}, drain(aliceCh))
+	assert.Equal(t, []string{utils.AddUsers}, drain(bobCh))
+
+	// Исключённый получает событие о выходе, но не дальнейшие сообщения
+	repo.setMembers(chat, alice)
+	w.handleChatEvent(membershipEvent(t, chat, utils.RemoveUsers))
+	assert.Equal(t, []string{utils.RemoveUsers}, drain(bobCh))
+	drain(aliceCh)
+
+	w.handleMessageEvent(newMessage(t, chat))
+	assert.Equal(t, []string{utils.NewMessage}, drain(aliceCh))
+	assert.Empty(t, drain(bobCh))
+}
+
+func TestRegisterUserChannel_AppliesRemovalDuringLoad(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	chat := uuid.New()
+	repo := &fakeChatRepo{members: map[uuid.UUID][]uuid.UUID{chat: {alice, bob}}}
+	w := newTestUsecase(repo)
+	aliceCh := register(t, w, alice)
+
+	// Боба исключают, пока читается его список чатов
+	repo.onLoad = func() {
+		repo.setMembers(chat, alice)
+		w.handleChatEvent(membershipEvent(t, chat, utils.RemoveUsers))
+	}
+	bobCh := register(t, w, bob)
+	assert.Equal(t, 2, repo.loads, "список не перечитывается")
+	assert.Empty(t, w.loading)
+	drain(aliceCh)
+
+	w.handleMessageEvent(newMessage(t, chat))
+	assert.Equal(t, []string{utils.NewMessage}, drain(aliceCh))
+	assert.Empty(t, drain(bobCh))
+}
+
+func TestRegisterUserChannel_AppliesAdditionDuringLoad(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+	chat, other := uuid.New(), uuid.New()
+	repo := &fakeChatRepo{members: map[uuid.UUID][]uuid.UUID{chat: {alice}, other: {alice}}}
+	w := newTestUsecase(repo)
+	register(t, w, alice)
+
+	// Пока читается список Боба, его добавляют в чат, а в чужом чате
+	// меняется состав — подключение от этого не отказывает
+	repo.onLoad = func() {
+		repo.setMembers(chat, alice, bob)
+		w.handleChatEvent(membershipEvent(t, chat, utils.AddUsers))
+		for i := 0; i < 5; i++ {
+			w.handleChatEvent(membershipEvent(t, other, utils.AddUsers))
+		}
+	}
+	bobCh := register(t, w, bob)
+
+	w.handleMessageEvent(newMessage(t, chat))
+	w.handleMessageEvent(newMessage(t, other))
+	assert.Equal(t, []string{utils.NewMessage}, drain(bobCh))
+}
+
+func TestRegisterUserChannel_ReloadsAfterLastConnectionClosed(t *testing.T) {
+	alice := uuid.New()
+	chat := uuid.New()
+	repo := &fakeChatRepo{members: map[uuid.UUID][]uuid.UUID{chat: {alice}}}
+	w := newTestUsecase(repo)
+
+	first := register(t, w, alice)
+	w.UnregisterUserChannel(alice, first)
+
+	second := register(t, w, alice)
+	assert.Equal(t, 2, repo.loads)
+
+	w.handleMessageEvent(newMessage(t, chat))
+	assert.Equal(t, []string{utils.NewMessage}, drain(second))
+}
@@ -0,0 +1,256 @@
+package audio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/audio"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Файлы собираются прямо в тестах: первая половина записи тихая, вторая громкая,
+// поэтому огибающая должна начинаться с нуля и заканчиваться максимумом.
+
+func assertRising(t *testing.T, info *audio.Info) {
+	t.Helper()
+	require.Len(t, info.Waveform, audio.WaveformSamples)
+	assert.Equal(t, byte(0), info.Waveform[0])
+	assert.Equal(t, byte(255), info.Waveform[audio.WaveformSamples-1])
+}
+
+func oggPage(granule uint64, packets ...[]byte) []byte {
+	var lacing, body []byte
+	for _, p := range packets {
+		n := len(p)
+		for ; n >= 255; n -= 255 {
+			lacing = append(lacing, 255)
+		}
+		lacing = append(lacing, byte(n))
+		body = append(body, p...)
+	}
+	page := []byte("OggS\x00\x00")
+	page = binary.LittleEndian.AppendUint64(page, granule)
+	page = append(page, 1, 0, 0, 0) // serial
+	page = append(page, make([]byte, 8)...)
+	page = append(page, byte(len(lacing)))
+	page = append(page, lacing...)
+	return append(page, body...)
+}
+
+func opusOgg() []byte {
+	head := []byte("OpusHead\x01\x01")
+	head = binary.LittleEndian.AppendUint16(head, 312)
+	head = binary.LittleEndian.AppendUint32(head, 48000)
+	head = append(head, 0, 0, 0)
+
+	// TOC 0x48: SILK WB, 20 мс, один кадр — 960 отсчётов
+	var packets [][]byte
+	for i := 0; i < 100; i++ {
+		size := 10
+		if i >= 50 {
+			size = 100
+		}
+		packets = append(packets, append([]byte{0x48}, make([]byte, size-1)...))
+	}
+
+	data := oggPage(0, head)
+	data = append(data, oggPage(0, []byte("OpusTags\x00\x00\x00\x00\x00\x00\x00\x00"))...)
+	data = append(data, oggPage(100*960+312, packets[:60]...)...)
+	return append(data, oggPage(100*960+312, packets[60:]...)...)
+}
+
+func TestProbe_OggOpus(t *testing.T) {
+	info, err := audio.Probe(opusOgg())
+	require.NoError(t, err)
+	assert.Equal(t, "ogg/opus", info.Format)
+	assert.Equal(t, "audio/ogg", info.MimeType)
+	assert.Equal(t, 2*time.Second, info.Duration)
+	assertRising(t, info)
+}
+
+func TestProbe_OggTruncated(t *testing.T) {
+	data := opusOgg()
+	_, err := audio.Probe(data[:len(data)-50])
+	assert.ErrorIs(t, err, audio.ErrInvalidAudio)
+}
+
+func TestProbe_WAV(t *testing.T) {
+	const rate = 8000
+	var pcm []byte
+	for i := 0; i < 2*rate; i++ {
+		amp := int16(100)
+		if i >= rate {
+			amp = 20000
+		}
+		if i%2 == 1 {
+			amp = -amp
+		}
+		pcm = binary.LittleEndian.AppendUint16(pcm, uint16(amp))
+	}
+
+	fmtChunk := []byte{1, 0, 1, 0}
+	fmtChunk = binary.LittleEndian.AppendUint32(fmtChunk, rate)
+	fmtChunk = binary.LittleEndian.AppendUint32(fmtChunk, rate*2)
+	fmtChunk = append(fmtChunk, 2, 0, 16, 0)
+
+	data := []byte("RIFF\x00\x00\x00\x00WAVEfmt ")
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(fmtChunk)))
+	data = append(data, fmtChunk...)
+	// Размер data как у незавершённой потоковой записи
+	data = append(data, []byte("data\xff\xff\xff\xff")...)
+	data = append(data, pcm...)
+
+	info, err := audio.Probe(data)
+	require.NoError(t, err)
+	assert.Equal(t, "audio/wav", info.MimeType)
+	assert.Equal(t, 2*time.Second, info.Duration)
+	assertRising(t, info)
+}
+
+func ebml(id []byte, body ...[]byte) []byte {
+	payload := bytes.Join(body, nil)
+	out := append([]byte{}, id...)
+	size := binary.BigEndian.AppendUint64(nil, uint64(len(payload)))
+	size[0] = 0x01
+	return append(append(out, size...), payload...)
+}
+
+// unknownSize — так MediaRecorder записывает Segment и Cluster
+func unknownSize(id []byte) []byte {
+	return append(append([]byte{}, id...), 0x01, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF)
+}
+
+func webm(trackType byte) []byte {
+	data := ebml([]byte{0x1A, 0x45, 0xDF, 0xA3}, ebml([]byte{0x42, 0x82}, []byte("webm")))
+	data = append(data, unknownSize([]byte{0x18, 0x53, 0x80, 0x67})...)
+	data = append(data, ebml([]byte{0x16, 0x54, 0xAE, 0x6B},
+		ebml([]byte{0xAE},
+			ebml([]byte{0xD7}, []byte{1}),
+			ebml([]byte{0x83}, []byte{trackType}),
+			ebml([]byte{0x86}, []byte("A_OPUS")),
+		),
+	)...)
+	data = append(data, unknownSize([]byte{0x1F, 0x43, 0xB6, 0x75})...)
+	data = append(data, ebml([]byte{0xE7}, []byte{0})...)
+	for i := 0; i < 100; i++ {
+		size := 10
+		if i >= 50 {
+			size = 100
+		}
+		block := []byte{0x81}
+		block = binary.BigEndian.AppendUint16(block, uint16(i*20))
+		block = append(block, 0x80, 0x48)
+		data = append(data, ebml([]byte{0xA3}, block, make([]byte, size-1))...)
+	}
+	return data
+}
+
+func TestProbe_WebMWithoutDuration(t *testing.T) {
+	info, err := audio.Probe(webm(2))
+	require.NoError(t, err)
+	assert.Equal(t, "webm/opus", info.Format)
+	assert.Equal(t, "audio/webm", info.MimeType)
+	assert.Equal(t, 2*time.Second, info.Duration)
+	assertRising(t, info)
+}
+
+func TestProbe_WebMVideo(t *testing.T) {
+	_, err := audio.Probe(webm(1))
+	assert.ErrorIs(t, err, audio.ErrUnsupportedFormat)
+}
+
+// bitWriter пишет биты от старшего к младшему
+type bitWriter struct {
+	data []byte
+	pos  int
+}
+
+func (w *bitWriter) write(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.pos/8 >= len(w.data) {
+			w.data = append(w.data, 0)
+		}
+		if v>>i&1 == 1 {
+			w.data[w.pos/8] |= 0x80 >> (w.pos % 8)
+		}
+		w.pos++
+	}
+}
+
+func TestProbe_MP3(t *testing.T) {
+	// ID3v2 с одним пустым байтом содержимого
+	data := []byte("ID3\x03\x00\x00\x00\x00\x00\x01\x00")
+	for i := 0; i < 100; i++ {
+		gain := 100
+		if i >= 50 {
+			gain = 200
+		}
+		// MPEG-1 Layer III, 128 кбит/с, 44.1 кГц, моно, без CRC: кадр 417 байт
+		frame := []byte{0xFF, 0xFB, 0x90, 0xC0}
+		side := &bitWriter{}
+		side.write(0, 9+5+4+12+9)
+		side.write(gain, 8)
+		frame = append(frame, side.data...)
+		data = append(data, append(frame, make([]byte, 417-len(frame))...)...)
+	}
+
+	info, err := audio.Probe(data)
+	require.NoError(t, err)
+	assert.Equal(t, "audio/mpeg", info.MimeType)
+	assert.InDelta(t, 100*1152/44100.0, info.Duration.Seconds(), 0.001)
+	assertRising(t, info)
+}
+
+func box(typ string, body ...[]byte) []byte {
+	payload := bytes.Join(body, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(payload)))
+	return append(append(out, typ...), payload...)
+}
+
+func u32s(vs ...uint32) []byte {
+	var out []byte
+	for _, v := range vs {
+		out = binary.BigEndian.AppendUint32(out, v)
+	}
+	return out
+}
+
+func TestProbe_MP4(t *testing.T) {
+	sizes := []uint32{0, 0, 100} // версия и флаги, общий размер, число отсчётов
+	for i := 0; i < 100; i++ {
+		if i < 50 {
+			sizes = append(sizes, 10)
+		} else {
+			sizes = append(sizes, 100)
+		}
+	}
+	data := box("ftyp", []byte("M4A \x00\x00\x00\x00"))
+	data = append(data, box("moov",
+		box("trak",
+			box("tkhd", u32s(0, 0, 0, 1)),
+			box("mdia",
+				box("mdhd", u32s(0, 0, 0, 48000, 96000)),
+				box("hdlr", u32s(0, 0), []byte("soun")),
+				box("minf", box("stbl",
+					box("stts", u32s(0, 1, 100, 960)),
+					box("stsz", u32s(sizes...)),
+				)),
+			),
+		),
+	)...)
+	data = append(data, box("mdat", make([]byte, 16))...)
+
+	info, err := audio.Probe(data)
+	require.NoError(t, err)
+	assert.Equal(t, "audio/mp4", info.MimeType)
+	assert.Equal(t, 2*time.Second, info.Duration)
+	assertRising(t, info)
+}
+
+func TestProbe_Unsupported(t *testing.T) {
+	_, err := audio.Probe([]byte("definitely not audio"))
+	assert.ErrorIs(t, err, audio.ErrUnsupportedFormat)
+}
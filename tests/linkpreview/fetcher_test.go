@@ -0,0 +1,148 @@
+package linkpreview_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/linkpreview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const page = `<!doctype html>
+<html><head>
+<title>Fallback title</title>
+<meta property="og:title" content="Velvet Pulls">
+<meta property="og:description" content="Мессенджер">
+<meta property="og:image" content="/static/cover.png">
+<meta property="og:site_name" content="VP">
+</head><body><meta property="og:title" content="ignored"></body></html>`
+
+func newServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *int32) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func htmlHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestFetch_ParsesOpenGraphAndCaches(t *testing.T) {
+	srv, hits := newServer(t, htmlHandler(page))
+
+	f := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client(), CacheTTL: time.Minute})
+
+	preview, err := f.Fetch(context.Background(), srv.URL+"/post")
+	require.NoError(t, err)
+	assert.Equal(t, srv.URL+"/post", preview.URL)
+	assert.Equal(t, "Velvet Pulls", preview.Title)
+	assert.Equal(t, "Мессенджер", preview.Description)
+	assert.Equal(t, srv.URL+"/static/cover.png", preview.ImageURL)
+	assert.Equal(t, "VP", preview.SiteName)
+
+	_, err = f.Fetch(context.Background(), srv.URL+"/post")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(hits))
+}
+
+func TestFetch_FallsBackToTitleTag(t *testing.T) {
+	srv, _ := newServer(t, htmlHandler(`<html><head><title> Just   a title </title>
+<meta name="description" content="plain"></head></html>`))
+
+	f := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client()})
+
+	preview, err := f.Fetch(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "Just a title", preview.Title)
+	assert.Equal(t, "plain", preview.Description)
+}
+
+func TestFetch_DenyAndAllowLists(t *testing.T) {
+	srv, hits := newServer(t, htmlHandler(page))
+
+	denied := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client(), Deny: []string{"127.0.0.1"}})
+	_, err := denied.Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, linkpreview.ErrHostNotAllowed)
+
+	notAllowed := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client(), Allow: []string{"example.com"}})
+	_, err = notAllowed.Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, linkpreview.ErrHostNotAllowed)
+
+	assert.EqualValues(t, 0, atomic.LoadInt32(hits))
+}
+
+func TestFetch_RedirectChecksLists(t *testing.T) {
+	target, targetHits := newServer(t, htmlHandler(page))
+	srv, _ := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace(target.URL, "127.0.0.1", "localhost", 1), http.StatusFound)
+	})
+
+	f := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client(), Deny: []string{"localhost"}})
+	_, err := f.Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, linkpreview.ErrHostNotAllowed)
+	assert.EqualValues(t, 0, atomic.LoadInt32(targetHits))
+}
+
+func TestFetch_BlocksPrivateNetworksByDefault(t *testing.T) {
+	srv, hits := newServer(t, htmlHandler(page))
+
+	f := linkpreview.NewFetcher(linkpreview.Config{})
+	_, err := f.Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, linkpreview.ErrHostNotAllowed)
+	assert.EqualValues(t, 0, atomic.LoadInt32(hits))
+
+	allowed := linkpreview.NewFetcher(linkpreview.Config{AllowPrivateNetworks: true})
+	_, err = allowed.Fetch(context.Background(), srv.URL)
+	assert.NoError(t, err)
+}
+
+func TestFetch_SizeLimit(t *testing.T) {
+	padding := strings.Repeat("<!-- padding -->", 1000)
+	srv, _ := newServer(t, htmlHandler("<html><head>"+padding+`<meta property="og:title" content="late"></head></html>`))
+
+	f := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client(), MaxBodySize: 1024})
+	_, err := f.Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, linkpreview.ErrNothingToShow)
+}
+
+func TestFetch_Timeout(t *testing.T) {
+	srv, _ := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	})
+
+	f := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client(), Timeout: 50 * time.Millisecond})
+	start := time.Now()
+	_, err := f.Fetch(context.Background(), srv.URL)
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestFetch_RejectsNonHTML(t *testing.T) {
+	srv, _ := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"title":"nope"}`))
+	})
+
+	f := linkpreview.NewFetcher(linkpreview.Config{Client: srv.Client()})
+	_, err := f.Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, linkpreview.ErrNotHTML)
+
+	_, err = f.Fetch(context.Background(), "ftp://example.com/file")
+	assert.ErrorIs(t, err, linkpreview.ErrUnsupportedURL)
+}
@@ -0,0 +1,44 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := model.NormalizeTags([]string{" #Go", "work", "go", "to-do_2", "Работа"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "to-do_2", "work", "работа"}, tags)
+
+	invalid := [][]string{
+		{""},
+		{"#"},
+		{"two words"},
+		{"semi;colon"},
+		{strings.Repeat("x", model.MaxBookmarkTagLength+1)},
+		{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+	}
+	for _, input := range invalid {
+		_, err := model.NormalizeTags(input)
+		assert.ErrorIs(t, err, model.ErrValidation, "%q", input)
+	}
+}
+
+func TestBookmarkSearch_Validate(t *testing.T) {
+	search := model.BookmarkSearch{Query: "  кино ", Tags: []string{"#Films"}}
+	require.NoError(t, search.Validate())
+	assert.Equal(t, "кино", search.Query)
+	assert.Equal(t, []string{"films"}, search.Tags)
+	assert.Equal(t, model.DefaultBookmarkSearchLimit, search.Limit)
+
+	search = model.BookmarkSearch{Limit: 1000}
+	require.NoError(t, search.Validate())
+	assert.Equal(t, model.MaxBookmarkSearchLimit, search.Limit)
+
+	search = model.BookmarkSearch{Query: strings.Repeat("я", 101)}
+	assert.ErrorIs(t, search.Validate(), model.ErrValidation)
+}
@@ -0,0 +1,94 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMentions(t *testing.T) {
+	alice := uuid.New()
+	bob := uuid.New()
+	members := map[string]uuid.UUID{"alice": alice, "bob_1": bob}
+	resolve := func(username string) (uuid.UUID, bool) {
+		id, ok := members[strings.ToLower(username)]
+		return id, ok
+	}
+
+	// «привет» занимает 6 UTF-16 единиц, эмодзи — 2; bob_1! — упоминание bob_1,
+	// mail@alice — не упоминание, @carol нет среди участников
+	entities := model.FindMentions("привет @Alice 🙂 @bob_1! mail@alice @carol", resolve)
+
+	require.Len(t, entities, 2)
+	assert.Equal(t, model.EntityMention, entities[0].Type)
+	assert.Equal(t, 7, entities[0].Offset)
+	assert.Equal(t, 6, entities[0].Length)
+	assert.Equal(t, alice, *entities[0].UserID)
+	assert.Equal(t, 17, entities[1].Offset)
+	assert.Equal(t, 6, entities[1].Length)
+	assert.Equal(t, bob, *entities[1].UserID)
+
+	assert.Equal(t, []uuid.UUID{alice, bob}, model.MentionedUsers(append(entities, entities...)))
+}
+
+func TestValidateEntities(t *testing.T) {
+	body := "жирный 🙂 ссылка"
+
+	valid := []model.MessageEntity{
+		{Type: model.EntityBold, Offset: 0, Length: 6},
+		{Type: model.EntityItalic, Offset: 3, Length: 6},
+		{Type: model.EntityLink, Offset: 10, Length: 6, URL: "https://example.com/a"},
+	}
+	assert.NoError(t, model.ValidateEntities(body, valid))
+
+	invalid := map[string][]model.MessageEntity{
+		"mention from client": {{Type: model.EntityMention, Offset: 0, Length: 6}},
+		"unknown type":        {{Type: "html", Offset: 0, Length: 6}},
+		"out of bounds":       {{Type: model.EntityBold, Offset: 10, Length: 7}},
+		"empty":               {{Type: model.EntityBold, Offset: 0, Length: 0}},
+		"splits emoji":        {{Type: model.EntitySpoiler, Offset: 7, Length: 1}},
+		"javascript link":     {{Type: model.EntityLink, Offset: 10, Length: 6, URL: "javascript:alert(1)"}},
+		"url outside link":    {{Type: model.EntityCode, Offset: 0, Length: 6, URL: "https://example.com"}},
+		"same type overlap": {
+			{Type: model.EntityBold, Offset: 0, Length: 6},
+			{Type: model.EntityItalic, Offset: 2, Length: 1},
+			{Type: model.EntityBold, Offset: 5, Length: 3},
+		},
+	}
+	for name, entities := range invalid {
+		err := model.ValidateEntities(body, entities)
+		assert.ErrorIs(t, err, model.ErrValidation, name)
+	}
+}
+
+func TestMergeEntities_SkipsMentionsInCode(t *testing.T) {
+	userID := uuid.New()
+	formatting := []model.MessageEntity{
+		{Type: model.EntityCode, Offset: 0, Length: 8},
+		{Type: model.EntityBold, Offset: 9, Length: 4},
+	}
+	mentions := []model.MessageEntity{
+		{Type: model.EntityMention, Offset: 2, Length: 4, UserID: &userID},
+		{Type: model.EntityMention, Offset: 9, Length: 4, UserID: &userID},
+	}
+
+	merged := model.MergeEntities(formatting, mentions)
+
+	require.Len(t, merged, 3)
+	assert.Equal(t, model.EntityCode, merged[0].Type)
+	assert.Equal(t, model.EntityBold, merged[1].Type)
+	assert.Equal(t, model.EntityMention, merged[2].Type)
+	assert.Equal(t, 9, merged[2].Offset)
+}
+
+func TestPreviewURL(t *testing.T) {
+	assert.Equal(t, "https://example.com/a?b=1", model.PreviewURL("see https://example.com/a?b=1.", nil))
+	assert.Equal(t, "", model.PreviewURL("no links here", nil))
+
+	linked := []model.MessageEntity{{Type: model.EntityLink, Offset: 0, Length: 4, URL: "https://docs.example.com"}}
+	assert.Equal(t, "https://docs.example.com", model.PreviewURL("docs and https://example.com", linked))
+}
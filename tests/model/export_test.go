@@ -0,0 +1,28 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatExport_UpdateProgress(t *testing.T) {
+	e := model.ChatExport{Status: model.ExportRunning}
+	e.UpdateProgress()
+	assert.Equal(t, 0, e.Progress)
+
+	e.TotalMessages, e.ProcessedMessages = 150, 150
+	e.TotalFiles, e.ProcessedFiles = 50, 0
+	e.UpdateProgress()
+	assert.Equal(t, 75, e.Progress)
+
+	// Пока архив не загружен, выгрузка не считается готовой
+	e.ProcessedFiles = 50
+	e.UpdateProgress()
+	assert.Equal(t, 99, e.Progress)
+
+	e.Status = model.ExportDone
+	e.UpdateProgress()
+	assert.Equal(t, 100, e.Progress)
+}
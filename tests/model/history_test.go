@@ -0,0 +1,59 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageCursor_RoundTrip(t *testing.T) {
+	cursor := model.MessageCursor{SentAt: time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC), ID: uuid.New()}
+
+	parsed, err := model.ParseMessageCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.SentAt.Equal(parsed.SentAt))
+	assert.Equal(t, cursor.ID, parsed.ID)
+
+	for _, bad := range []string{"", "!!!", "bm90LWEtY3Vyc29y", "MTIzX25vdC1hLXV1aWQ"} {
+		_, err := model.ParseMessageCursor(bad)
+		assert.ErrorIs(t, err, model.ErrValidation, bad)
+	}
+}
+
+func TestHistoryQuery_Validate(t *testing.T) {
+	q := model.HistoryQuery{}
+	require.NoError(t, q.Validate())
+	assert.Equal(t, model.DefaultHistoryLimit, q.Limit)
+
+	q = model.HistoryQuery{Limit: 1000}
+	require.NoError(t, q.Validate())
+	assert.Equal(t, model.MaxHistoryLimit, q.Limit)
+
+	cursor := &model.MessageCursor{SentAt: time.Now(), ID: uuid.New()}
+	q = model.HistoryQuery{Before: cursor, After: cursor}
+	assert.ErrorIs(t, q.Validate(), model.ErrValidation)
+
+	q = model.HistoryQuery{Limit: -1}
+	assert.ErrorIs(t, q.Validate(), model.ErrValidation)
+}
+
+func TestWindowQuery_Validate(t *testing.T) {
+	id := uuid.New()
+	date := time.Now()
+
+	q := model.WindowQuery{MessageID: &id}
+	require.NoError(t, q.Validate())
+	assert.Equal(t, model.DefaultWindowLimit, q.Limit)
+
+	q = model.WindowQuery{Date: &date, Limit: 500}
+	require.NoError(t, q.Validate())
+	assert.Equal(t, model.MaxWindowLimit, q.Limit)
+
+	for _, bad := range []model.WindowQuery{{}, {MessageID: &id, Date: &date}, {Date: &date, Limit: -5}} {
+		assert.ErrorIs(t, bad.Validate(), model.ErrValidation)
+	}
+}
@@ -0,0 +1,98 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const telegramExportSample = `{
+ "name": "Team",
+ "type": "private_supergroup",
+ "id": 42,
+ "messages": [
+  {"id": 1, "type": "service", "date": "2024-01-01T10:00:00", "actor": "Alice", "action": "create_group", "text": ""},
+  {"id": 2, "type": "message", "date": "2024-01-01T10:00:05", "date_unixtime": "1704103205",
+   "from": "Alice", "from_id": "user1", "text": ["see ", {"type": "bold", "text": "docs"}, " at ", {"type": "text_link", "text": "here", "href": "https://example.com"}],
+   "text_entities": [{"type": "plain", "text": "see "}, {"type": "bold", "text": "docs"}, {"type": "plain", "text": " at "}, {"type": "text_link", "text": "here", "href": "https://example.com"}]},
+  {"id": 3, "type": "message", "date": "2024-01-01T10:01:00", "edited": "2024-01-01T10:02:00",
+   "from": null, "reply_to_message_id": 2, "text": "ok", "photo": "photos/photo_1.jpg"},
+  {"id": 4, "type": "message", "date": "2024-01-01T10:03:00", "from": "Bob",
+   "file": "(File not included. Change data exporting settings to download.)", "media_type": "video_file", "text": ""}
+ ]
+}`
+
+func TestTelegramExport_Parse(t *testing.T) {
+	var export model.TelegramExport
+	require.NoError(t, easyjson.Unmarshal([]byte(telegramExportSample), &export))
+	require.NoError(t, export.Validate())
+	require.Len(t, export.Messages, 4)
+
+	msg := export.Messages[1]
+	sentAt, err := msg.SentAt()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 5, 0, time.UTC), sentAt)
+	assert.Equal(t, "Alice", msg.Author())
+
+	body, entities := msg.Content()
+	assert.Equal(t, "see docs at here", body)
+	assert.Equal(t, []model.MessageEntity{
+		{Type: model.EntityBold, Offset: 4, Length: 4},
+		{Type: model.EntityLink, Offset: 12, Length: 4, URL: "https://example.com"},
+	}, entities)
+
+	reply := export.Messages[2]
+	assert.Equal(t, model.DeletedTelegramAuthor, reply.Author())
+	assert.Equal(t, int64(2), reply.ReplyToMessageID)
+	require.NotNil(t, reply.EditedAt())
+	body, _ = reply.Content()
+	assert.Equal(t, "ok", body)
+	files, missing := reply.Attachments()
+	require.Len(t, files, 1)
+	assert.True(t, files[0].IsPhoto)
+	assert.Equal(t, "photo_1.jpg", files[0].Name)
+	assert.Empty(t, missing)
+
+	files, missing = export.Messages[3].Attachments()
+	assert.Empty(t, files)
+	assert.Len(t, missing, 1)
+}
+
+func TestTelegramMessage_ContentDropsInvalidEntities(t *testing.T) {
+	msg := model.TelegramMessage{TextEntities: []model.TelegramTextEntity{
+		{Type: "link", Text: "example.com"},
+		{Type: "plain", Text: " and "},
+		{Type: "underline", Text: "this"},
+	}}
+	body, entities := msg.Content()
+	assert.Equal(t, "example.com and this", body)
+	assert.Empty(t, entities)
+}
+
+func TestTelegramExport_ValidateRejectsFullExport(t *testing.T) {
+	var export model.TelegramExport
+	require.NoError(t, easyjson.Unmarshal([]byte(`{"about": "...", "chats": {"list": []}}`), &export))
+	assert.ErrorIs(t, export.Validate(), model.ErrValidation)
+}
+
+func TestSplitImportedText(t *testing.T) {
+	parts := model.SplitImportedText("short", nil, 10)
+	require.Len(t, parts, 1)
+	assert.Equal(t, "short", parts[0].Body)
+
+	// «😀» занимает две единицы UTF-16, разметка на стыке делится между частями
+	body := "ab😀cdefgh"
+	entities := []model.MessageEntity{{Type: model.EntityBold, Offset: 1, Length: 5}}
+	parts = model.SplitImportedText(body, entities, 4)
+	require.Len(t, parts, 3)
+	assert.Equal(t, "ab😀c", parts[0].Body)
+	assert.Equal(t, "defg", parts[1].Body)
+	assert.Equal(t, "h", parts[2].Body)
+	assert.Equal(t, []model.MessageEntity{{Type: model.EntityBold, Offset: 1, Length: 4}}, parts[0].Entities)
+	assert.Equal(t, []model.MessageEntity{{Type: model.EntityBold, Offset: 0, Length: 1}}, parts[1].Entities)
+	assert.Empty(t, parts[2].Entities)
+}
@@ -0,0 +1,50 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkDeleteInput_Validate(t *testing.T) {
+	id := uuid.New()
+	other := uuid.New()
+
+	input := model.BulkDeleteInput{MessageIDs: []uuid.UUID{id, other, id}}
+	require.NoError(t, input.Validate())
+	assert.Equal(t, model.DeleteForEveryone, input.Mode)
+	assert.Equal(t, []uuid.UUID{id, other}, input.MessageIDs)
+
+	tooMany := make([]uuid.UUID, model.MaxBulkDeleteMessages+1)
+	for i := range tooMany {
+		tooMany[i] = uuid.New()
+	}
+	for _, bad := range []model.BulkDeleteInput{
+		{},
+		{MessageIDs: tooMany},
+		{MessageIDs: []uuid.UUID{id}, Mode: "nobody"},
+	} {
+		assert.ErrorIs(t, bad.Validate(), model.ErrValidation)
+	}
+}
+
+func TestMessage_ValidateVoice(t *testing.T) {
+	voice := &model.Payload{URL: "/files/v", ContentType: "voice", DurationMs: 1500}
+
+	withCaption := model.Message{Body: "послушай", VoiceDTO: voice}
+	require.NoError(t, withCaption.Validate())
+	assert.Len(t, withCaption.Payloads(), 1)
+
+	require.NoError(t, (&model.Message{VoiceDTO: voice}).Validate())
+
+	for _, bad := range []model.Message{
+		{VoiceDTO: voice, Sticker: "/files/s"},
+		{VoiceDTO: voice, FilesDTO: []model.Payload{{URL: "/files/a", ContentType: "file"}}},
+		{VoiceDTO: voice, PollInput: &model.PollInput{Question: "?", Options: []string{"a", "b"}}},
+	} {
+		assert.ErrorIs(t, bad.Validate(), model.ErrValidation)
+	}
+}
@@ -0,0 +1,57 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollInput_Validate(t *testing.T) {
+	closesAt := time.Now().Add(time.Hour)
+	poll := model.PollInput{Question: "  Куда идём?  ", Options: []string{" Кино", "Театр "}, ClosesAt: &closesAt}
+	require.NoError(t, poll.Validate())
+	assert.Equal(t, "Куда идём?", poll.Question)
+	assert.Equal(t, []string{"Кино", "Театр"}, poll.Options)
+
+	past := time.Now().Add(-time.Minute)
+	tooFar := time.Now().Add(model.MaxPollDuration + time.Hour)
+	manyOptions := make([]string, model.MaxPollOptions+1)
+	for i := range manyOptions {
+		manyOptions[i] = strings.Repeat("x", i+1)
+	}
+
+	invalid := []model.PollInput{
+		{Question: " ", Options: []string{"a", "b"}},
+		{Question: strings.Repeat("я", model.MaxPollQuestionLength+1), Options: []string{"a", "b"}},
+		{Question: "?", Options: []string{"a"}},
+		{Question: "?", Options: manyOptions},
+		{Question: "?", Options: []string{"a", ""}},
+		{Question: "?", Options: []string{"Да", "да"}},
+		{Question: "?", Options: []string{"a", "b"}, ClosesAt: &past},
+		{Question: "?", Options: []string{"a", "b"}, ClosesAt: &tooFar},
+	}
+	for _, p := range invalid {
+		assert.ErrorIs(t, p.Validate(), model.ErrValidation, "%+v", p)
+	}
+}
+
+func TestPollVoteInput_Validate(t *testing.T) {
+	assert.NoError(t, (&model.PollVoteInput{Options: []int{0, 3}}).Validate())
+
+	for _, options := range [][]int{nil, {-1}, {model.MaxPollOptions}, {1, 1}} {
+		assert.ErrorIs(t, (&model.PollVoteInput{Options: options}).Validate(), model.ErrValidation, "%v", options)
+	}
+}
+
+func TestMessageValidate_PollUsesQuestionAsBody(t *testing.T) {
+	msg := model.Message{PollInput: &model.PollInput{Question: "Обед?", Options: []string{"Да", "Нет"}}}
+	require.NoError(t, msg.Validate())
+	assert.Equal(t, "Обед?", msg.Body)
+
+	withSticker := model.Message{Sticker: "cat", PollInput: &model.PollInput{Question: "Обед?", Options: []string{"Да", "Нет"}}}
+	assert.ErrorIs(t, withSticker.Validate(), model.ErrValidation)
+}
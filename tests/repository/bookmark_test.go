@@ -0,0 +1,163 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCreateBookmark_CopiesIntoSavedChat(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBookmarkRepo(db)
+
+	userID := uuid.New()
+	authorID := uuid.New()
+	sourceChatID := uuid.New()
+	savedChatID := uuid.New()
+	srcID := uuid.New()
+	copyID := uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)SELECT id FROM message.*WHERE chat_id = \$1 AND id = ANY`).
+		WithArgs(sourceChatID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(srcID))
+	mock.ExpectQuery(`(?s)INSERT INTO message \(.*forwarded_from_user_id`).
+		WithArgs(srcID, userID, savedChatID, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(copyID))
+	mock.ExpectExec(`(?s)INSERT INTO message_payload`).
+		WithArgs(copyID, srcID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`(?s)INSERT INTO message_entity.*FROM message_entity.*type <> 'mention'`).
+		WithArgs(copyID, srcID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`(?s)INSERT INTO poll .*FROM poll.*INSERT INTO poll_option`).
+		WithArgs(copyID, srcID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`(?s)INSERT INTO bookmark.*ON CONFLICT DO NOTHING.*RETURNING created_at`).
+		WithArgs(copyID, userID, sourceChatID, srcID, pq.Array([]string{"go"}), savedChatID).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)SELECT.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(copyID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			copyID, nil, savedChatID, userID, "hello", time.Now(), false,
+			nil, "me", "default", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			authorID, "author", sourceChatID, createdAt,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			nil,
+			nil,
+		))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	bookmark, err := repo.CreateBookmark(ctx, userID, savedChatID, sourceChatID, srcID, []string{"go"})
+	require.NoError(t, err)
+	assert.Equal(t, copyID, bookmark.Message.ID)
+	assert.Equal(t, savedChatID, bookmark.Message.ChatID)
+	assert.Equal(t, srcID, *bookmark.SourceMessageID)
+	assert.Equal(t, []string{"go"}, bookmark.Tags)
+	assert.Equal(t, createdAt, bookmark.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateBookmark_AlreadySaved(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBookmarkRepo(db)
+
+	userID := uuid.New()
+	savedChatID := uuid.New()
+	messageID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO bookmark.*RETURNING created_at`).
+		WithArgs(messageID, userID, savedChatID, messageID, sqlmock.AnyArg(), savedChatID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM message WHERE id = \$1 AND chat_id = \$2`).
+		WithArgs(messageID, savedChatID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, err = repo.CreateBookmark(ctx, userID, savedChatID, savedChatID, messageID, []string{})
+	assert.ErrorIs(t, err, repository.ErrBookmarkExists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateBookmarkTags_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBookmarkRepo(db)
+
+	userID := uuid.New()
+	messageID := uuid.New()
+
+	mock.ExpectExec(`UPDATE bookmark SET tags = \$3 WHERE user_id = \$1 AND message_id = \$2`).
+		WithArgs(userID, messageID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	err = repo.UpdateBookmarkTags(ctx, userID, messageID, []string{"todo"})
+	assert.ErrorIs(t, err, repository.ErrBookmarkNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchBookmarks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewBookmarkRepo(db)
+
+	userID := uuid.New()
+	savedChatID := uuid.New()
+	sourceChatID := uuid.New()
+	messageID := uuid.New()
+	createdAt := time.Now()
+
+	mock.ExpectQuery(`(?s)FROM bookmark b.*ILIKE.*b.tags @> \$3::text\[\].*message_hidden.*ORDER BY b.created_at DESC`).
+		WithArgs(userID, `50\%`, pq.Array([]string{"go"}), 25).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "source_chat_id", "source_message_id", "tags", "created_at"}).
+			AddRow(messageID, sourceChatID, nil, `{go,work}`, createdAt))
+	mock.ExpectQuery(`(?s)SELECT.*FROM message m.*WHERE m.id = ANY\(\$1::uuid\[\]\)`).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			messageID, nil, savedChatID, userID, "скидка 50%", time.Now(), false,
+			nil, "me", "default", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			nil, nil, nil, nil,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			nil,
+			nil,
+		))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	bookmarks, err := repo.SearchBookmarks(ctx, userID, model.BookmarkSearch{Query: "50%", Tags: []string{"go"}, Limit: 25})
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 1)
+	assert.Equal(t, "скидка 50%", bookmarks[0].Message.Body)
+	assert.Equal(t, sourceChatID, *bookmarks[0].SourceChatID)
+	assert.Nil(t, bookmarks[0].SourceMessageID)
+	assert.Equal(t, []string{"go", "work"}, bookmarks[0].Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
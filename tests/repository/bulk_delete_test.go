@@ -0,0 +1,136 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDeleteMessages_TombstonesBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	authorID := uuid.New()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)SELECT user_id.*FROM message.*WHERE chat_id = \$1 AND id = ANY\(\$2::uuid\[\]\).*FOR UPDATE`).
+		WithArgs(chatID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(authorID).AddRow(authorID))
+	mock.ExpectExec(`(?s)UPDATE message.*deleted_at = CURRENT_TIMESTAMP.*WHERE id = ANY`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`(?s)DELETE FROM message_payload WHERE message_id = ANY.*SELECT DISTINCT r.file_path`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"file_path"}).AddRow("/files/abc"))
+	for _, table := range []string{"message_reaction", "message_version", "message_entity", "poll", "pinned_message"} {
+		mock.ExpectExec(`DELETE FROM ` + table + ` WHERE message_id = ANY`).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectCommit()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	files, err := repo.DeleteMessages(ctx, chatID, ids, &authorID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/files/abc"}, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMessages_ForeignMessageRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	authorID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)SELECT user_id.*FOR UPDATE`).
+		WithArgs(chatID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(authorID).AddRow(uuid.New()))
+	mock.ExpectRollback()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, err = repo.DeleteMessages(ctx, chatID, []uuid.UUID{uuid.New(), uuid.New()}, &authorID)
+	assert.ErrorIs(t, err, repository.ErrNotMessageAuthor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHideMessages_MissingMessage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectQuery(`(?s)WITH target AS.*INSERT INTO message_hidden.*WHERE \(SELECT COUNT\(\*\) FROM target\) = \$4`).
+		WithArgs(chatID, userID, sqlmock.AnyArg(), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	err = repo.HideMessages(ctx, chatID, userID, []uuid.UUID{uuid.New(), uuid.New()})
+	assert.ErrorIs(t, err, repository.ErrMessagesNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClearHistory(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	lastSentAt := time.Now()
+
+	mock.ExpectQuery(`(?s)DELETE FROM message WHERE chat_id = \$1.*array_agg\(file_path\)`).
+		WithArgs(chatID).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "files"}).AddRow(lastSentAt, `{/files/a,/files/b}`))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	before, files, err := repo.ClearHistory(ctx, chatID)
+	require.NoError(t, err)
+	require.NotNil(t, before)
+	assert.Equal(t, lastSentAt, *before)
+	assert.Equal(t, []string{"/files/a", "/files/b"}, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClearHistory_EmptyChat(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+
+	mock.ExpectQuery(`(?s)DELETE FROM message WHERE chat_id = \$1`).
+		WithArgs(chatID).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "files"}).AddRow(nil, `{}`))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	before, files, err := repo.ClearHistory(ctx, chatID)
+	require.NoError(t, err)
+	assert.Nil(t, before)
+	assert.Empty(t, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
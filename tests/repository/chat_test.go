@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
@@ -32,10 +33,10 @@ func TestGetChatByID(t *testing.T) {
 		Title:      "Test Chat",
 	}
 
-	rows := sqlmock.NewRows([]string{"id", "avatar_path", "type", "title"}).
-		AddRow(expectedChat.ID, expectedChat.AvatarPath, expectedChat.Type, expectedChat.Title)
+	rows := sqlmock.NewRows([]string{"id", "avatar_path", "type", "title", "message_ttl", "is_saved"}).
+		AddRow(expectedChat.ID, expectedChat.AvatarPath, expectedChat.Type, expectedChat.Title, nil, false)
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, avatar_path, type, title FROM chat WHERE id = $1")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, avatar_path, type, title, message_ttl, saved_by IS NOT NULL FROM chat WHERE id = $1")).
 		WithArgs(chatID).
 		WillReturnRows(rows)
 
@@ -71,17 +72,24 @@ func TestGetChats(t *testing.T) {
 		Type:       "dialog",
 		Title:      "Chat 2",
 	}
+	lastReadID := uuid.New()
+	draftParentID := uuid.New()
+	draftUpdatedAt := time.Now()
 
 	rows := sqlmock.NewRows([]string{
-		"c.id", "c.avatar_path", "c.type", "c.title", "uc.send_notifications",
+		"c.id", "c.avatar_path", "c.type", "c.title", "uc.send_notifications", "is_saved",
 		"m.id", "m.user_id", "m.body", "m.sent_at", "count_users",
+		"unread_count", "unread_mentions", "last_read_message_id",
+		"d.body", "d.entities", "d.parent_message_id", "d.updated_at",
 	}).
-		AddRow(chat1.ID, chat1.AvatarPath, chat1.Type, chat1.Title, true,
-			nil, nil, nil, nil, 2).
-		AddRow(chat2.ID, chat2.AvatarPath, chat2.Type, chat2.Title, false,
-			nil, nil, nil, nil, 1)
-
-	mock.ExpectQuery("(?s)SELECT c\\.id.*FROM chat c.*WHERE uc\\.user_id = \\$1.*ORDER BY m\\.sent_at DESC NULLS LAST").
+		AddRow(chat1.ID, chat1.AvatarPath, chat1.Type, chat1.Title, true, false,
+			nil, nil, nil, nil, 2, 3, 1, lastReadID,
+			"half-typed", []byte(`[{"type":"bold","offset":0,"length":4}]`), draftParentID, draftUpdatedAt).
+		AddRow(chat2.ID, chat2.AvatarPath, chat2.Type, chat2.Title, false, true,
+			nil, nil, nil, nil, 1, 0, 0, nil,
+			nil, nil, nil, nil)
+
+	mock.ExpectQuery("(?s)SELECT c\\.id.*FROM chat c.*LEFT JOIN message_draft d.*WHERE uc\\.user_id = \\$1.*ORDER BY m\\.sent_at DESC NULLS LAST").
 		WithArgs(userID).
 		WillReturnRows(rows)
 
@@ -90,6 +98,18 @@ func TestGetChats(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, chats, 2)
 	assert.Equal(t, chat2.ID, lastChatID) // предполагается, что он будет последним, если сортировка работает
+	assert.Equal(t, 3, chats[0].UnreadCount)
+	assert.Equal(t, 1, chats[0].UnreadMentions)
+	require.NotNil(t, chats[0].LastReadMessageID)
+	assert.Equal(t, lastReadID, *chats[0].LastReadMessageID)
+	assert.Nil(t, chats[1].LastReadMessageID)
+	require.NotNil(t, chats[0].Draft)
+	assert.Equal(t, "half-typed", chats[0].Draft.Body)
+	assert.Equal(t, draftParentID, *chats[0].Draft.ParentMessageID)
+	require.Len(t, chats[0].Draft.Entities, 1)
+	assert.Nil(t, chats[1].Draft)
+	assert.False(t, chats[0].IsSaved)
+	assert.True(t, chats[1].IsSaved)
 
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
@@ -276,7 +296,7 @@ func TestGetChatByID_NotFound(t *testing.T) {
 	repo := repository.NewChatRepo(db)
 	chatID := uuid.New()
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, avatar_path, type, title FROM chat WHERE id = $1")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, avatar_path, type, title, message_ttl, saved_by IS NOT NULL FROM chat WHERE id = $1")).
 		WithArgs(chatID).
 		WillReturnError(sql.ErrNoRows)
 
@@ -321,3 +341,98 @@ func TestRemoveUserFromChatByUsername_UserNotFound(t *testing.T) {
 	err = repo.RemoveUserFromChatByUsername(ctx, username, chatID)
 	assert.Error(t, err)
 }
+
+func TestGetPinnedMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewChatRepo(db)
+	chatID := uuid.New()
+	messageID := uuid.New()
+	authorID := uuid.New()
+	pinnedBy := uuid.New()
+	pinnedAt := time.Now()
+
+	mock.ExpectQuery(`(?s)SELECT m.id.*FROM pinned_message p.*WHERE p.chat_id = \$1.*ORDER BY p.pinned_at DESC`).
+		WithArgs(chatID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "username", "body", "message_type", "pinned_by", "pinned_at"}).
+			AddRow(messageID, authorID, "author", "pinned text", "default", pinnedBy, pinnedAt))
+
+	pins, err := repo.GetPinnedMessages(context.Background(), chatID)
+	require.NoError(t, err)
+	require.Len(t, pins, 1)
+	assert.Equal(t, messageID, pins[0].MessageID)
+	assert.Equal(t, "pinned text", pins[0].Body)
+	require.NotNil(t, pins[0].PinnedBy)
+	assert.Equal(t, pinnedBy, *pins[0].PinnedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPinMessage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewChatRepo(db)
+	chatID := uuid.New()
+	messageID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectExec(`(?s)INSERT INTO pinned_message.*ON CONFLICT \(chat_id, message_id\) DO NOTHING`).
+		WithArgs(chatID, messageID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.PinMessage(context.Background(), chatID, messageID, userID)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOrCreateSavedChat_CreatesWhenMissing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewChatRepo(db)
+	userID := uuid.New()
+	chatID := uuid.New()
+
+	mock.ExpectQuery(`SELECT id FROM chat WHERE saved_by = \$1`).
+		WithArgs(userID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`(?s)INSERT INTO chat \(type, title, saved_by\).*ON CONFLICT \(saved_by\) DO NOTHING.*INSERT INTO user_chat`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(chatID))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	got, err := repo.GetOrCreateSavedChat(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, chatID, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOrCreateSavedChat_LostRace(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewChatRepo(db)
+	userID := uuid.New()
+	chatID := uuid.New()
+
+	mock.ExpectQuery(`SELECT id FROM chat WHERE saved_by = \$1`).
+		WithArgs(userID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`(?s)INSERT INTO chat \(type, title, saved_by\)`).
+		WithArgs(userID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(`SELECT id FROM chat WHERE saved_by = \$1`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(chatID))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	got, err := repo.GetOrCreateSavedChat(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, chatID, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSaveDraft_Upserts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewDraftRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	parentID := uuid.New()
+	updatedAt := time.Now()
+
+	mock.ExpectQuery(`(?s)INSERT INTO message_draft.*ON CONFLICT \(user_id, chat_id\) DO UPDATE.*RETURNING updated_at`).
+		WithArgs(userID, chatID, "черновик", sqlmock.AnyArg(), &parentID).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	saved, err := repo.SaveDraft(ctx, userID, &model.Draft{
+		ChatID:          chatID,
+		Body:            "черновик",
+		Entities:        []model.MessageEntity{{Type: model.EntityItalic, Offset: 0, Length: 8}},
+		ParentMessageID: &parentID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, updatedAt, saved.UpdatedAt)
+	assert.Equal(t, chatID, saved.ChatID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDraft_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewDraftRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+
+	mock.ExpectQuery(`(?s)SELECT body, entities, parent_message_id, updated_at.*FROM message_draft`).
+		WithArgs(userID, chatID).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, err = repo.GetDraft(ctx, userID, chatID)
+	assert.ErrorIs(t, err, repository.ErrDraftNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteDraft_ReportsWhetherDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewDraftRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+
+	mock.ExpectExec(`DELETE FROM message_draft WHERE user_id = \$1 AND chat_id = \$2`).
+		WithArgs(userID, chatID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM message_draft`).
+		WithArgs(userID, chatID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	deleted, err := repo.DeleteDraft(ctx, userID, chatID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = repo.DeleteDraft(ctx, userID, chatID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
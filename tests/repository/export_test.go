@@ -0,0 +1,119 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var exportColumns = []string{
+	"id", "chat_id", "user_id", "status", "processed_messages", "total_messages",
+	"processed_files", "total_files", "file_path", "error", "created_at", "finished_at",
+}
+
+func TestCreateExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewExportRepo(db)
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+
+	exportID, chatID, userID := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery(`(?s)INSERT INTO chat_export \(chat_id, user_id\).*RETURNING`).
+		WithArgs(chatID, userID).
+		WillReturnRows(sqlmock.NewRows(exportColumns).AddRow(
+			exportID, chatID, userID, "pending", 0, 0, 0, 0, nil, nil, time.Now(), nil))
+
+	export, err := repo.CreateExport(ctx, chatID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, exportID, export.ID)
+	assert.Equal(t, model.ExportPending, export.Status)
+	assert.Empty(t, export.FileURL)
+	assert.Nil(t, export.FinishedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateExport_AlreadyRunning(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewExportRepo(db)
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+
+	mock.ExpectQuery(`(?s)INSERT INTO chat_export`).
+		WillReturnError(&pq.Error{Code: "23505"})
+
+	_, err = repo.CreateExport(ctx, uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, repository.ErrExportInProgress)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetExport_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewExportRepo(db)
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+
+	exportID, userID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`(?s)FROM chat_export.*WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(exportID, userID).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetExport(ctx, exportID, userID)
+	assert.ErrorIs(t, err, repository.ErrExportNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewExportRepo(db)
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+
+	exportID, chatID, userID := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery(`(?s)UPDATE chat_export.*SET status = 'running'.*FOR UPDATE SKIP LOCKED`).
+		WithArgs(float64(600)).
+		WillReturnRows(sqlmock.NewRows(exportColumns).AddRow(
+			exportID, chatID, userID, "running", 0, 40, 0, 0, nil, nil, time.Now(), nil))
+
+	export, err := repo.ClaimExport(ctx, 10*time.Minute)
+	require.NoError(t, err)
+	require.NotNil(t, export)
+	assert.Equal(t, model.ExportRunning, export.Status)
+	assert.Equal(t, userID, export.UserID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimExport_EmptyQueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewExportRepo(db)
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+
+	mock.ExpectQuery(`(?s)UPDATE chat_export`).
+		WillReturnError(sql.ErrNoRows)
+
+	export, err := repo.ClaimExport(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.Nil(t, export)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
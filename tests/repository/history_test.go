@@ -0,0 +1,145 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func historyRow(rows *sqlmock.Rows, id, chatID, userID uuid.UUID, messageType string, sentAt time.Time) *sqlmock.Rows {
+	return rows.AddRow(
+		id, nil, chatID, userID, "text", sentAt, false,
+		nil, "user", messageType, nil, []byte(`[]`),
+		nil, nil, nil, nil, nil, 0,
+		nil, nil, nil, nil,
+		nil, 0, nil, nil,
+		[]byte(`[]`),
+		nil,
+		nil,
+	)
+}
+
+func TestGetMessagePage_LatestLoadsPayloadsInOneQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	rows := sqlmock.NewRows(messageColumns)
+	historyRow(rows, ids[0], chatID, userID, "with_payload", now)
+	historyRow(rows, ids[1], chatID, userID, "default", now.Add(-time.Minute))
+	historyRow(rows, ids[2], chatID, userID, "with_payload", now.Add(-2*time.Minute))
+
+	mock.ExpectQuery(`(?s)WHERE m.chat_id = \$1.*message_hidden.*ORDER BY m.sent_at DESC, m.id DESC\s+LIMIT \$3`).
+		WithArgs(chatID, userID, 3).
+		WillReturnRows(rows)
+	mock.ExpectQuery(`(?s)FROM public.message_payload\s+WHERE message_id = ANY\(\$1::uuid\[\]\)`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "file_path", "file_name", "content_type", "file_size", "duration_ms", "waveform"}).
+			AddRow(ids[0], "/files/a", "a.txt", "file", 10, nil, nil))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	page, err := repo.GetMessagePage(ctx, chatID, userID, model.HistoryQuery{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Messages, 2)
+	assert.Equal(t, ids[0], page.Messages[0].ID)
+	require.Len(t, page.Messages[0].FilesDTO, 1)
+	assert.Equal(t, "a.txt", page.Messages[0].FilesDTO[0].Filename)
+	assert.True(t, page.HasOlder)
+	assert.False(t, page.HasNewer)
+
+	older, err := model.ParseMessageCursor(page.OlderCursor)
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], older.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessagePage_AfterCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	userID := uuid.New()
+	cursor := model.MessageCursor{SentAt: time.Now().Add(-time.Hour).UTC(), ID: uuid.New()}
+	first := uuid.New()
+	second := uuid.New()
+
+	rows := sqlmock.NewRows(messageColumns)
+	historyRow(rows, first, chatID, userID, "default", cursor.SentAt.Add(time.Minute))
+	historyRow(rows, second, chatID, userID, "default", cursor.SentAt.Add(2*time.Minute))
+
+	mock.ExpectQuery(`(?s)AND \(m.sent_at, m.id\) > \(\$4, \$5\).*ORDER BY m.sent_at ASC, m.id ASC`).
+		WithArgs(chatID, userID, 3, cursor.SentAt, cursor.ID).
+		WillReturnRows(rows)
+	mock.ExpectQuery(`(?s)SELECT EXISTS.*\(m.sent_at, m.id\) <= \(\$3, \$4\)`).
+		WithArgs(chatID, userID, cursor.SentAt, cursor.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	page, err := repo.GetMessagePage(ctx, chatID, userID, model.HistoryQuery{After: &cursor, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Messages, 2)
+	assert.Equal(t, second, page.Messages[0].ID, "страница идёт от новых к старым")
+	assert.Equal(t, first, page.Messages[1].ID)
+	assert.False(t, page.HasNewer)
+	assert.True(t, page.HasOlder)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessageWindow_AroundDate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	userID := uuid.New()
+	anchor := model.MessageCursor{SentAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), ID: uuid.Nil}
+	firstAfter := uuid.New()
+	secondAfter := uuid.New()
+	lastBefore := uuid.New()
+
+	newer := sqlmock.NewRows(messageColumns)
+	historyRow(newer, firstAfter, chatID, userID, "default", anchor.SentAt.Add(time.Minute))
+	historyRow(newer, secondAfter, chatID, userID, "default", anchor.SentAt.Add(2*time.Minute))
+	older := sqlmock.NewRows(messageColumns)
+	historyRow(older, lastBefore, chatID, userID, "default", anchor.SentAt.Add(-time.Minute))
+
+	mock.ExpectQuery(`(?s)AND \(m.sent_at, m.id\) >= \(\$4, \$5\).*ORDER BY m.sent_at ASC, m.id ASC`).
+		WithArgs(chatID, userID, 2, anchor.SentAt, anchor.ID).
+		WillReturnRows(newer)
+	mock.ExpectQuery(`(?s)AND \(m.sent_at, m.id\) < \(\$4, \$5\).*ORDER BY m.sent_at DESC, m.id DESC`).
+		WithArgs(chatID, userID, 2, anchor.SentAt, anchor.ID).
+		WillReturnRows(older)
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	page, err := repo.GetMessageWindow(ctx, chatID, userID, anchor, 1)
+	require.NoError(t, err)
+	require.Len(t, page.Messages, 2)
+	assert.Equal(t, firstAfter, page.Messages[0].ID)
+	assert.Equal(t, lastBefore, page.Messages[1].ID)
+	require.NotNil(t, page.AnchorID)
+	assert.Equal(t, firstAfter, *page.AnchorID)
+	assert.True(t, page.HasNewer)
+	assert.False(t, page.HasOlder)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
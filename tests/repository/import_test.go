@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestImportChat(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewImportRepo(db)
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+
+	ownerID, chatID := uuid.New(), uuid.New()
+	first := uuid.New()
+	chat := &model.ImportedChat{
+		Title:   "Team",
+		OwnerID: ownerID,
+		Messages: []model.ImportedMessage{
+			{
+				ID: first, Author: "Alice", Body: "hello", SentAt: time.Now().Add(-time.Hour),
+				Entities: []model.MessageEntity{{Type: model.EntityBold, Offset: 0, Length: 5}},
+			},
+			{
+				ID: uuid.New(), ParentMessageID: &first, Author: "Bob", SentAt: time.Now(),
+				Payloads: []model.Payload{{URL: "/files/x", Filename: "a.jpg", ContentType: "photo", Size: 10}},
+			},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO chat \(type, title.*VALUES \('group', \$1`).
+		WithArgs("Team").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(chatID))
+	mock.ExpectExec(`(?s)INSERT INTO user_chat.*'owner'`).
+		WithArgs(ownerID, chatID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`(?s)INSERT INTO message \(.*imported_author.*jsonb_to_recordset`).
+		WithArgs(sqlmock.AnyArg(), chatID, ownerID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`(?s)INSERT INTO message_entity.*jsonb_to_recordset`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`(?s)INSERT INTO message_payload.*jsonb_to_recordset`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.ImportChat(ctx, chat))
+	assert.Equal(t, chatID, chat.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportChat_RollsBackOnFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewImportRepo(db)
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+
+	chat := &model.ImportedChat{
+		Title:    "Team",
+		OwnerID:  uuid.New(),
+		Messages: []model.ImportedMessage{{ID: uuid.New(), Author: "Alice", Body: "hi", SentAt: time.Now()}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO chat`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectExec(`(?s)INSERT INTO user_chat`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`(?s)INSERT INTO message \(`).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	err = repo.ImportChat(ctx, chat)
+	assert.ErrorIs(t, err, repository.ErrDatabaseOperation)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,5 +1,631 @@
 package repository_test
 
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/model"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/internal/repository"
+	"github.com/go-park-mail-ru/2025_1_VelvetPulls/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var messageColumns = []string{
+	"id", "parent_message_id", "chat_id", "user_id", "body", "sent_at", "is_redacted",
+	"avatar_path", "username", "message_type", "sticker_path", "reactions",
+	"parent_id", "parent_user_id", "parent_username", "parent_body", "parent_message_type", "reply_count",
+	"forwarded_from_user_id", "forwarded_username", "forwarded_from_chat_id", "forwarded_sent_at",
+	"expires_at", "edit_count", "edited_at", "deleted_at",
+	"entities", "link_preview", "poll",
+}
+
+func TestGetMessage_WithReactions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectQuery(`(?s)SELECT.*FROM message_reaction.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(messageID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			messageID, nil, chatID, userID, "hello", time.Now(), false,
+			nil, "test_user", "default", nil, []byte(`[{"reaction":"👍","count":2},{"reaction":"🔥","count":1}]`),
+			nil, nil, nil, nil, nil, 0,
+			nil, nil, nil, nil,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			[]byte(`{"url":"https://example.com","title":"Example"}`),
+			nil,
+		))
+
+	msg, err := repo.GetMessage(context.Background(), messageID)
+	require.NoError(t, err)
+	require.Len(t, msg.Reactions, 2)
+	assert.Equal(t, "👍", msg.Reactions[0].Reaction)
+	assert.Equal(t, 2, msg.Reactions[0].Count)
+	require.NotNil(t, msg.LinkPreview)
+	assert.Equal(t, "Example", msg.LinkPreview.Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetReplies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	parentID := uuid.New()
+	parentUserID := uuid.New()
+	replyID := uuid.New()
+	afterID := uuid.New()
+	viewerID := uuid.New()
+
+	mock.ExpectQuery(`(?s)WITH ref_message.*WHERE m.parent_message_id = \$1.*FROM message_hidden h.*ORDER BY m.sent_at ASC`).
+		WithArgs(parentID, afterID, 25, viewerID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			replyID, parentID, chatID, uuid.New(), "reply", time.Now(), false,
+			nil, "replier", "default", nil, []byte(`[]`),
+			parentID, parentUserID, "author", "original", "default", 0,
+			nil, nil, nil, nil,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			nil,
+			nil,
+		))
+
+	replies, err := repo.GetReplies(context.Background(), parentID, viewerID, &afterID)
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+	require.NotNil(t, replies[0].Parent)
+	assert.Equal(t, parentID, *replies[0].ParentMessageID)
+	assert.Equal(t, "original", replies[0].Parent.Body)
+	assert.Equal(t, "author", replies[0].Parent.Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestForwardMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	userID := uuid.New()
+	authorID := uuid.New()
+	fromChatID := uuid.New()
+	toChatID := uuid.New()
+	srcID := uuid.New()
+	newID := uuid.New()
+	originalSentAt := time.Now().Add(-time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)SELECT id FROM message.*WHERE chat_id = \$1 AND id = ANY`).
+		WithArgs(fromChatID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(srcID))
+	mock.ExpectQuery(`(?s)INSERT INTO message \(.*forwarded_from_user_id`).
+		WithArgs(srcID, userID, toChatID, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newID))
+	mock.ExpectExec(`(?s)INSERT INTO message_payload`).
+		WithArgs(newID, srcID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`(?s)INSERT INTO message_entity.*FROM message_entity.*type <> 'mention'`).
+		WithArgs(newID, srcID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`(?s)INSERT INTO poll .*FROM poll.*INSERT INTO poll_option`).
+		WithArgs(newID, srcID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)SELECT.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(newID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			newID, nil, toChatID, userID, "hello", time.Now(), false,
+			nil, "forwarder", "default", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			authorID, "author", fromChatID, originalSentAt,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			nil,
+			nil,
+		))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	forwarded, err := repo.ForwardMessages(ctx, userID, fromChatID, toChatID, []uuid.UUID{srcID})
+	require.NoError(t, err)
+	require.Len(t, forwarded, 1)
+	require.NotNil(t, forwarded[0].Forward)
+	assert.Equal(t, authorID, *forwarded[0].Forward.UserID)
+	assert.Equal(t, fromChatID, *forwarded[0].Forward.ChatID)
+	assert.Equal(t, "author", forwarded[0].Forward.Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestForwardMessages_NotInChat(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	fromChatID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)SELECT id FROM message.*WHERE chat_id = \$1 AND id = ANY`).
+		WithArgs(fromChatID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, err = repo.ForwardMessages(ctx, uuid.New(), fromChatID, uuid.New(), []uuid.UUID{uuid.New()})
+	assert.ErrorIs(t, err, repository.ErrMessagesNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+var scheduledColumns = []string{
+	"id", "chat_id", "user_id", "body", "sticker_path", "parent_message_id", "payloads", "entities", "send_at", "ttl", "created_at",
+}
+
+func TestDispatchDueMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	authorID := uuid.New()
+	leftID := uuid.New()
+	dueID := uuid.New()
+	droppedID := uuid.New()
+	newID := uuid.New()
+	sendAt := time.Now().Add(-time.Minute)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)FROM scheduled_message.*WHERE send_at <= CURRENT_TIMESTAMP.*FOR UPDATE SKIP LOCKED`).
+		WithArgs(100).
+		WillReturnRows(sqlmock.NewRows(scheduledColumns).
+			AddRow(dueID, chatID, authorID, "later", nil, nil, []byte(`[]`), []byte(`[]`), sendAt, nil, sendAt).
+			AddRow(droppedID, chatID, leftID, "gone", nil, nil,
+				[]byte(`[{"URL":"/files/dropped","Filename":"a.txt","ContentType":"file","Size":1}]`), []byte(`[]`), sendAt, nil, sendAt))
+
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs(chatID, authorID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery(`(?s)INSERT INTO message \(user_id, chat_id, body`).
+		WithArgs(authorID, chatID, "later", "default", "", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newID))
+	mock.ExpectExec(`DELETE FROM scheduled_message`).
+		WithArgs(dueID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs(chatID, leftID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(`DELETE FROM scheduled_message`).
+		WithArgs(droppedID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectQuery(`(?s)SELECT.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(newID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			newID, nil, chatID, authorID, "later", time.Now(), false,
+			nil, "author", "default", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			nil, nil, nil, nil,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			nil,
+			nil,
+		))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	sent, dropped, err := repo.DispatchDueMessages(ctx, 100)
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	assert.Equal(t, newID, sent[0].ID)
+	assert.Equal(t, []string{"/files/dropped"}, dropped)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteExpiredMessages(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	firstID := uuid.New()
+	secondID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)SELECT id, chat_id.*WHERE expires_at <= CURRENT_TIMESTAMP.*FOR UPDATE SKIP LOCKED`).
+		WithArgs(500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id"}).
+			AddRow(firstID, chatID).
+			AddRow(secondID, chatID))
+	mock.ExpectQuery(`(?s)DELETE FROM message WHERE id = ANY.*SELECT DISTINCT p.file_path`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"file_path"}).AddRow("/files/abc"))
+	mock.ExpectCommit()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	deleted, files, err := repo.DeleteExpiredMessages(ctx, 500)
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	assert.Equal(t, firstID, deleted[0].ID)
+	assert.Equal(t, chatID, deleted[1].ChatID)
+	assert.Equal(t, []string{"/files/abc"}, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteExpiredMessages_Nothing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)WHERE expires_at <= CURRENT_TIMESTAMP`).
+		WithArgs(500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chat_id"}))
+	mock.ExpectRollback()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	deleted, files, err := repo.DeleteExpiredMessages(ctx, 500)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+	assert.Empty(t, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateMessage_StoresVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	chatID := uuid.New()
+	editedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)WITH prev AS.*FOR UPDATE.*INSERT INTO message_version.*UPDATE message m`).
+		WithArgs("fixed", messageID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(messageID))
+	mock.ExpectExec(`DELETE FROM message_entity WHERE message_id = \$1`).
+		WithArgs(messageID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)SELECT.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(messageID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			messageID, nil, chatID, uuid.New(), "fixed", time.Now(), true,
+			nil, "author", "default", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			nil, nil, nil, nil,
+			nil, 1, editedAt, nil,
+			[]byte(`[]`),
+			nil,
+			nil,
+		))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	msg, err := repo.UpdateMessage(ctx, messageID, "fixed", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, msg.EditCount)
+	require.NotNil(t, msg.EditedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetMessageRevisions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	sentAt := time.Now().Add(-time.Hour)
+	firstEdit := time.Now().Add(-30 * time.Minute)
+
+	mock.ExpectQuery(`(?s)SELECT version, body, created_at, replaced_at.*FROM message_version.*ORDER BY version`).
+		WithArgs(messageID).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "body", "created_at", "replaced_at"}).
+			AddRow(1, "original", sentAt, firstEdit).
+			AddRow(2, "second", firstEdit, time.Now()))
+
+	revisions, err := repo.GetMessageRevisions(context.Background(), messageID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, "original", revisions[0].Body)
+	assert.Equal(t, 2, revisions[1].Version)
+	require.NotNil(t, revisions[0].ReplacedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMessage_LeavesTombstone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	chatID := uuid.New()
+	deletedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)UPDATE message.*SET body = ''.*deleted_at = CURRENT_TIMESTAMP.*WHERE id = \$1 AND deleted_at IS NULL`).
+		WithArgs(messageID).
+		WillReturnRows(sqlmock.NewRows([]string{"chat_id", "deleted_at"}).AddRow(chatID, deletedAt))
+	mock.ExpectQuery(`(?s)DELETE FROM message_payload WHERE message_id = \$1.*other.message_id <> \$1`).
+		WithArgs(messageID).
+		WillReturnRows(sqlmock.NewRows([]string{"files"}).AddRow("{/files/a}"))
+	for _, table := range []string{"message_reaction", "message_version", "message_entity", "poll", "pinned_message"} {
+		mock.ExpectExec(`DELETE FROM ` + table + ` WHERE message_id = \$1`).
+			WithArgs(messageID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	deleted, files, err := repo.DeleteMessage(ctx, messageID)
+	require.NoError(t, err)
+	assert.Equal(t, chatID, deleted.ChatID)
+	require.NotNil(t, deleted.DeletedAt)
+	assert.Equal(t, []string{"/files/a"}, files)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteMessage_AlreadyDeleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+	messageID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)UPDATE message.*deleted_at IS NULL`).
+		WithArgs(messageID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, _, err = repo.DeleteMessage(ctx, messageID)
+	assert.ErrorIs(t, err, repository.ErrMessagesNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHideMessage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectExec(`(?s)INSERT INTO message_hidden \(message_id, user_id\).*ON CONFLICT DO NOTHING`).
+		WithArgs(messageID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.HideMessage(context.Background(), messageID, userID))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMessage_DuplicateClientID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	newID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO message \(user_id, chat_id, body`).
+		WithArgs(userID, chatID, "hi", "default", "", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newID))
+	mock.ExpectQuery(`(?s)INSERT INTO message_client_id.*ON CONFLICT.*WHERE message_client_id.created_at <`).
+		WithArgs(userID, chatID, "retry-1", newID, 86400).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, err = repo.CreateMessage(ctx, &model.Message{
+		UserID:          userID,
+		ChatID:          chatID,
+		Body:            "hi",
+		ClientMessageID: "retry-1",
+	})
+	assert.ErrorIs(t, err, repository.ErrDuplicateClientMessageID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMessage_RollsBackOnPayloadFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	newID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO message \(user_id, chat_id, body`).
+		WithArgs(userID, chatID, "", "with_payload", "", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newID))
+	mock.ExpectExec(`INSERT INTO message_payload`).
+		WithArgs(sqlmock.AnyArg(), newID, "/files/a", "a.txt", "file", int64(3), nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO message_payload`).
+		WithArgs(sqlmock.AnyArg(), newID, "/files/b", "b.png", "photo", int64(5), nil, nil).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, err = repo.CreateMessage(ctx, &model.Message{
+		UserID:    userID,
+		ChatID:    chatID,
+		FilesDTO:  []model.Payload{{URL: "/files/a", Filename: "a.txt", ContentType: "file", Size: 3}},
+		PhotosDTO: []model.Payload{{URL: "/files/b", Filename: "b.png", ContentType: "photo", Size: 5}},
+	})
+	assert.ErrorIs(t, err, repository.ErrDatabaseOperation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMessage_StoresVoice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	newID := uuid.New()
+	waveform := []byte{0, 128, 255}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO message \(user_id, chat_id, body`).
+		WithArgs(userID, chatID, "", "with_payload", "", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newID))
+	mock.ExpectExec(`INSERT INTO message_payload .*duration_ms, waveform`).
+		WithArgs(sqlmock.AnyArg(), newID, "/files/v", "voice.ogg", "voice", int64(1234), 4200, waveform).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)SELECT.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(newID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			newID, nil, chatID, userID, "", time.Now(), false,
+			nil, "author", "with_payload", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			nil, nil, nil, nil,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			nil,
+			nil,
+		))
+	mock.ExpectQuery(`FROM public.message_payload`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "file_path", "file_name", "content_type", "file_size", "duration_ms", "waveform"}).
+			AddRow(newID, "/files/v", "voice.ogg", "voice", 1234, 4200, waveform))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	msg, err := repo.CreateMessage(ctx, &model.Message{
+		UserID:   userID,
+		ChatID:   chatID,
+		VoiceDTO: &model.Payload{URL: "/files/v", Filename: "voice.ogg", ContentType: "voice", Size: 1234, DurationMs: 4200, Waveform: waveform},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, msg.VoiceDTO)
+	assert.Equal(t, 4200, msg.VoiceDTO.DurationMs)
+	assert.Equal(t, waveform, msg.VoiceDTO.Waveform)
+	assert.Empty(t, msg.FilesDTO)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindMessageByClientID_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+
+	mock.ExpectQuery(`(?s)SELECT message_id.*FROM message_client_id.*created_at >=`).
+		WithArgs(userID, chatID, "retry-1", 86400).
+		WillReturnError(sql.ErrNoRows)
+
+	msg, err := repo.FindMessageByClientID(context.Background(), userID, chatID, "retry-1")
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetReaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectExec(`(?s)INSERT INTO message_reaction.*ON CONFLICT \(message_id, user_id\)`).
+		WithArgs(messageID, userID, "🔥").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SetReaction(context.Background(), messageID, userID, "🔥")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	userID := uuid.New()
+	messageID := uuid.New()
+
+	mock.ExpectExec(`(?s)INSERT INTO message_view.*ON CONFLICT \(message_id, user_id\) DO NOTHING`).
+		WithArgs(chatID, userID, messageID).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+
+	marked, err := repo.MarkRead(context.Background(), chatID, userID, messageID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), marked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteReaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectExec(`DELETE FROM message_reaction`).
+		WithArgs(messageID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.DeleteReaction(context.Background(), messageID, userID)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // func TestCreateMessage(t *testing.T) {
 // 	db, mock, err := sqlmock.New()
 // 	require.NoError(t, err)
@@ -73,3 +699,212 @@ package repository_test
 // 	require.Equal(t, body, result[0].Body)
 // 	require.Equal(t, username, result[0].Username)
 // }
+
+func TestCreateMessage_StoresMentions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	mentionedID := uuid.New()
+	newID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO message \(user_id, chat_id, body`).
+		WithArgs(userID, chatID, "hi @alice", "default", "", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newID))
+	mock.ExpectExec(`(?s)INSERT INTO message_entity.*jsonb_to_recordset`).
+		WithArgs(newID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)SELECT.*FROM message_entity me.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(newID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			newID, nil, chatID, userID, "hi @alice", time.Now(), false,
+			nil, "author", "default", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			nil, nil, nil, nil,
+			nil, 0, nil, nil,
+			[]byte(`[{"type":"mention","offset":3,"length":6,"user_id":"`+mentionedID.String()+`"}]`),
+			nil,
+			nil,
+		))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	msg, err := repo.CreateMessage(ctx, &model.Message{
+		UserID: userID,
+		ChatID: chatID,
+		Body:   "hi @alice",
+		Entities: []model.MessageEntity{
+			{Type: model.EntityMention, Offset: 3, Length: 6, UserID: &mentionedID},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, msg.Entities, 1)
+	assert.Equal(t, model.EntityMention, msg.Entities[0].Type)
+	require.NotNil(t, msg.Entities[0].UserID)
+	assert.Equal(t, mentionedID, *msg.Entities[0].UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetNextUnreadMention_None(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	chatID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectQuery(`(?s)FROM message m.*me.type = 'mention'.*FROM message_view mv.*ORDER BY m.sent_at, m.id\s+LIMIT 1`).
+		WithArgs(chatID, userID, nil).
+		WillReturnError(sql.ErrNoRows)
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	_, err = repo.GetNextUnreadMention(ctx, chatID, userID, nil)
+	assert.ErrorIs(t, err, repository.ErrMessagesNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachLinkPreview(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	preview := model.LinkPreview{URL: "https://example.com", Title: "Example"}
+
+	mock.ExpectExec(`(?s)INSERT INTO link_preview.*ON CONFLICT \(url\) DO UPDATE.*UPDATE message.*WHERE id = \$6 AND edit_count = \$7 AND deleted_at IS NULL`).
+		WithArgs("https://example.com", "Example", "", "", "", messageID, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	require.NoError(t, repo.AttachLinkPreview(ctx, messageID, 2, preview))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachLinkPreview_MessageEdited(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+
+	mock.ExpectExec(`(?s)INSERT INTO link_preview.*UPDATE message`).
+		WithArgs("https://example.com", "Example", "", "", "", messageID, 0).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	err = repo.AttachLinkPreview(ctx, messageID, 0, model.LinkPreview{URL: "https://example.com", Title: "Example"})
+	assert.ErrorIs(t, err, repository.ErrMessagesNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateMessage_StoresPoll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	userID := uuid.New()
+	chatID := uuid.New()
+	newID := uuid.New()
+	poll := &model.PollInput{Question: "Где встречаемся?", Options: []string{"Офис", "Кафе"}, MultipleChoice: true}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)INSERT INTO message \(user_id, chat_id, body`).
+		WithArgs(userID, chatID, "Где встречаемся?", "poll", "", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newID))
+	mock.ExpectExec(`(?s)INSERT INTO poll \(.*INSERT INTO poll_option.*unnest\(\$6::text\[\]\) WITH ORDINALITY`).
+		WithArgs(newID, "Где встречаемся?", true, false, nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)SELECT.*FROM poll p.*FROM message m.*WHERE m.id = \$1`).
+		WithArgs(newID).
+		WillReturnRows(sqlmock.NewRows(messageColumns).AddRow(
+			newID, nil, chatID, userID, "Где встречаемся?", time.Now(), false,
+			nil, "author", "poll", nil, []byte(`[]`),
+			nil, nil, nil, nil, nil, 0,
+			nil, nil, nil, nil,
+			nil, 0, nil, nil,
+			[]byte(`[]`),
+			nil,
+			[]byte(`{"question":"Где встречаемся?","multiple_choice":true,"anonymous":false,"closes_at":null,"closed":false,"total_voters":1,`+
+				`"options":[{"position":0,"text":"Офис","votes":1,"voters":["`+userID.String()+`"]},{"position":1,"text":"Кафе","votes":0}]}`),
+		))
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	msg, err := repo.CreateMessage(ctx, &model.Message{
+		UserID:    userID,
+		ChatID:    chatID,
+		Body:      poll.Question,
+		PollInput: poll,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, msg.Poll)
+	assert.True(t, msg.Poll.MultipleChoice)
+	assert.Nil(t, msg.Poll.ClosesAt)
+	assert.Equal(t, 1, msg.Poll.TotalVoters)
+	require.Len(t, msg.Poll.Options, 2)
+	assert.Equal(t, []uuid.UUID{userID}, msg.Poll.Options[0].Voters)
+	assert.Empty(t, msg.Poll.Options[1].Voters)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVotePoll(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM poll_vote WHERE message_id = \$1 AND user_id = \$2`).
+		WithArgs(messageID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`(?s)INSERT INTO poll_vote.*unnest\(\$3::int\[\]\).*closes_at > CURRENT_TIMESTAMP`).
+		WithArgs(messageID, userID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	require.NoError(t, repo.VotePoll(ctx, messageID, userID, []int{0, 2}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVotePoll_Closed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewMessageRepo(db)
+
+	messageID := uuid.New()
+	userID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM poll_vote`).
+		WithArgs(messageID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`(?s)INSERT INTO poll_vote`).
+		WithArgs(messageID, userID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	ctx := utils.WithLogger(context.Background(), zap.NewNop())
+	err = repo.VotePoll(ctx, messageID, userID, []int{1})
+	assert.ErrorIs(t, err, repository.ErrPollClosed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}